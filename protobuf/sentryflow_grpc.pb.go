@@ -19,9 +19,21 @@ import (
 const _ = grpc.SupportPackageIsVersion7
 
 const (
-	SentryFlow_GetAPILog_FullMethodName       = "/protobuf.SentryFlow/GetAPILog"
-	SentryFlow_GetAPIMetrics_FullMethodName   = "/protobuf.SentryFlow/GetAPIMetrics"
-	SentryFlow_GetEnvoyMetrics_FullMethodName = "/protobuf.SentryFlow/GetEnvoyMetrics"
+	SentryFlow_GetAPILog_FullMethodName          = "/protobuf.SentryFlow/GetAPILog"
+	SentryFlow_GetAPIMetrics_FullMethodName      = "/protobuf.SentryFlow/GetAPIMetrics"
+	SentryFlow_GetEnvoyMetrics_FullMethodName    = "/protobuf.SentryFlow/GetEnvoyMetrics"
+	SentryFlow_GetAPIEvents_FullMethodName       = "/protobuf.SentryFlow/GetAPIEvents"
+	SentryFlow_GetAPIFindings_FullMethodName     = "/protobuf.SentryFlow/GetAPIFindings"
+	SentryFlow_GetAlerts_FullMethodName          = "/protobuf.SentryFlow/GetAlerts"
+	SentryFlow_GetRequestChains_FullMethodName   = "/protobuf.SentryFlow/GetRequestChains"
+	SentryFlow_ListAPIs_FullMethodName           = "/protobuf.SentryFlow/ListAPIs"
+	SentryFlow_GetAPI_FullMethodName             = "/protobuf.SentryFlow/GetAPI"
+	SentryFlow_GetOpenAPISpec_FullMethodName     = "/protobuf.SentryFlow/GetOpenAPISpec"
+	SentryFlow_GetServiceGraph_FullMethodName    = "/protobuf.SentryFlow/GetServiceGraph"
+	SentryFlow_GetRiskScores_FullMethodName      = "/protobuf.SentryFlow/GetRiskScores"
+	SentryFlow_ListEgress_FullMethodName         = "/protobuf.SentryFlow/ListEgress"
+	SentryFlow_GeneratePolicies_FullMethodName   = "/protobuf.SentryFlow/GeneratePolicies"
+	SentryFlow_GetSidecarCoverage_FullMethodName = "/protobuf.SentryFlow/GetSidecarCoverage"
 )
 
 // SentryFlowClient is the client API for SentryFlow service.
@@ -31,6 +43,18 @@ type SentryFlowClient interface {
 	GetAPILog(ctx context.Context, in *ClientInfo, opts ...grpc.CallOption) (SentryFlow_GetAPILogClient, error)
 	GetAPIMetrics(ctx context.Context, in *ClientInfo, opts ...grpc.CallOption) (SentryFlow_GetAPIMetricsClient, error)
 	GetEnvoyMetrics(ctx context.Context, in *ClientInfo, opts ...grpc.CallOption) (SentryFlow_GetEnvoyMetricsClient, error)
+	GetAPIEvents(ctx context.Context, in *ClientInfo, opts ...grpc.CallOption) (SentryFlow_GetAPIEventsClient, error)
+	GetAPIFindings(ctx context.Context, in *ClientInfo, opts ...grpc.CallOption) (SentryFlow_GetAPIFindingsClient, error)
+	GetAlerts(ctx context.Context, in *ClientInfo, opts ...grpc.CallOption) (SentryFlow_GetAlertsClient, error)
+	GetRequestChains(ctx context.Context, in *ClientInfo, opts ...grpc.CallOption) (SentryFlow_GetRequestChainsClient, error)
+	ListAPIs(ctx context.Context, in *APIQuery, opts ...grpc.CallOption) (*APIList, error)
+	GetAPI(ctx context.Context, in *APIQuery, opts ...grpc.CallOption) (*APIEndpoint, error)
+	GetOpenAPISpec(ctx context.Context, in *OpenAPIQuery, opts ...grpc.CallOption) (*OpenAPISpec, error)
+	GetServiceGraph(ctx context.Context, in *ServiceGraphQuery, opts ...grpc.CallOption) (*ServiceGraph, error)
+	GetRiskScores(ctx context.Context, in *RiskQuery, opts ...grpc.CallOption) (*RiskScores, error)
+	ListEgress(ctx context.Context, in *EgressQuery, opts ...grpc.CallOption) (*EgressList, error)
+	GeneratePolicies(ctx context.Context, in *PolicyQuery, opts ...grpc.CallOption) (*PolicySet, error)
+	GetSidecarCoverage(ctx context.Context, in *CoverageQuery, opts ...grpc.CallOption) (*CoverageReport, error)
 }
 
 type sentryFlowClient struct {
@@ -137,6 +161,206 @@ func (x *sentryFlowGetEnvoyMetricsClient) Recv() (*EnvoyMetrics, error) {
 	return m, nil
 }
 
+func (c *sentryFlowClient) GetAPIEvents(ctx context.Context, in *ClientInfo, opts ...grpc.CallOption) (SentryFlow_GetAPIEventsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &SentryFlow_ServiceDesc.Streams[3], SentryFlow_GetAPIEvents_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &sentryFlowGetAPIEventsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type SentryFlow_GetAPIEventsClient interface {
+	Recv() (*APIEvent, error)
+	grpc.ClientStream
+}
+
+type sentryFlowGetAPIEventsClient struct {
+	grpc.ClientStream
+}
+
+func (x *sentryFlowGetAPIEventsClient) Recv() (*APIEvent, error) {
+	m := new(APIEvent)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *sentryFlowClient) GetAPIFindings(ctx context.Context, in *ClientInfo, opts ...grpc.CallOption) (SentryFlow_GetAPIFindingsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &SentryFlow_ServiceDesc.Streams[4], SentryFlow_GetAPIFindings_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &sentryFlowGetAPIFindingsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type SentryFlow_GetAPIFindingsClient interface {
+	Recv() (*APIFinding, error)
+	grpc.ClientStream
+}
+
+type sentryFlowGetAPIFindingsClient struct {
+	grpc.ClientStream
+}
+
+func (x *sentryFlowGetAPIFindingsClient) Recv() (*APIFinding, error) {
+	m := new(APIFinding)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *sentryFlowClient) GetAlerts(ctx context.Context, in *ClientInfo, opts ...grpc.CallOption) (SentryFlow_GetAlertsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &SentryFlow_ServiceDesc.Streams[5], SentryFlow_GetAlerts_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &sentryFlowGetAlertsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type SentryFlow_GetAlertsClient interface {
+	Recv() (*Alert, error)
+	grpc.ClientStream
+}
+
+type sentryFlowGetAlertsClient struct {
+	grpc.ClientStream
+}
+
+func (x *sentryFlowGetAlertsClient) Recv() (*Alert, error) {
+	m := new(Alert)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *sentryFlowClient) GetRequestChains(ctx context.Context, in *ClientInfo, opts ...grpc.CallOption) (SentryFlow_GetRequestChainsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &SentryFlow_ServiceDesc.Streams[6], SentryFlow_GetRequestChains_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &sentryFlowGetRequestChainsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type SentryFlow_GetRequestChainsClient interface {
+	Recv() (*RequestChain, error)
+	grpc.ClientStream
+}
+
+type sentryFlowGetRequestChainsClient struct {
+	grpc.ClientStream
+}
+
+func (x *sentryFlowGetRequestChainsClient) Recv() (*RequestChain, error) {
+	m := new(RequestChain)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *sentryFlowClient) ListAPIs(ctx context.Context, in *APIQuery, opts ...grpc.CallOption) (*APIList, error) {
+	out := new(APIList)
+	err := c.cc.Invoke(ctx, SentryFlow_ListAPIs_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sentryFlowClient) GetAPI(ctx context.Context, in *APIQuery, opts ...grpc.CallOption) (*APIEndpoint, error) {
+	out := new(APIEndpoint)
+	err := c.cc.Invoke(ctx, SentryFlow_GetAPI_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sentryFlowClient) GetOpenAPISpec(ctx context.Context, in *OpenAPIQuery, opts ...grpc.CallOption) (*OpenAPISpec, error) {
+	out := new(OpenAPISpec)
+	err := c.cc.Invoke(ctx, SentryFlow_GetOpenAPISpec_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sentryFlowClient) GetServiceGraph(ctx context.Context, in *ServiceGraphQuery, opts ...grpc.CallOption) (*ServiceGraph, error) {
+	out := new(ServiceGraph)
+	err := c.cc.Invoke(ctx, SentryFlow_GetServiceGraph_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sentryFlowClient) GetRiskScores(ctx context.Context, in *RiskQuery, opts ...grpc.CallOption) (*RiskScores, error) {
+	out := new(RiskScores)
+	err := c.cc.Invoke(ctx, SentryFlow_GetRiskScores_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sentryFlowClient) ListEgress(ctx context.Context, in *EgressQuery, opts ...grpc.CallOption) (*EgressList, error) {
+	out := new(EgressList)
+	err := c.cc.Invoke(ctx, SentryFlow_ListEgress_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sentryFlowClient) GeneratePolicies(ctx context.Context, in *PolicyQuery, opts ...grpc.CallOption) (*PolicySet, error) {
+	out := new(PolicySet)
+	err := c.cc.Invoke(ctx, SentryFlow_GeneratePolicies_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sentryFlowClient) GetSidecarCoverage(ctx context.Context, in *CoverageQuery, opts ...grpc.CallOption) (*CoverageReport, error) {
+	out := new(CoverageReport)
+	err := c.cc.Invoke(ctx, SentryFlow_GetSidecarCoverage_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // SentryFlowServer is the server API for SentryFlow service.
 // All implementations should embed UnimplementedSentryFlowServer
 // for forward compatibility
@@ -144,6 +368,18 @@ type SentryFlowServer interface {
 	GetAPILog(*ClientInfo, SentryFlow_GetAPILogServer) error
 	GetAPIMetrics(*ClientInfo, SentryFlow_GetAPIMetricsServer) error
 	GetEnvoyMetrics(*ClientInfo, SentryFlow_GetEnvoyMetricsServer) error
+	GetAPIEvents(*ClientInfo, SentryFlow_GetAPIEventsServer) error
+	GetAPIFindings(*ClientInfo, SentryFlow_GetAPIFindingsServer) error
+	GetAlerts(*ClientInfo, SentryFlow_GetAlertsServer) error
+	GetRequestChains(*ClientInfo, SentryFlow_GetRequestChainsServer) error
+	ListAPIs(context.Context, *APIQuery) (*APIList, error)
+	GetAPI(context.Context, *APIQuery) (*APIEndpoint, error)
+	GetOpenAPISpec(context.Context, *OpenAPIQuery) (*OpenAPISpec, error)
+	GetServiceGraph(context.Context, *ServiceGraphQuery) (*ServiceGraph, error)
+	GetRiskScores(context.Context, *RiskQuery) (*RiskScores, error)
+	ListEgress(context.Context, *EgressQuery) (*EgressList, error)
+	GeneratePolicies(context.Context, *PolicyQuery) (*PolicySet, error)
+	GetSidecarCoverage(context.Context, *CoverageQuery) (*CoverageReport, error)
 }
 
 // UnimplementedSentryFlowServer should be embedded to have forward compatible implementations.
@@ -159,6 +395,42 @@ func (UnimplementedSentryFlowServer) GetAPIMetrics(*ClientInfo, SentryFlow_GetAP
 func (UnimplementedSentryFlowServer) GetEnvoyMetrics(*ClientInfo, SentryFlow_GetEnvoyMetricsServer) error {
 	return status.Errorf(codes.Unimplemented, "method GetEnvoyMetrics not implemented")
 }
+func (UnimplementedSentryFlowServer) GetAPIEvents(*ClientInfo, SentryFlow_GetAPIEventsServer) error {
+	return status.Errorf(codes.Unimplemented, "method GetAPIEvents not implemented")
+}
+func (UnimplementedSentryFlowServer) GetAPIFindings(*ClientInfo, SentryFlow_GetAPIFindingsServer) error {
+	return status.Errorf(codes.Unimplemented, "method GetAPIFindings not implemented")
+}
+func (UnimplementedSentryFlowServer) GetAlerts(*ClientInfo, SentryFlow_GetAlertsServer) error {
+	return status.Errorf(codes.Unimplemented, "method GetAlerts not implemented")
+}
+func (UnimplementedSentryFlowServer) GetRequestChains(*ClientInfo, SentryFlow_GetRequestChainsServer) error {
+	return status.Errorf(codes.Unimplemented, "method GetRequestChains not implemented")
+}
+func (UnimplementedSentryFlowServer) ListAPIs(context.Context, *APIQuery) (*APIList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListAPIs not implemented")
+}
+func (UnimplementedSentryFlowServer) GetAPI(context.Context, *APIQuery) (*APIEndpoint, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAPI not implemented")
+}
+func (UnimplementedSentryFlowServer) GetOpenAPISpec(context.Context, *OpenAPIQuery) (*OpenAPISpec, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetOpenAPISpec not implemented")
+}
+func (UnimplementedSentryFlowServer) GetServiceGraph(context.Context, *ServiceGraphQuery) (*ServiceGraph, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetServiceGraph not implemented")
+}
+func (UnimplementedSentryFlowServer) GetRiskScores(context.Context, *RiskQuery) (*RiskScores, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRiskScores not implemented")
+}
+func (UnimplementedSentryFlowServer) ListEgress(context.Context, *EgressQuery) (*EgressList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListEgress not implemented")
+}
+func (UnimplementedSentryFlowServer) GeneratePolicies(context.Context, *PolicyQuery) (*PolicySet, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GeneratePolicies not implemented")
+}
+func (UnimplementedSentryFlowServer) GetSidecarCoverage(context.Context, *CoverageQuery) (*CoverageReport, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSidecarCoverage not implemented")
+}
 
 // UnsafeSentryFlowServer may be embedded to opt out of forward compatibility for this service.
 // Use of this interface is not recommended, as added methods to SentryFlowServer will
@@ -234,13 +506,274 @@ func (x *sentryFlowGetEnvoyMetricsServer) Send(m *EnvoyMetrics) error {
 	return x.ServerStream.SendMsg(m)
 }
 
+func _SentryFlow_GetAPIEvents_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ClientInfo)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(SentryFlowServer).GetAPIEvents(m, &sentryFlowGetAPIEventsServer{stream})
+}
+
+type SentryFlow_GetAPIEventsServer interface {
+	Send(*APIEvent) error
+	grpc.ServerStream
+}
+
+type sentryFlowGetAPIEventsServer struct {
+	grpc.ServerStream
+}
+
+func (x *sentryFlowGetAPIEventsServer) Send(m *APIEvent) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _SentryFlow_GetAPIFindings_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ClientInfo)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(SentryFlowServer).GetAPIFindings(m, &sentryFlowGetAPIFindingsServer{stream})
+}
+
+type SentryFlow_GetAPIFindingsServer interface {
+	Send(*APIFinding) error
+	grpc.ServerStream
+}
+
+type sentryFlowGetAPIFindingsServer struct {
+	grpc.ServerStream
+}
+
+func (x *sentryFlowGetAPIFindingsServer) Send(m *APIFinding) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _SentryFlow_GetAlerts_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ClientInfo)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(SentryFlowServer).GetAlerts(m, &sentryFlowGetAlertsServer{stream})
+}
+
+type SentryFlow_GetAlertsServer interface {
+	Send(*Alert) error
+	grpc.ServerStream
+}
+
+type sentryFlowGetAlertsServer struct {
+	grpc.ServerStream
+}
+
+func (x *sentryFlowGetAlertsServer) Send(m *Alert) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _SentryFlow_GetRequestChains_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ClientInfo)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(SentryFlowServer).GetRequestChains(m, &sentryFlowGetRequestChainsServer{stream})
+}
+
+type SentryFlow_GetRequestChainsServer interface {
+	Send(*RequestChain) error
+	grpc.ServerStream
+}
+
+type sentryFlowGetRequestChainsServer struct {
+	grpc.ServerStream
+}
+
+func (x *sentryFlowGetRequestChainsServer) Send(m *RequestChain) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _SentryFlow_ListAPIs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(APIQuery)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SentryFlowServer).ListAPIs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SentryFlow_ListAPIs_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SentryFlowServer).ListAPIs(ctx, req.(*APIQuery))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SentryFlow_GetAPI_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(APIQuery)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SentryFlowServer).GetAPI(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SentryFlow_GetAPI_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SentryFlowServer).GetAPI(ctx, req.(*APIQuery))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SentryFlow_GetOpenAPISpec_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(OpenAPIQuery)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SentryFlowServer).GetOpenAPISpec(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SentryFlow_GetOpenAPISpec_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SentryFlowServer).GetOpenAPISpec(ctx, req.(*OpenAPIQuery))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SentryFlow_GetServiceGraph_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ServiceGraphQuery)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SentryFlowServer).GetServiceGraph(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SentryFlow_GetServiceGraph_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SentryFlowServer).GetServiceGraph(ctx, req.(*ServiceGraphQuery))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SentryFlow_GetRiskScores_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RiskQuery)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SentryFlowServer).GetRiskScores(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SentryFlow_GetRiskScores_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SentryFlowServer).GetRiskScores(ctx, req.(*RiskQuery))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SentryFlow_ListEgress_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EgressQuery)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SentryFlowServer).ListEgress(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SentryFlow_ListEgress_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SentryFlowServer).ListEgress(ctx, req.(*EgressQuery))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SentryFlow_GeneratePolicies_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PolicyQuery)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SentryFlowServer).GeneratePolicies(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SentryFlow_GeneratePolicies_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SentryFlowServer).GeneratePolicies(ctx, req.(*PolicyQuery))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SentryFlow_GetSidecarCoverage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CoverageQuery)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SentryFlowServer).GetSidecarCoverage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SentryFlow_GetSidecarCoverage_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SentryFlowServer).GetSidecarCoverage(ctx, req.(*CoverageQuery))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // SentryFlow_ServiceDesc is the grpc.ServiceDesc for SentryFlow service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
 var SentryFlow_ServiceDesc = grpc.ServiceDesc{
 	ServiceName: "protobuf.SentryFlow",
 	HandlerType: (*SentryFlowServer)(nil),
-	Methods:     []grpc.MethodDesc{},
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListAPIs",
+			Handler:    _SentryFlow_ListAPIs_Handler,
+		},
+		{
+			MethodName: "GetAPI",
+			Handler:    _SentryFlow_GetAPI_Handler,
+		},
+		{
+			MethodName: "GetOpenAPISpec",
+			Handler:    _SentryFlow_GetOpenAPISpec_Handler,
+		},
+		{
+			MethodName: "GetServiceGraph",
+			Handler:    _SentryFlow_GetServiceGraph_Handler,
+		},
+		{
+			MethodName: "GetRiskScores",
+			Handler:    _SentryFlow_GetRiskScores_Handler,
+		},
+		{
+			MethodName: "ListEgress",
+			Handler:    _SentryFlow_ListEgress_Handler,
+		},
+		{
+			MethodName: "GeneratePolicies",
+			Handler:    _SentryFlow_GeneratePolicies_Handler,
+		},
+		{
+			MethodName: "GetSidecarCoverage",
+			Handler:    _SentryFlow_GetSidecarCoverage_Handler,
+		},
+	},
 	Streams: []grpc.StreamDesc{
 		{
 			StreamName:    "GetAPILog",
@@ -257,6 +790,26 @@ var SentryFlow_ServiceDesc = grpc.ServiceDesc{
 			Handler:       _SentryFlow_GetEnvoyMetrics_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "GetAPIEvents",
+			Handler:       _SentryFlow_GetAPIEvents_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "GetAPIFindings",
+			Handler:       _SentryFlow_GetAPIFindings_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "GetAlerts",
+			Handler:       _SentryFlow_GetAlerts_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "GetRequestChains",
+			Handler:       _SentryFlow_GetRequestChains_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "sentryflow.proto",
 }
@@ -0,0 +1,161 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package anomaly
+
+import (
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/5gsec/SentryFlow/config"
+	"github.com/5gsec/SentryFlow/internal/detection"
+	"github.com/5gsec/SentryFlow/inventory"
+	"github.com/5gsec/SentryFlow/protobuf"
+)
+
+// == //
+
+// bucketPeriod is the period of traffic aggregated into a sample of the baselines
+const bucketPeriod = time.Minute
+
+// maxBaselines is the maximum number of (caller, endpoint) pairs to learn
+const maxBaselines = 10000
+
+// baselineExpiry is the period without traffic after which a pair is forgotten
+const baselineExpiry = 7 * 24 * time.Hour
+
+// AnomalyD global reference for Anomaly Detector
+var AnomalyD *AnomalyDetector
+
+// init Function
+func init() {
+	AnomalyD = NewAnomalyDetector()
+}
+
+// AnomalyDetector Structure
+type AnomalyDetector struct {
+	stopChan chan struct{}
+
+	baselines     map[string]*pairBaseline
+	baselinesLock sync.Mutex
+
+	report func(*protobuf.APIFinding)
+}
+
+// NewAnomalyDetector Function
+func NewAnomalyDetector() *AnomalyDetector {
+	ad := &AnomalyDetector{
+		stopChan: make(chan struct{}),
+
+		baselines:     make(map[string]*pairBaseline),
+		baselinesLock: sync.Mutex{},
+	}
+
+	return ad
+}
+
+// == //
+
+// StartAnomalyDetector Function
+func StartAnomalyDetector(wg *sync.WaitGroup, report func(*protobuf.APIFinding)) bool {
+	AnomalyD.report = report
+
+	// compare traffic with baselines periodically
+	go detectAnomalies(wg)
+
+	log.Printf("[AnomalyDetector] Started Anomaly Detector (learning for %ds)", config.GlobalConfig.AnomalyLearningPeriod)
+
+	return true
+}
+
+// StopAnomalyDetector Function
+func StopAnomalyDetector() bool {
+	close(AnomalyD.stopChan)
+
+	log.Print("[AnomalyDetector] Stopped Anomaly Detector")
+
+	return true
+}
+
+// detectAnomalies Function
+func detectAnomalies(wg *sync.WaitGroup) {
+	wg.Add(1)
+
+	ticker := time.NewTicker(bucketPeriod)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			for _, finding := range AnomalyD.closeBuckets(time.Now()) {
+				if AnomalyD.report != nil {
+					go AnomalyD.report(finding)
+				}
+			}
+		case <-AnomalyD.stopChan:
+			wg.Done()
+			return
+		}
+	}
+}
+
+// == //
+
+// ObserveAPILog Function that adds an API log to the baseline of its (caller, endpoint) pair
+func ObserveAPILog(apiLog *protobuf.APILog) {
+	caller := fmt.Sprintf("%s/%s", apiLog.SrcNamespace, inventory.SrcWorkload(apiLog))
+	workload := inventory.DstWorkload(apiLog)
+	endpoint, _ := inventory.EndpointTemplate(apiLog)
+
+	key := fmt.Sprintf("%s -> %s/%s %s %s", caller, apiLog.DstNamespace, workload, apiLog.Method, endpoint)
+	now := time.Now()
+
+	AnomalyD.baselinesLock.Lock()
+	defer AnomalyD.baselinesLock.Unlock()
+
+	pb, ok := AnomalyD.baselines[key]
+	if !ok {
+		if len(AnomalyD.baselines) >= maxBaselines {
+			return
+		}
+
+		pb = &pairBaseline{
+			caller:       caller,
+			namespace:    apiLog.DstNamespace,
+			workload:     workload,
+			method:       apiLog.Method,
+			pathTemplate: endpoint,
+
+			firstSeen: now,
+			reports:   detection.NewSuppressor(reportPeriod),
+		}
+		AnomalyD.baselines[key] = pb
+	}
+
+	pb.observe(apiLog, now)
+}
+
+// closeBuckets Function that closes the current bucket of every pair
+func (ad *AnomalyDetector) closeBuckets(now time.Time) []*protobuf.APIFinding {
+	learningPeriod := time.Duration(config.GlobalConfig.AnomalyLearningPeriod) * time.Second
+	threshold := config.GlobalConfig.AnomalyThreshold
+
+	ad.baselinesLock.Lock()
+	defer ad.baselinesLock.Unlock()
+
+	findings := make([]*protobuf.APIFinding, 0)
+
+	for key, pb := range ad.baselines {
+		if now.Sub(pb.lastSeen) > baselineExpiry {
+			delete(ad.baselines, key)
+			continue
+		}
+
+		findings = append(findings, pb.closeBucket(now, learningPeriod, threshold)...)
+	}
+
+	return findings
+}
+
+// == //
@@ -0,0 +1,214 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package anomaly
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/5gsec/SentryFlow/internal/detection"
+	"github.com/5gsec/SentryFlow/inventory"
+	"github.com/5gsec/SentryFlow/protobuf"
+	"github.com/5gsec/SentryFlow/types"
+)
+
+// == //
+
+// Types of anomaly findings
+const (
+	FindingCategoryAnomaly = "Anomaly"
+
+	FindingTypeRateSpike       = "RateSpike"
+	FindingTypeRateDrop        = "RateDrop"
+	FindingTypeErrorRatioSpike = "ErrorRatioSpike"
+	FindingTypeLatencySpike    = "LatencySpike"
+)
+
+const (
+	// alpha is the smoothing factor of the recent baselines (about the last 20 buckets)
+	alpha = 0.1
+
+	// seasonalAlpha is the smoothing factor of the time-of-day baselines (about the same hour of the last days)
+	seasonalAlpha = 0.02
+
+	// seasonalMinSamples is the number of buckets required before using a time-of-day baseline
+	seasonalMinSamples = 120
+
+	// minSamples is the number of buckets required before using a baseline
+	minSamples = 10
+
+	// minRequests is the number of requests in a bucket required to check its error ratio, latency or spike
+	minRequests = 10
+
+	// minimum standard deviations so that steady traffic does not make every change an anomaly
+	minRateStdDev       = 1.0
+	minErrorRatioStdDev = 0.02
+	minLatencyStdDev    = 5.0
+
+	// minErrorRatio is the error ratio below which errors are not reported
+	minErrorRatio = 0.05
+
+	// reportPeriod is the period for reporting the same anomaly of a pair again
+	reportPeriod = 15 * time.Minute
+)
+
+// == //
+
+// bucket Structure
+type bucket struct {
+	requests   uint64
+	errors     uint64
+	latencySum uint64
+	sample     *protobuf.APILog
+}
+
+// pairBaseline Structure (baseline of the traffic from a caller to an endpoint)
+type pairBaseline struct {
+	caller       string
+	namespace    string
+	workload     string
+	method       string
+	pathTemplate string
+
+	current bucket
+
+	rate       detection.EWMA
+	errorRatio detection.EWMA
+	latency    detection.EWMA
+	hourlyRate [24]detection.EWMA
+
+	firstSeen time.Time
+	lastSeen  time.Time
+
+	reports *detection.Suppressor // findings reported by type
+}
+
+// observe Function
+func (pb *pairBaseline) observe(apiLog *protobuf.APILog, now time.Time) {
+	pb.current.requests++
+	if inventory.IsServerError(apiLog) {
+		pb.current.errors++
+	}
+	pb.current.latencySum += apiLog.Latency
+	pb.current.sample = apiLog
+
+	pb.lastSeen = now
+}
+
+// newFinding Function
+func (pb *pairBaseline) newFinding(findingType string, score, threshold, observed, expected, stdDev float64, basis, description string, sample *protobuf.APILog, now time.Time) *protobuf.APIFinding {
+	severity := types.SeverityLow
+	if score >= 2*threshold {
+		severity = types.SeverityHigh
+	} else if score >= 1.5*threshold {
+		severity = types.SeverityMedium
+	}
+
+	finding := &protobuf.APIFinding{
+		TimeStamp:   strconv.FormatInt(now.Unix(), 10),
+		Category:    FindingCategoryAnomaly,
+		Type:        findingType,
+		Severity:    severity,
+		Description: description,
+		Score:       math.Round(score*100) / 100,
+
+		Namespace: pb.namespace,
+		Workload:  pb.workload,
+		Method:    pb.method,
+		Path:      pb.pathTemplate,
+
+		Evidence: map[string]string{
+			"caller":   pb.caller,
+			"observed": strconv.FormatFloat(observed, 'f', 2, 64),
+			"expected": strconv.FormatFloat(expected, 'f', 2, 64),
+			"stdDev":   strconv.FormatFloat(stdDev, 'f', 2, 64),
+			"baseline": basis,
+			"window":   bucketPeriod.String(),
+		},
+	}
+
+	if sample != nil {
+		finding.Samples = []*protobuf.APILog{sample}
+	}
+
+	return finding
+}
+
+// shouldReport Function
+func (pb *pairBaseline) shouldReport(findingType string, now time.Time) bool {
+	return pb.reports.ShouldReport(findingType, now)
+}
+
+// closeBucket Function that compares the current bucket with the baselines and then learns from it
+func (pb *pairBaseline) closeBucket(now time.Time, learningPeriod time.Duration, threshold float64) []*protobuf.APIFinding {
+	findings := make([]*protobuf.APIFinding, 0)
+
+	b := pb.current
+	pb.current = bucket{}
+
+	hour := now.UTC().Hour()
+	slot := &pb.hourlyRate[hour]
+	rate := float64(b.requests)
+
+	endpoint := fmt.Sprintf("%s %s on %s/%s", pb.method, pb.pathTemplate, pb.namespace, pb.workload)
+
+	if now.Sub(pb.firstSeen) >= learningPeriod && pb.rate.Samples >= minSamples {
+		// request rate, compared with the same time of day once it is known
+		expected, basis := &pb.rate, "recent"
+		if slot.Samples >= seasonalMinSamples {
+			expected, basis = slot, fmt.Sprintf("%02d:00 UTC", hour)
+		}
+
+		score := expected.Score(rate, minRateStdDev)
+		stdDev := expected.StdDev(minRateStdDev)
+
+		if score >= threshold && b.requests >= minRequests && pb.shouldReport(FindingTypeRateSpike, now) {
+			findings = append(findings, pb.newFinding(FindingTypeRateSpike, score, threshold, rate, expected.Mean, stdDev, basis,
+				fmt.Sprintf("%s calls %s %.0f times per minute, %.1f standard deviations above its %s baseline of %.1f",
+					pb.caller, endpoint, rate, score, basis, expected.Mean), b.sample, now))
+		} else if -score >= threshold && expected.Mean >= minRequests && pb.shouldReport(FindingTypeRateDrop, now) {
+			findings = append(findings, pb.newFinding(FindingTypeRateDrop, -score, threshold, rate, expected.Mean, stdDev, basis,
+				fmt.Sprintf("%s calls %s %.0f times per minute, %.1f standard deviations below its %s baseline of %.1f",
+					pb.caller, endpoint, rate, -score, basis, expected.Mean), b.sample, now))
+		}
+
+		if b.requests >= minRequests && pb.errorRatio.Samples >= minSamples {
+			// error ratio
+			errorRatio := float64(b.errors) / float64(b.requests)
+			score = pb.errorRatio.Score(errorRatio, minErrorRatioStdDev)
+			stdDev = pb.errorRatio.StdDev(minErrorRatioStdDev)
+
+			if score >= threshold && errorRatio >= minErrorRatio && pb.shouldReport(FindingTypeErrorRatioSpike, now) {
+				findings = append(findings, pb.newFinding(FindingTypeErrorRatioSpike, score, threshold, errorRatio, pb.errorRatio.Mean, stdDev, "recent",
+					fmt.Sprintf("%.0f%% of the calls from %s to %s failed, while %.0f%% usually do",
+						errorRatio*100, pb.caller, endpoint, pb.errorRatio.Mean*100), b.sample, now))
+			}
+
+			// latency
+			latency := float64(b.latencySum) / float64(b.requests)
+			score = pb.latency.Score(latency, minLatencyStdDev)
+			stdDev = pb.latency.StdDev(minLatencyStdDev)
+
+			if score >= threshold && pb.shouldReport(FindingTypeLatencySpike, now) {
+				findings = append(findings, pb.newFinding(FindingTypeLatencySpike, score, threshold, latency, pb.latency.Mean, stdDev, "recent",
+					fmt.Sprintf("Calls from %s to %s take %.0fms on average, while they usually take %.0fms",
+						pb.caller, endpoint, latency, pb.latency.Mean), b.sample, now))
+			}
+		}
+	}
+
+	// learn from the bucket
+	pb.rate.Update(rate, alpha)
+	slot.Update(rate, seasonalAlpha)
+
+	if b.requests > 0 {
+		pb.errorRatio.Update(float64(b.errors)/float64(b.requests), alpha)
+		pb.latency.Update(float64(b.latencySum)/float64(b.requests), alpha)
+	}
+
+	return findings
+}
+
+// == //
@@ -0,0 +1,318 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package chains
+
+import (
+	"log"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/5gsec/SentryFlow/config"
+	"github.com/5gsec/SentryFlow/graph"
+	"github.com/5gsec/SentryFlow/inventory"
+	"github.com/5gsec/SentryFlow/protobuf"
+)
+
+// == //
+
+// maxPendingChains is the maximum number of requests waiting for more hops
+const maxPendingChains = 10000
+
+// maxHopsPerChain is the maximum number of hops kept per request
+const maxHopsPerChain = 64
+
+// ChainA global reference for Chain Assembler
+var ChainA *ChainAssembler
+
+// init Function
+func init() {
+	ChainA = NewChainAssembler()
+}
+
+// pendingChain Structure (the hops of a request seen so far)
+type pendingChain struct {
+	traceID   string
+	requestID string
+
+	apiLogs  []*protobuf.APILog
+	lastSeen time.Time
+}
+
+// ChainAssembler Structure
+type ChainAssembler struct {
+	stopChan chan struct{}
+
+	pending     map[string]*pendingChain
+	pendingLock sync.Mutex
+	full        bool
+
+	report func(*protobuf.RequestChain)
+}
+
+// NewChainAssembler Function
+func NewChainAssembler() *ChainAssembler {
+	ca := &ChainAssembler{
+		stopChan: make(chan struct{}),
+
+		pending:     make(map[string]*pendingChain),
+		pendingLock: sync.Mutex{},
+	}
+
+	return ca
+}
+
+// == //
+
+// StartChainAssembler Function
+func StartChainAssembler(wg *sync.WaitGroup, report func(*protobuf.RequestChain)) bool {
+	ChainA.report = report
+
+	// export requests without new hops for a while
+	go flushChains(wg)
+
+	log.Printf("[ChainAssembler] Started Chain Assembler (timeout: %ds)", config.GlobalConfig.RequestChainTimeout)
+
+	return true
+}
+
+// StopChainAssembler Function
+func StopChainAssembler() bool {
+	close(ChainA.stopChan)
+
+	log.Print("[ChainAssembler] Stopped Chain Assembler")
+
+	return true
+}
+
+// flushChains Function
+func flushChains(wg *sync.WaitGroup) {
+	wg.Add(1)
+
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			for _, chain := range ChainA.expire(time.Now()) {
+				if ChainA.report != nil {
+					go ChainA.report(chain)
+				}
+			}
+		case <-ChainA.stopChan:
+			wg.Done()
+			return
+		}
+	}
+}
+
+// == //
+
+// traceIDOf Function that gives the trace ID in the trace context of a request (W3C or B3)
+func traceIDOf(headers map[string]string) string {
+	// version-traceid-parentid-flags
+	if parts := strings.Split(headers["traceparent"], "-"); len(parts) == 4 && len(parts[1]) == 32 {
+		return parts[1]
+	}
+
+	return headers["x-b3-traceid"]
+}
+
+// ObserveAPILog Function that adds an API log to the chain of its request
+func ObserveAPILog(apiLog *protobuf.APILog) {
+	traceID := traceIDOf(apiLog.RequestHeaders)
+	requestID := apiLog.RequestHeaders["x-request-id"]
+
+	key := traceID
+	if key == "" {
+		key = requestID
+	}
+	if key == "" {
+		return
+	}
+
+	ChainA.pendingLock.Lock()
+	defer ChainA.pendingLock.Unlock()
+
+	pc, ok := ChainA.pending[key]
+	if !ok {
+		if len(ChainA.pending) >= maxPendingChains {
+			if !ChainA.full {
+				log.Printf("[ChainAssembler] Too many pending requests (%d), ignoring new ones", maxPendingChains)
+				ChainA.full = true
+			}
+			return
+		}
+
+		pc = &pendingChain{traceID: traceID, requestID: requestID}
+		ChainA.pending[key] = pc
+	}
+
+	if len(pc.apiLogs) < maxHopsPerChain {
+		pc.apiLogs = append(pc.apiLogs, apiLog)
+	}
+	pc.lastSeen = time.Now()
+}
+
+// expire Function that assembles the requests without new hops within the timeout
+func (ca *ChainAssembler) expire(now time.Time) []*protobuf.RequestChain {
+	timeout := time.Duration(config.GlobalConfig.RequestChainTimeout) * time.Second
+
+	ready := make([]*pendingChain, 0)
+
+	ca.pendingLock.Lock()
+	for key, pc := range ca.pending {
+		if now.Sub(pc.lastSeen) >= timeout {
+			ready = append(ready, pc)
+			delete(ca.pending, key)
+		}
+	}
+	if len(ca.pending) < maxPendingChains {
+		ca.full = false
+	}
+	ca.pendingLock.Unlock()
+
+	chains := make([]*protobuf.RequestChain, 0, len(ready))
+
+	for _, pc := range ready {
+		// a single hop is already exported as an API log
+		if chain := assembleChain(pc); len(chain.Hops) > 1 {
+			chains = append(chains, chain)
+		}
+	}
+
+	return chains
+}
+
+// == //
+
+// hop Structure (a call between two workloads while assembling a chain)
+type hop struct {
+	index  int
+	source string
+	target string
+
+	apiLog   *protobuf.APILog
+	merged   bool
+	parent   *hop
+	children []*hop
+}
+
+// encloses Function that tells whether a call can be the parent of another call
+// Parents take longer than their children (and are logged later on ties, since they finish later)
+func (h *hop) encloses(child *hop) bool {
+	if h.apiLog.Latency != child.apiLog.Latency {
+		return h.apiLog.Latency > child.apiLog.Latency
+	}
+	return h.index > child.index
+}
+
+// assembleChain Function that builds the call tree of a request
+func assembleChain(pc *pendingChain) *protobuf.RequestChain {
+	hops := make([]*hop, 0, len(pc.apiLogs))
+
+	for _, apiLog := range pc.apiLogs {
+		source := graph.NodeID(apiLog.SrcNamespace, inventory.SrcWorkload(apiLog), apiLog.SrcIP, apiLog.SrcType)
+		target := graph.NodeID(apiLog.DstNamespace, inventory.DstWorkload(apiLog), apiLog.DstIP, apiLog.DstType)
+
+		// the sidecars of both the caller and the callee log the same call
+		duplicated := false
+		for _, h := range hops {
+			if !h.merged && h.source == source && h.target == target && h.apiLog.Method == apiLog.Method && h.apiLog.Path == apiLog.Path {
+				if apiLog.Latency > h.apiLog.Latency {
+					h.apiLog = apiLog
+				}
+				h.merged = true
+				duplicated = true
+				break
+			}
+		}
+
+		if !duplicated {
+			hops = append(hops, &hop{index: len(hops), source: source, target: target, apiLog: apiLog})
+		}
+	}
+
+	// the parent of a call is the tightest call enclosing it that is made to its source
+	for _, child := range hops {
+		for _, candidate := range hops {
+			if candidate == child || candidate.target != child.source || !candidate.encloses(child) {
+				continue
+			}
+			if child.parent == nil || child.parent.encloses(candidate) {
+				child.parent = candidate
+			}
+		}
+		if child.parent != nil {
+			child.parent.children = append(child.parent.children, child)
+		}
+	}
+
+	roots := make([]*hop, 0)
+	for _, h := range hops {
+		if h.parent == nil {
+			roots = append(roots, h)
+		}
+	}
+
+	chain := &protobuf.RequestChain{
+		ChainID:   pc.traceID,
+		TraceID:   pc.traceID,
+		RequestID: pc.requestID,
+		Hops:      make([]*protobuf.RequestHop, 0, len(hops)),
+	}
+	if chain.ChainID == "" {
+		chain.ChainID = pc.requestID
+	}
+
+	sortHops(roots)
+	if len(roots) > 0 {
+		chain.TimeStamp = roots[0].apiLog.TimeStamp
+		chain.Latency = roots[0].apiLog.Latency
+	}
+
+	for _, root := range roots {
+		appendHops(chain, root, -1)
+	}
+
+	return chain
+}
+
+// sortHops Function (longer calls first)
+func sortHops(hops []*hop) {
+	sort.SliceStable(hops, func(i, j int) bool { return hops[i].apiLog.Latency > hops[j].apiLog.Latency })
+}
+
+// appendHops Function that adds a call and its descendants in depth-first order
+func appendHops(chain *protobuf.RequestChain, h *hop, parent int32) {
+	// time not spent in child calls (approximate if they are made in parallel)
+	selfLatency := h.apiLog.Latency
+	for _, child := range h.children {
+		if child.apiLog.Latency >= selfLatency {
+			selfLatency = 0
+			break
+		}
+		selfLatency -= child.apiLog.Latency
+	}
+
+	id := int32(len(chain.Hops))
+
+	chain.Hops = append(chain.Hops, &protobuf.RequestHop{
+		Id:          id,
+		Parent:      parent,
+		Source:      h.source,
+		Target:      h.target,
+		Latency:     h.apiLog.Latency,
+		SelfLatency: selfLatency,
+		ApiLog:      h.apiLog,
+	})
+
+	sortHops(h.children)
+	for _, child := range h.children {
+		appendHops(chain, child, id)
+	}
+}
+
+// == //
@@ -0,0 +1,86 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/5gsec/SentryFlow/config"
+	"github.com/5gsec/SentryFlow/protobuf"
+
+	"google.golang.org/grpc"
+)
+
+// == //
+
+// command Structure
+type command struct {
+	description string
+	run         func(args []string) int
+}
+
+// commands is the list of subcommands
+var commands = map[string]command{
+	"coverage": {"Report the workloads with and without the Istio proxy observed by a running SentryFlow", runCoverage},
+	"graph":    {"Export the service dependency graph observed by a running SentryFlow", runGraph},
+	"openapi":  {"Generate OpenAPI specs from the APIs observed by a running SentryFlow", runOpenAPI},
+	"policy":   {"Suggest AuthorizationPolicies and NetworkPolicies from the traffic observed by a running SentryFlow", runPolicy},
+	"unpatch":  {"Restore the Istio mesh config and remove Telemetry resources of SentryFlow (e.g., after it was killed)", runUnpatch},
+}
+
+// rpcTimeout is the timeout for each gRPC call made by subcommands
+const rpcTimeout = 30 * time.Second
+
+// == //
+
+// Run Function that runs a subcommand and returns an exit code
+func Run(args []string) int {
+	cmd, ok := commands[args[0]]
+	if !ok {
+		fmt.Fprintf(os.Stderr, "Unknown command %q\n\n", args[0])
+		printUsage()
+		return 2
+	}
+
+	return cmd.run(args[1:])
+}
+
+// printUsage Function
+func printUsage() {
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	fmt.Fprintf(os.Stderr, "Usage: sentryflow [flags] [command [command flags]]\n\nCommands:\n")
+	for _, name := range names {
+		fmt.Fprintf(os.Stderr, "  %-12s %s\n", name, commands[name].description)
+	}
+}
+
+// defaultServer Function that gives the exporter address of a SentryFlow running locally
+func defaultServer() string {
+	return fmt.Sprintf("localhost:%s", config.GlobalConfig.ExporterPort)
+}
+
+// connectSentryFlow Function
+func connectSentryFlow(server string) (protobuf.SentryFlowClient, *grpc.ClientConn, error) {
+	conn, err := grpc.Dial(server, grpc.WithInsecure())
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return protobuf.NewSentryFlowClient(conn), conn, nil
+}
+
+// rpcContext Function
+func rpcContext() (context.Context, context.CancelFunc) {
+	return context.WithTimeout(context.Background(), rpcTimeout)
+}
+
+// == //
@@ -0,0 +1,78 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/5gsec/SentryFlow/protobuf"
+)
+
+// == //
+
+// runCoverage Function (sentryflow coverage)
+func runCoverage(args []string) int {
+	flags := flag.NewFlagSet("coverage", flag.ContinueOnError)
+
+	server := flags.String("server", defaultServer(), "Address of the SentryFlow exporter")
+	namespace := flags.String("namespace", "", "Only workloads in this namespace, all if empty")
+	status := flags.String("status", "", "Only workloads with this status {covered|silent|ambient|partial|uncovered|optedOut}, all if empty")
+
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+
+	client, conn, err := connectSentryFlow(*server)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to connect to %s: %v\n", *server, err)
+		return 1
+	}
+	defer conn.Close()
+
+	ctx, cancel := rpcContext()
+	report, err := client.GetSidecarCoverage(ctx, &protobuf.CoverageQuery{Namespace: *namespace, Status: *status})
+	cancel()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to get the sidecar coverage: %v\n", err)
+		return 1
+	}
+
+	for _, note := range report.Notes {
+		fmt.Fprintln(os.Stderr, note)
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+
+	fmt.Fprintln(w, "NAMESPACE\tKIND\tWORKLOAD\tPODS\tSIDECARS\tAMBIENT\tOPTED OUT\tLAST ACCESS LOG\tSTATUS")
+	for _, workload := range report.Workloads {
+		lastAccessLog := "-"
+		if workload.LastAccessLog != 0 {
+			lastAccessLog = time.Unix(workload.LastAccessLog, 0).UTC().Format(time.RFC3339)
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%d\t%d\t%d\t%d\t%s\t%s\n", workload.Namespace, workload.Kind, workload.Name,
+			workload.Pods, workload.PodsWithSidecar, workload.PodsAmbient, workload.PodsOptedOut, lastAccessLog, workload.Status)
+	}
+
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "NAMESPACE\tINJECTION\tWORKLOADS\tCOVERED")
+	for _, namespace := range report.Namespaces {
+		injection := "disabled"
+		if namespace.InjectionEnabled {
+			injection = namespace.InjectionLabel
+		}
+		fmt.Fprintf(w, "%s\t%s\t%d\t%d\n", namespace.Name, injection, namespace.Workloads, namespace.Covered)
+	}
+
+	if err := w.Flush(); err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to write the sidecar coverage: %v\n", err)
+		return 1
+	}
+
+	return 0
+}
+
+// == //
@@ -0,0 +1,58 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/5gsec/SentryFlow/protobuf"
+)
+
+// == //
+
+// runGraph Function (sentryflow graph)
+func runGraph(args []string) int {
+	flags := flag.NewFlagSet("graph", flag.ContinueOnError)
+
+	server := flags.String("server", defaultServer(), "Address of the SentryFlow exporter")
+	namespace := flags.String("namespace", "", "Only calls from or to this namespace, all if empty")
+	format := flags.String("format", "dot", "Output format {dot|json}")
+	output := flags.String("output", "", "Output file, stdout if empty")
+
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+
+	client, conn, err := connectSentryFlow(*server)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to connect to %s: %v\n", *server, err)
+		return 1
+	}
+	defer conn.Close()
+
+	ctx, cancel := rpcContext()
+	sg, err := client.GetServiceGraph(ctx, &protobuf.ServiceGraphQuery{Namespace: *namespace, Format: *format})
+	cancel()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to get the service graph: %v\n", err)
+		return 1
+	}
+
+	if *output == "" {
+		fmt.Println(strings.TrimSuffix(sg.Document, "\n"))
+		return 0
+	}
+
+	if err := os.WriteFile(filepath.Clean(*output), []byte(sg.Document), 0600); err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to write the service graph: %v\n", err)
+		return 1
+	}
+
+	return 0
+}
+
+// == //
@@ -0,0 +1,112 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/5gsec/SentryFlow/protobuf"
+)
+
+// == //
+
+// runOpenAPI Function (sentryflow openapi)
+func runOpenAPI(args []string) int {
+	flags := flag.NewFlagSet("openapi", flag.ContinueOnError)
+
+	server := flags.String("server", defaultServer(), "Address of the SentryFlow exporter")
+	namespace := flags.String("namespace", "", "Namespace of the workload")
+	workload := flags.String("workload", "", "Name of the workload")
+	all := flags.Bool("all", false, "Generate specs for all workloads in the API inventory")
+	format := flags.String("format", "yaml", "Output format {yaml|json}")
+	output := flags.String("output", "", "Output file (a directory with -all), stdout if empty")
+
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+
+	if !*all && (*namespace == "" || *workload == "") {
+		fmt.Fprintln(os.Stderr, "Either -namespace and -workload or -all is required")
+		flags.PrintDefaults()
+		return 2
+	}
+
+	client, conn, err := connectSentryFlow(*server)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to connect to %s: %v\n", *server, err)
+		return 1
+	}
+	defer conn.Close()
+
+	targets := [][2]string{{*namespace, *workload}}
+
+	if *all {
+		ctx, cancel := rpcContext()
+		apis, err := client.ListAPIs(ctx, &protobuf.APIQuery{})
+		cancel()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to list APIs: %v\n", err)
+			return 1
+		}
+
+		targets = make([][2]string, 0)
+		seen := make(map[[2]string]bool)
+		for _, api := range apis.APIs {
+			target := [2]string{api.Namespace, api.Workload}
+			if !seen[target] {
+				seen[target] = true
+				targets = append(targets, target)
+			}
+		}
+	}
+
+	failed := 0
+
+	for _, target := range targets {
+		ctx, cancel := rpcContext()
+		spec, err := client.GetOpenAPISpec(ctx, &protobuf.OpenAPIQuery{Namespace: target[0], Workload: target[1], Format: *format})
+		cancel()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to get an OpenAPI spec for %s/%s: %v\n", target[0], target[1], err)
+			failed++
+			continue
+		}
+
+		if err := writeSpec(spec, *output, *all); err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to write an OpenAPI spec for %s/%s: %v\n", target[0], target[1], err)
+			failed++
+		}
+	}
+
+	if failed != 0 {
+		return 1
+	}
+
+	return 0
+}
+
+// writeSpec Function
+func writeSpec(spec *protobuf.OpenAPISpec, output string, dir bool) error {
+	if output == "" {
+		if dir {
+			fmt.Printf("# %s/%s\n", spec.Namespace, spec.Workload)
+		}
+		fmt.Println(spec.Document)
+		return nil
+	}
+
+	fileName := output
+	if dir {
+		if err := os.MkdirAll(output, 0750); err != nil {
+			return err
+		}
+		fileName = filepath.Join(output, fmt.Sprintf("%s.%s.%s", spec.Namespace, spec.Workload, spec.Format))
+	}
+
+	return os.WriteFile(filepath.Clean(fileName), []byte(spec.Document), 0600)
+}
+
+// == //
@@ -0,0 +1,92 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/5gsec/SentryFlow/protobuf"
+)
+
+// == //
+
+// runPolicy Function (sentryflow policy)
+func runPolicy(args []string) int {
+	flags := flag.NewFlagSet("policy", flag.ContinueOnError)
+
+	server := flags.String("server", defaultServer(), "Address of the SentryFlow exporter")
+	namespace := flags.String("namespace", "", "Only policies for workloads in this namespace, all if empty")
+	workload := flags.String("workload", "", "Only policies for this workload, all if empty")
+	kinds := flags.String("kinds", "AuthorizationPolicy,NetworkPolicy", "Kinds of policies to generate (comma-separated)")
+	trustDomain := flags.String("trust-domain", "cluster.local", "Trust domain of Istio service accounts")
+	minCalls := flags.Uint64("min-calls", 1, "Calls from a caller to an endpoint required to allow them")
+	diff := flags.Bool("diff", false, "Show the differences from the policies in the cluster (dry run, nothing is applied)")
+	output := flags.String("output", "", "Output file, stdout if empty")
+
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+
+	client, conn, err := connectSentryFlow(*server)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to connect to %s: %v\n", *server, err)
+		return 1
+	}
+	defer conn.Close()
+
+	query := &protobuf.PolicyQuery{
+		Namespace:   *namespace,
+		Workload:    *workload,
+		Kinds:       strings.Split(*kinds, ","),
+		TrustDomain: *trustDomain,
+		MinCalls:    *minCalls,
+		Diff:        *diff,
+	}
+
+	ctx, cancel := rpcContext()
+	policySet, err := client.GeneratePolicies(ctx, query)
+	cancel()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to generate policies: %v\n", err)
+		return 1
+	}
+
+	for _, policy := range policySet.Policies {
+		if policy.Kind == "" {
+			fmt.Fprintf(os.Stderr, "Skipped %s/%s: %s\n", policy.Namespace, policy.Workload, strings.Join(policy.Notes, "; "))
+		}
+	}
+
+	if *diff {
+		for _, policy := range policySet.Policies {
+			if policy.Kind == "" {
+				continue
+			}
+			fmt.Printf("%s %s/%s/%s\n", policy.Status, policy.Kind, policy.Namespace, policy.Name)
+			if policy.Diff != "" {
+				fmt.Print(policy.Diff)
+			}
+		}
+		if *output == "" {
+			return 0
+		}
+	}
+
+	if *output == "" {
+		fmt.Print(policySet.Yaml)
+		return 0
+	}
+
+	if err := os.WriteFile(filepath.Clean(*output), []byte(policySet.Yaml), 0600); err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to write policies: %v\n", err)
+		return 1
+	}
+
+	return 0
+}
+
+// == //
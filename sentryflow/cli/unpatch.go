@@ -0,0 +1,49 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/5gsec/SentryFlow/k8s"
+)
+
+// == //
+
+// runUnpatch Function (sentryflow unpatch)
+func runUnpatch(args []string) int {
+	flags := flag.NewFlagSet("unpatch", flag.ContinueOnError)
+
+	dryRun := flags.Bool("dry-run", false, "Show the changes without making them")
+
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+
+	// Works without a running SentryFlow (in the cluster, or with the kubectl config)
+	if !k8s.ConnectK8sClient() {
+		fmt.Fprintln(os.Stderr, "Failed to connect to Kubernetes")
+		return 1
+	}
+
+	changes, err := k8s.RestoreIstio(*dryRun)
+	for _, change := range changes {
+		fmt.Print(change)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to unpatch Istio: %v\n", err)
+		return 1
+	}
+
+	if len(changes) == 0 {
+		fmt.Println("Istio is not patched by SentryFlow")
+	} else if *dryRun {
+		fmt.Println("Dry run, nothing is changed")
+	}
+
+	return 0
+}
+
+// == //
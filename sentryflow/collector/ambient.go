@@ -0,0 +1,46 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package collector
+
+import (
+	"github.com/5gsec/SentryFlow/k8s"
+	"github.com/5gsec/SentryFlow/types"
+	otelLogsV1 "go.opentelemetry.io/proto/otlp/logs/v1"
+)
+
+// == //
+
+// resolvePeer Function that gives the workload behind an identity if an end of an access log is a waypoint proxy (or unknown)
+// Waypoint proxies of the Istio ambient mode relay requests, so their addresses are not the real clients or servers
+func resolvePeer(resource types.K8sResource, identity string) types.K8sResource {
+	if identity == "" {
+		return resource
+	}
+
+	if resource.Type != types.K8sResourceTypeUnknown && !k8s.IsWaypoint(resource) {
+		return resource
+	}
+
+	if peer, ok := k8s.LookupIdentity(identity); ok {
+		return peer
+	}
+
+	return resource
+}
+
+// otelAttribute Function that gives the value of an attribute of an OpenTelemetry log record (empty if missing or "-")
+func otelAttribute(logRecord *otelLogsV1.LogRecord, key string) string {
+	for _, attribute := range logRecord.GetAttributes() {
+		if attribute.GetKey() != key {
+			continue
+		}
+		if value := attribute.GetValue().GetStringValue(); value != "-" {
+			return value
+		}
+		return ""
+	}
+
+	return ""
+}
+
+// == //
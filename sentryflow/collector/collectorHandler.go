@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"log"
 	"net"
+	"sync"
 
 	"github.com/5gsec/SentryFlow/config"
 	"google.golang.org/grpc"
@@ -26,12 +27,16 @@ type ColHandler struct {
 	colService net.Listener
 	grpcServer *grpc.Server
 	collectors []collectorInterface
+
+	stopChan chan struct{}
 }
 
 // NewCollectorHandler Function
 func NewCollectorHandler() *ColHandler {
 	ch := &ColHandler{
 		collectors: make([]collectorInterface, 0),
+
+		stopChan: make(chan struct{}),
 	}
 	return ch
 }
@@ -39,7 +44,7 @@ func NewCollectorHandler() *ColHandler {
 // == //
 
 // StartCollector Function
-func StartCollector() bool {
+func StartCollector(wg *sync.WaitGroup) bool {
 	// Make a string with the given collector address and port
 	collectorService := fmt.Sprintf("%s:%s", config.GlobalConfig.CollectorAddr, config.GlobalConfig.CollectorPort)
 
@@ -76,12 +81,24 @@ func StartCollector() bool {
 
 	log.Print("[Collector] Serving Collector gRPC services")
 
+	// scrape L4 metrics from ztunnel (Istio ambient mode)
+	if config.GlobalConfig.ZtunnelScrapePeriod > 0 {
+		wg.Add(1)
+		go scrapeZtunnel(wg, ColH.stopChan)
+
+		log.Printf("[Collector] Scraping ztunnel metrics every %d seconds", config.GlobalConfig.ZtunnelScrapePeriod)
+	}
+
 	return true
 }
 
 // StopCollector Function
 func StopCollector() bool {
-	ColH.grpcServer.GracefulStop()
+	close(ColH.stopChan)
+
+	if ColH.grpcServer != nil {
+		ColH.grpcServer.GracefulStop()
+	}
 
 	log.Print("[Collector] Gracefully stopped Collector gRPC services")
 
@@ -0,0 +1,37 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package collector
+
+import (
+	"net"
+	"strings"
+)
+
+// == //
+
+// destinationHost Function that names the host a request was sent to
+// The authority is taken first, then the SNI, then the host of the Istio upstream cluster (outbound|443||api.example.com)
+func destinationHost(authority, sni, upstreamCluster string) string {
+	// fields of text access logs may keep their quotes
+	authority = strings.Trim(authority, `\"`)
+	sni = strings.Trim(sni, `\"`)
+
+	if authority != "" && authority != "-" {
+		if host, _, err := net.SplitHostPort(authority); err == nil {
+			return strings.ToLower(host)
+		}
+		return strings.ToLower(strings.Trim(authority, "[]"))
+	}
+
+	if sni != "" && sni != "-" {
+		return strings.ToLower(sni)
+	}
+
+	if parts := strings.Split(upstreamCluster, "|"); len(parts) == 4 && parts[3] != "" {
+		return strings.ToLower(parts[3])
+	}
+
+	return ""
+}
+
+// == //
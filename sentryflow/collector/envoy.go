@@ -6,6 +6,7 @@ import (
 	"io"
 	"log"
 	"strconv"
+	"strings"
 
 	"github.com/5gsec/SentryFlow/k8s"
 	"github.com/5gsec/SentryFlow/processor"
@@ -59,20 +60,65 @@ func (evyMetrics *EnvoyMetricsServer) registerService(server *grpc.Server) {
 
 // == //
 
+// envoyResponseFlags Function that converts response flags into the short form of access logs (e.g., UF,URX)
+func envoyResponseFlags(flags *envoyAccLogsData.ResponseFlags) string {
+	if flags == nil {
+		return ""
+	}
+
+	shortFlags := make([]string, 0)
+	for _, flag := range []struct {
+		set  bool
+		name string
+	}{
+		{flags.GetFailedLocalHealthcheck(), "LH"},
+		{flags.GetNoHealthyUpstream(), "UH"},
+		{flags.GetUpstreamRequestTimeout(), "UT"},
+		{flags.GetLocalReset(), "LR"},
+		{flags.GetUpstreamRemoteReset(), "UR"},
+		{flags.GetUpstreamConnectionFailure(), "UF"},
+		{flags.GetUpstreamConnectionTermination(), "UC"},
+		{flags.GetUpstreamOverflow(), "UO"},
+		{flags.GetNoRouteFound(), "NR"},
+		{flags.GetRateLimited(), "RL"},
+		{flags.GetUnauthorizedDetails() != nil, "UAEX"},
+		{flags.GetDownstreamConnectionTermination(), "DC"},
+		{flags.GetUpstreamRetryLimitExceeded(), "URX"},
+		{flags.GetStreamIdleTimeout(), "SI"},
+	} {
+		if flag.set {
+			shortFlags = append(shortFlags, flag.name)
+		}
+	}
+
+	return strings.Join(shortFlags, ",")
+}
+
 // generateAPILogsFromEnvoy Function
 func generateAPILogsFromEnvoy(entry *envoyAccLogsData.HTTPAccessLogEntry) *protobuf.APILog {
 	comm := entry.GetCommonProperties()
 	timeStamp := comm.GetStartTime().Seconds
+	startTime := comm.GetStartTime().AsTime() // for resolving IP addresses as they were assigned then
 
 	srcInform := entry.GetCommonProperties().GetDownstreamRemoteAddress().GetSocketAddress()
 	srcIP := srcInform.GetAddress()
 	srcPort := strconv.Itoa(int(srcInform.GetPortValue()))
-	src := k8s.LookupK8sResource(srcIP)
+	src := k8s.LookupK8sResourceAt(srcIP, srcPort, startTime)
+
+	// The certificate of the client tells the real client behind a waypoint proxy (in the ambient mode)
+	srcIdentity := ""
+	for _, san := range comm.GetTlsProperties().GetPeerCertificateProperties().GetSubjectAltName() {
+		if san.GetUri() != "" {
+			srcIdentity = san.GetUri()
+			break
+		}
+	}
+	src = resolvePeer(src, srcIdentity)
 
 	dstInform := entry.GetCommonProperties().GetUpstreamRemoteAddress().GetSocketAddress()
 	dstIP := dstInform.GetAddress()
 	dstPort := strconv.Itoa(int(dstInform.GetPortValue()))
-	dst := k8s.LookupK8sResource(dstIP)
+	dst := k8s.LookupK8sResourceAt(dstIP, dstPort, startTime)
 
 	request := entry.GetRequest()
 	response := entry.GetResponse()
@@ -81,29 +127,72 @@ func generateAPILogsFromEnvoy(entry *envoyAccLogsData.HTTPAccessLogEntry) *proto
 	method := request.GetRequestMethod().String()
 	path := request.GetPath()
 	resCode := response.GetResponseCode().GetValue()
+	latency := comm.GetTimeToLastDownstreamTxByte().AsDuration().Milliseconds()
+
+	// Collect the headers that Envoy gives (including those configured to be logged additionally)
+	reqHeaders := make(map[string]string)
+	for key, value := range request.GetRequestHeaders() {
+		reqHeaders[strings.ToLower(key)] = value
+	}
+	if request.GetAuthority() != "" {
+		reqHeaders[":authority"] = request.GetAuthority()
+	}
+	if request.GetUserAgent() != "" {
+		reqHeaders["user-agent"] = request.GetUserAgent()
+	}
+	if request.GetRequestId() != "" {
+		reqHeaders["x-request-id"] = request.GetRequestId()
+	}
+	if request.GetReferer() != "" {
+		reqHeaders["referer"] = request.GetReferer()
+	}
+
+	resHeaders := make(map[string]string)
+	for key, value := range response.GetResponseHeaders() {
+		resHeaders[strings.ToLower(key)] = value
+	}
+
+	// Trailers carry the status of gRPC calls (grpc-status, grpc-message)
+	for key, value := range response.GetResponseTrailers() {
+		resHeaders[strings.ToLower(key)] = value
+	}
 
 	envoyAPILog := &protobuf.APILog{
 		Id:        0, // @todo zero for now
 		TimeStamp: strconv.FormatInt(timeStamp, 10),
 
-		SrcNamespace: src.Namespace,
-		SrcName:      src.Name,
-		SrcLabel:     src.Labels,
-		SrcIP:        srcIP,
-		SrcPort:      srcPort,
-		SrcType:      types.K8sResourceTypeToString(src.Type),
-
-		DstNamespace: dst.Namespace,
-		DstName:      dst.Name,
-		DstLabel:     dst.Labels,
-		DstIP:        dstIP,
-		DstPort:      dstPort,
-		DstType:      types.K8sResourceTypeToString(dst.Type),
+		SrcNamespace:    src.Namespace,
+		SrcName:         src.Name,
+		SrcLabel:        src.Labels,
+		SrcWorkloadKind: src.WorkloadKind,
+		SrcWorkloadName: src.WorkloadName,
+		SrcIdentity:     srcIdentity,
+		SrcIP:           srcIP,
+		SrcPort:         srcPort,
+		SrcType:         types.K8sResourceTypeToString(src.Type),
+
+		DstNamespace:    dst.Namespace,
+		DstName:         dst.Name,
+		DstLabel:        dst.Labels,
+		DstWorkloadKind: dst.WorkloadKind,
+		DstWorkloadName: dst.WorkloadName,
+		DstIP:           dstIP,
+		DstPort:         dstPort,
+		DstType:         types.K8sResourceTypeToString(dst.Type),
+		DstHost:         destinationHost(request.GetAuthority(), comm.GetTlsProperties().GetTlsSniHostname(), comm.GetUpstreamCluster()),
 
 		Protocol:     protocol,
 		Method:       method,
 		Path:         path,
 		ResponseCode: int32(resCode),
+		Latency:      uint64(latency),
+
+		ResponseFlags: envoyResponseFlags(comm.GetResponseFlags()),
+		RequestBytes:  request.GetRequestHeadersBytes() + request.GetRequestBodyBytes(),
+		ResponseBytes: response.GetResponseHeadersBytes() + response.GetResponseBodyBytes(),
+
+		RequestHeaders:  reqHeaders,
+		ResponseHeaders: resHeaders,
 	}
 
 	return envoyAPILog
@@ -12,6 +12,7 @@ import (
 	"github.com/5gsec/SentryFlow/protobuf"
 	"github.com/5gsec/SentryFlow/types"
 	otelLogs "go.opentelemetry.io/proto/otlp/collector/logs/v1"
+	otelLogsV1 "go.opentelemetry.io/proto/otlp/logs/v1"
 	"google.golang.org/grpc"
 )
 
@@ -36,98 +37,178 @@ func (otlLogs *OpenTelemetryLogsServer) registerService(server *grpc.Server) {
 
 // == //
 
-// generateAPILogsFromOtel Function
-func generateAPILogsFromOtel(logText string) []*protobuf.APILog {
-	apiLogs := make([]*protobuf.APILog, 0)
+// splitLogFields Function that splits an access log (in k8s.AccessLogFormat) into its fields
+// Quoted fields (e.g., "curl/8.0 (x86_64-pc-linux-gnu)") and bracketed ones (e.g., [2024-01-01T00:00:00.000Z]) may have spaces
+func splitLogFields(accessLog string) []string {
+	fields := make([]string, 0, 24)
 
-	// Preprocess redundant chars
-	logText = strings.ReplaceAll(logText, `\"`, "")
-	logText = strings.ReplaceAll(logText, `}`, "")
+	for idx := 0; idx < len(accessLog); {
+		switch accessLog[idx] {
+		case ' ', '\t', '\n', '\r':
+			idx++
+			continue
+		case '"':
+			if end := strings.IndexByte(accessLog[idx+1:], '"'); end != -1 {
+				fields = append(fields, accessLog[idx+1:idx+1+end])
+				idx += end + 2
+				continue
+			}
+		case '[':
+			if end := strings.IndexByte(accessLog[idx+1:], ']'); end != -1 {
+				fields = append(fields, accessLog[idx:idx+2+end]) // the brackets are kept (as in the start time)
+				idx += end + 2
+				continue
+			}
+		}
 
-	// Split logs by log_records, this is a single access log instance
-	parts := strings.Split(logText, "log_records")
-	if len(parts) == 0 {
-		return nil
+		// Unquoted fields (and unterminated quoted ones) end at the next space
+		end := strings.IndexAny(accessLog[idx:], " \t\n\r")
+		if end == -1 {
+			end = len(accessLog) - idx
+		}
+		fields = append(fields, accessLog[idx:idx+end])
+		idx += end
 	}
 
-	// Ignore the first entry (the metadata "resource_logs:{resource:{ scope_logs:{" part)
-	for _, accessLog := range parts[0:] {
-		var srcIP string
-		var srcPort string
-		var dstIP string
-		var dstPort string
+	return fields
+}
 
-		if len(accessLog) == 0 {
-			continue
-		}
+// logField Function that gives a field of an access log (empty if it is missing or "-")
+func logField(fields []string, idx int) string {
+	if idx >= len(fields) || fields[idx] == "-" {
+		return ""
+	}
+	return fields[idx]
+}
 
-		index := strings.Index(accessLog, "string_value:\"")
-		if index == -1 {
-			continue
+// generateAPILogsFromOtel Function
+func generateAPILogsFromOtel(req *otelLogs.ExportLogsServiceRequest) []*protobuf.APILog {
+	apiLogs := make([]*protobuf.APILog, 0)
+
+	for _, resourceLogs := range req.GetResourceLogs() {
+		for _, scopeLogs := range resourceLogs.GetScopeLogs() {
+			for _, logRecord := range scopeLogs.GetLogRecords() {
+				if apiLog := generateAPILogFromOtel(logRecord); apiLog != nil {
+					apiLogs = append(apiLogs, apiLog)
+				}
+			}
 		}
+	}
 
-		words := strings.Fields(accessLog[index+len("string_value:\""):])
+	return apiLogs
+}
 
-		timeStamp := words[0]
-		method := words[1]
-		path := words[2]
-		protocol := words[3]
-		resCode, _ := strconv.ParseInt(words[4], 10, 64)
+// generateAPILogFromOtel Function that converts an OpenTelemetry log record (an access log) into an API log
+func generateAPILogFromOtel(logRecord *otelLogsV1.LogRecord) *protobuf.APILog {
+	var srcIP string
+	var srcPort string
+	var dstIP string
+	var dstPort string
 
-		srcInform := words[21]
+	accessLog := logRecord.GetBody().GetStringValue()
 
-		// Extract the left and right words based on the colon delimiter (ADDR:PORT)
-		colonIndex := strings.LastIndex(srcInform, ":")
-		if colonIndex > 0 && colonIndex < len(srcInform)-1 {
-			srcIP = strings.TrimSpace(srcInform[:colonIndex])
-			srcPort = strings.TrimSpace(srcInform[colonIndex+1:])
-		}
-		src := k8s.LookupK8sResource(srcIP)
+	fields := splitLogFields(accessLog)
+	requestLine := strings.Fields(logField(fields, k8s.AccessLogRequestLine))
+	if len(requestLine) < 3 {
+		return nil // not an access log of HTTP requests
+	}
 
-		dstInform := words[20]
+	timeStamp := logField(fields, k8s.AccessLogStartTime)
+	startTime := types.ParseTimeStamp(timeStamp) // for resolving IP addresses as they were assigned then
+	method := requestLine[0]
+	path := requestLine[1]
+	protocol := requestLine[2]
+	resCode, _ := strconv.ParseInt(logField(fields, k8s.AccessLogResponseCode), 10, 64)
+	latency, _ := strconv.ParseUint(logField(fields, k8s.AccessLogDuration), 10, 64) // %DURATION% in milliseconds
 
-		// Extract the left and right words based on the colon delimiter (ADDR:PORT)
-		colonIndex = strings.LastIndex(dstInform, ":")
-		if colonIndex > 0 && colonIndex < len(dstInform)-1 {
-			dstIP = strings.TrimSpace(dstInform[:colonIndex])
-			dstPort = strings.TrimSpace(dstInform[colonIndex+1:])
-		}
-		dst := k8s.LookupK8sResource(dstIP)
-
-		// Create APILog
-		apiLog := protobuf.APILog{
-			Id:        0, // @todo zero for now
-			TimeStamp: timeStamp,
-
-			SrcNamespace: src.Namespace,
-			SrcName:      src.Name,
-			SrcLabel:     src.Labels,
-			SrcIP:        srcIP,
-			SrcPort:      srcPort,
-			SrcType:      types.K8sResourceTypeToString(src.Type),
-
-			DstNamespace: dst.Namespace,
-			DstName:      dst.Name,
-			DstLabel:     dst.Labels,
-			DstIP:        dstIP,
-			DstPort:      dstPort,
-			DstType:      types.K8sResourceTypeToString(dst.Type),
-
-			Protocol:     protocol,
-			Method:       method,
-			Path:         path,
-			ResponseCode: int32(resCode),
+	responseFlags := logField(fields, k8s.AccessLogResponseFlags) // %RESPONSE_FLAGS% (e.g., UF,URX)
+
+	requestBytes, _ := strconv.ParseUint(logField(fields, k8s.AccessLogBytesReceived), 10, 64) // %BYTES_RECEIVED%
+	responseBytes, _ := strconv.ParseUint(logField(fields, k8s.AccessLogBytesSent), 10, 64)    // %BYTES_SENT%
+
+	// Collect the headers that the default access log format of Istio gives
+	reqHeaders := make(map[string]string)
+	for key, idx := range map[string]int{"user-agent": k8s.AccessLogUserAgent, "x-request-id": k8s.AccessLogRequestID, ":authority": k8s.AccessLogAuthority} {
+		if value := logField(fields, idx); value != "" {
+			reqHeaders[key] = value
 		}
+	}
+
+	srcInform := logField(fields, k8s.AccessLogRemoteAddress)
 
-		apiLogs = append(apiLogs, &apiLog)
+	// Extract the left and right words based on the colon delimiter (ADDR:PORT)
+	colonIndex := strings.LastIndex(srcInform, ":")
+	if colonIndex > 0 && colonIndex < len(srcInform)-1 {
+		srcIP = strings.TrimSpace(srcInform[:colonIndex])
+		srcPort = strings.TrimSpace(srcInform[colonIndex+1:])
 	}
+	src := k8s.LookupK8sResourceAt(srcIP, srcPort, startTime)
 
-	return apiLogs
+	dstInform := logField(fields, k8s.AccessLogLocalAddress)
+
+	// Extract the left and right words based on the colon delimiter (ADDR:PORT)
+	colonIndex = strings.LastIndex(dstInform, ":")
+	if colonIndex > 0 && colonIndex < len(dstInform)-1 {
+		dstIP = strings.TrimSpace(dstInform[:colonIndex])
+		dstPort = strings.TrimSpace(dstInform[colonIndex+1:])
+	}
+	dst := k8s.LookupK8sResourceAt(dstIP, dstPort, startTime)
+
+	// Identities of clients and servers (added by SentryFlow to the log format) tell the real ends behind waypoint proxies
+	srcIdentity := otelAttribute(logRecord, k8s.SourcePrincipalAttribute)
+	dstIdentity := otelAttribute(logRecord, k8s.DestinationPrincipalAttribute)
+	src = resolvePeer(src, srcIdentity)
+	dst = resolvePeer(dst, dstIdentity)
+
+	// %REQUESTED_SERVER_NAME% (SNI) and %UPSTREAM_CLUSTER% name the destination when the authority is missing
+	sni, upstreamCluster := logField(fields, k8s.AccessLogServerName), logField(fields, k8s.AccessLogUpstreamCluster)
+
+	// Create APILog
+	apiLog := protobuf.APILog{
+		Id:        0, // @todo zero for now
+		TimeStamp: timeStamp,
+
+		SrcNamespace:    src.Namespace,
+		SrcName:         src.Name,
+		SrcLabel:        src.Labels,
+		SrcWorkloadKind: src.WorkloadKind,
+		SrcWorkloadName: src.WorkloadName,
+		SrcIdentity:     srcIdentity,
+		SrcIP:           srcIP,
+		SrcPort:         srcPort,
+		SrcType:         types.K8sResourceTypeToString(src.Type),
+
+		DstNamespace:    dst.Namespace,
+		DstName:         dst.Name,
+		DstLabel:        dst.Labels,
+		DstWorkloadKind: dst.WorkloadKind,
+		DstWorkloadName: dst.WorkloadName,
+		DstIdentity:     dstIdentity,
+		DstIP:           dstIP,
+		DstPort:         dstPort,
+		DstType:         types.K8sResourceTypeToString(dst.Type),
+		DstHost:         destinationHost(reqHeaders[":authority"], sni, upstreamCluster),
+
+		Protocol:     protocol,
+		Method:       method,
+		Path:         path,
+		ResponseCode: int32(resCode),
+		Latency:      latency,
+
+		ResponseFlags: responseFlags,
+		RequestBytes:  requestBytes,
+		ResponseBytes: responseBytes,
+
+		RequestHeaders:  reqHeaders,
+		ResponseHeaders: make(map[string]string),
+	}
+
+	return &apiLog
 }
 
 // Export Function for Log.Export in OpenTelemetry format
 func (otlLogs *OpenTelemetryLogsServer) Export(_ context.Context, req *otelLogs.ExportLogsServiceRequest) (*otelLogs.ExportLogsServiceResponse, error) {
-	apiLogs := generateAPILogsFromOtel(req.String())
+	apiLogs := generateAPILogsFromOtel(req)
 	for _, apiLog := range apiLogs {
 		processor.InsertAPILog(apiLog)
 	}
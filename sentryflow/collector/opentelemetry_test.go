@@ -0,0 +1,91 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package collector
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/5gsec/SentryFlow/k8s"
+)
+
+// TestSplitLogFields checks splitting access logs into the fields of k8s.AccessLogFormat
+func TestSplitLogFields(t *testing.T) {
+	accessLog := `[2024-01-01T00:00:00.000Z] "GET /api/v1/users?id=1 HTTP/1.1" 200 - via_upstream - "-" 0 1234 5 4 "-" ` +
+		`"curl/8.0 (x86_64-pc-linux-gnu)" "0b6f3c2e-1d4e-4b0a-9c1a-5a9e8f7d6c5b" "users.default.svc.cluster.local:8080" ` +
+		`"10.0.1.7:8080" outbound|8080||users.default.svc.cluster.local 10.0.0.5:51234 10.96.0.10:8080 10.0.0.5:40000 ` +
+		`outbound_.8080_._.users.default.svc.cluster.local default` + "\n"
+
+	fields := splitLogFields(accessLog)
+	if len(fields) != len(k8s.AccessLogFormat) {
+		t.Fatalf("expected %d fields, got %d: %q", len(k8s.AccessLogFormat), len(fields), fields)
+	}
+
+	tests := []struct {
+		name     string
+		idx      int
+		expected string
+	}{
+		{"start time", k8s.AccessLogStartTime, "[2024-01-01T00:00:00.000Z]"},
+		{"request line", k8s.AccessLogRequestLine, "GET /api/v1/users?id=1 HTTP/1.1"},
+		{"response code", k8s.AccessLogResponseCode, "200"},
+		{"response flags", k8s.AccessLogResponseFlags, ""},
+		{"bytes received", k8s.AccessLogBytesReceived, "0"},
+		{"bytes sent", k8s.AccessLogBytesSent, "1234"},
+		{"duration", k8s.AccessLogDuration, "5"},
+		{"forwarded for", k8s.AccessLogForwardedFor, ""},
+		{"user agent", k8s.AccessLogUserAgent, "curl/8.0 (x86_64-pc-linux-gnu)"},
+		{"request ID", k8s.AccessLogRequestID, "0b6f3c2e-1d4e-4b0a-9c1a-5a9e8f7d6c5b"},
+		{"authority", k8s.AccessLogAuthority, "users.default.svc.cluster.local:8080"},
+		{"upstream cluster", k8s.AccessLogUpstreamCluster, "outbound|8080||users.default.svc.cluster.local"},
+		{"local address", k8s.AccessLogLocalAddress, "10.96.0.10:8080"},
+		{"remote address", k8s.AccessLogRemoteAddress, "10.0.0.5:40000"},
+		{"server name", k8s.AccessLogServerName, "outbound_.8080_._.users.default.svc.cluster.local"},
+		{"route name", k8s.AccessLogRouteName, "default"},
+		{"missing", len(k8s.AccessLogFormat), ""},
+	}
+
+	for _, tc := range tests {
+		if value := logField(fields, tc.idx); value != tc.expected {
+			t.Errorf("%s: expected %q, got %q", tc.name, tc.expected, value)
+		}
+	}
+}
+
+// TestSplitLogFieldsFormat checks that every field of k8s.AccessLogFormat is split as one field
+func TestSplitLogFieldsFormat(t *testing.T) {
+	fields := splitLogFields(strings.Join(k8s.AccessLogFormat[:], " "))
+	if len(fields) != len(k8s.AccessLogFormat) {
+		t.Fatalf("expected %d fields, got %d: %q", len(k8s.AccessLogFormat), len(fields), fields)
+	}
+
+	for idx, field := range fields {
+		if expected := strings.Trim(k8s.AccessLogFormat[idx], `"`); field != expected {
+			t.Errorf("field %d: expected %q, got %q", idx, expected, field)
+		}
+	}
+}
+
+// TestSplitLogFieldsEdgeCases checks access logs that are not well-formed
+func TestSplitLogFieldsEdgeCases(t *testing.T) {
+	tests := []struct {
+		name      string
+		accessLog string
+		expected  []string
+	}{
+		{"empty", "", []string{}},
+		{"spaces", " a\tb \n", []string{"a", "b"}},
+		{"empty quotes", `a "" b`, []string{"a", "", "b"}},
+		{"unterminated quote", `a "b c`, []string{"a", `"b`, "c"}},
+		{"unterminated bracket", `[a b`, []string{"[a", "b"}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			fields := splitLogFields(tc.accessLog)
+			if strings.Join(fields, "|") != strings.Join(tc.expected, "|") || len(fields) != len(tc.expected) {
+				t.Errorf("expected %q, got %q", tc.expected, fields)
+			}
+		})
+	}
+}
@@ -0,0 +1,191 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package collector
+
+import (
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/5gsec/SentryFlow/config"
+	"github.com/5gsec/SentryFlow/k8s"
+	"github.com/5gsec/SentryFlow/processor"
+	"github.com/5gsec/SentryFlow/protobuf"
+
+	dto "github.com/prometheus/client_model/go"
+	"github.com/prometheus/common/expfmt"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// == //
+
+const (
+	// ztunnelMetricsPort is the port of ztunnel serving Prometheus metrics
+	ztunnelMetricsPort = "15020"
+
+	// ztunnelScrapeTimeout is the timeout for scraping metrics from a ztunnel pod
+	ztunnelScrapeTimeout = 10 * time.Second
+
+	// ztunnelMetricPrefix is the prefix of the L4 metrics of ztunnel (e.g., istio_tcp_sent_bytes_total)
+	ztunnelMetricPrefix = "istio_tcp_"
+)
+
+// ztunnelLabels are the labels kept from ztunnel metrics (the reporting side, the ends of connections and their identities)
+var ztunnelLabels = []string{
+	"reporter",
+	"source_workload_namespace", "source_workload", "source_principal",
+	"destination_workload_namespace", "destination_workload", "destination_principal", "destination_service",
+}
+
+// == //
+
+// scrapeZtunnel Function that periodically scrapes L4 metrics from ztunnel pods (the ambient mode has no sidecars sending them)
+func scrapeZtunnel(wg *sync.WaitGroup, stopChan chan struct{}) {
+	defer wg.Done()
+
+	period := time.Duration(config.GlobalConfig.ZtunnelScrapePeriod) * time.Second
+
+	ticker := time.NewTicker(period)
+	defer ticker.Stop()
+
+	client := &http.Client{Timeout: ztunnelScrapeTimeout}
+	failing := false
+
+	for {
+		select {
+		case <-ticker.C:
+			pods, err := k8s.ZtunnelPods()
+			if err != nil {
+				// Report once, ztunnel only exists in clusters running the ambient mode
+				if !failing {
+					log.Printf("[ZtunnelMetrics] Failed to get ztunnel pods: %v", err)
+				}
+				failing = true
+				continue
+			}
+			failing = false
+
+			for _, pod := range pods {
+				ztunnelMetrics, err := scrapeZtunnelPod(client, pod)
+				if err != nil {
+					log.Printf("[ZtunnelMetrics] Failed to scrape %s/%s: %v", pod.Namespace, pod.Name, err)
+					continue
+				}
+				processor.InsertMetrics(ztunnelMetrics)
+			}
+		case <-stopChan:
+			return
+		}
+	}
+}
+
+// scrapeZtunnelPod Function
+func scrapeZtunnelPod(client *http.Client, pod corev1.Pod) (*protobuf.EnvoyMetrics, error) {
+	url := fmt.Sprintf("http://%s/metrics", net.JoinHostPort(pod.Status.PodIP, ztunnelMetricsPort))
+
+	resp, err := client.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %s", resp.Status)
+	}
+
+	var parser expfmt.TextParser
+	families, err := parser.TextToMetricFamilies(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	return generateMetricsFromZtunnel(pod, families), nil
+}
+
+// generateMetricsFromZtunnel Function that converts the L4 metrics of a ztunnel pod into Envoy metrics
+// Each series is keyed by its name and the ends of the connection (e.g., istio_tcp_sent_bytes_total{source_workload="sleep",...})
+func generateMetricsFromZtunnel(pod corev1.Pod, families map[string]*dto.MetricFamily) *protobuf.EnvoyMetrics {
+	ztunnelMetrics := &protobuf.EnvoyMetrics{
+		TimeStamp: strconv.FormatInt(time.Now().UnixMilli(), 10),
+
+		Namespace: pod.Namespace,
+		Name:      pod.Name,
+		IPAddress: pod.Status.PodIP,
+		Labels:    pod.Labels,
+
+		Metrics: make(map[string]*protobuf.MetricValue),
+	}
+
+	values := map[string]map[string]float64{
+		"GAUGE":     make(map[string]float64),
+		"COUNTER":   make(map[string]float64),
+		"HISTOGRAM": make(map[string]float64),
+		"SUMMARY":   make(map[string]float64),
+	}
+
+	for name, family := range families {
+		if !strings.HasPrefix(name, ztunnelMetricPrefix) {
+			continue
+		}
+
+		for _, metric := range family.GetMetric() {
+			metricType, value := ztunnelValue(name, family.GetType(), metric)
+
+			// Series differing only in the labels left out are added up
+			values[metricType][ztunnelSeries(name, metric)] += value
+		}
+	}
+
+	for metricType, series := range values {
+		ztunnelMetrics.Metrics[metricType] = &protobuf.MetricValue{Value: make(map[string]string, len(series))}
+		for key, value := range series {
+			ztunnelMetrics.Metrics[metricType].Value[key] = strconv.FormatFloat(value, 'f', -1, 64)
+		}
+	}
+
+	return ztunnelMetrics
+}
+
+// ztunnelValue Function that gives the type and the value of a metric
+// ztunnel serves the OpenMetrics format, where counters come without their type (e.g., istio_tcp_sent_bytes_total)
+func ztunnelValue(name string, familyType dto.MetricType, metric *dto.Metric) (string, float64) {
+	switch familyType {
+	case dto.MetricType_COUNTER:
+		return "COUNTER", metric.GetCounter().GetValue()
+	case dto.MetricType_GAUGE:
+		return "GAUGE", metric.GetGauge().GetValue()
+	case dto.MetricType_HISTOGRAM:
+		return "HISTOGRAM", float64(metric.GetHistogram().GetSampleCount())
+	case dto.MetricType_SUMMARY:
+		return "SUMMARY", float64(metric.GetSummary().GetSampleCount())
+	}
+
+	if strings.HasSuffix(name, "_total") {
+		return "COUNTER", metric.GetUntyped().GetValue()
+	}
+	return "GAUGE", metric.GetUntyped().GetValue()
+}
+
+// ztunnelSeries Function that gives the key of a series with the labels kept from ztunnel metrics
+func ztunnelSeries(name string, metric *dto.Metric) string {
+	labels := make(map[string]string)
+	for _, label := range metric.GetLabel() {
+		labels[label.GetName()] = label.GetValue()
+	}
+
+	pairs := make([]string, 0, len(ztunnelLabels))
+	for _, key := range ztunnelLabels {
+		if value := labels[key]; value != "" && value != "unknown" {
+			pairs = append(pairs, fmt.Sprintf("%s=%q", key, value))
+		}
+	}
+
+	return name + "{" + strings.Join(pairs, ",") + "}"
+}
+
+// == //
@@ -19,9 +19,22 @@ type SentryFlowConfig struct {
 	ExporterAddr string // IP address to use for exporter gRPC
 	ExporterPort string // Port to use for exporter gRPC
 
+	MetricsAddr string // IP address to serve Prometheus metrics
+	MetricsPort string // Port to serve Prometheus metrics (empty to disable)
+
+	CollectorService string // Address (host:port) of the SentryFlow collector given to Istio proxies
+
+	IstioPatchMode   string // How Istio proxies are told to send access logs (configMap or telemetry)
+	TelemetryScope   string // Where Telemetry resources are created in the telemetry mode (mesh or namespace)
+	IstioPatchDryRun bool   // Only log the changes that would be made to Istio
+
+	ZtunnelScrapePeriod int // Period for scraping L4 metrics from ztunnel in the Istio ambient mode (0 to disable)
+
 	PatchingNamespaces           bool // Enable/Disable patching namespaces with 'istio-injection'
 	RestartingPatchedDeployments bool // Enable/Disable restarting deployments after patching
 
+	IPHistoryHorizon int // Time to keep past assignments of IP addresses to pods and services
+
 	AggregationPeriod int // Period for aggregating metrics
 	CleanUpPeriod     int // Period for cleaning up outdated metrics
 
@@ -29,6 +42,37 @@ type SentryFlowConfig struct {
 	AIEngineServicePort string // Port for AI Engine
 	AIEngineBatchSize   int    // Batch Size to send APIs to AI Engine
 
+	APIInventoryFile       string // File to persist the API inventory
+	APIInventorySavePeriod int    // Period for saving the API inventory
+
+	APISpecRefreshPeriod int             // Period for reloading API specs
+	APISpecs             []APISpecConfig // API specs to compare observed traffic with (from the config file)
+
+	AnomalyLearningPeriod int     // Period for learning traffic baselines before detecting anomalies
+	AnomalyThreshold      float64 // Score (deviation from the baseline in standard deviations) to report anomalies
+
+	ServiceGraphHalfLife int // Half-life of the request counts in the service graph
+	RequestChainTimeout  int // Time to wait for more hops of a request before exporting its chain
+
+	AlertRules []AlertRuleConfig // Alert rules evaluated on API logs (from the config file)
+	Sinks      []SinkConfig      // Sinks to send alerts and findings to (from the config file)
+
+	Redaction RedactionConfig // Redaction of sensitive data in API logs (from the config file)
+	Filter    FilterConfig    // Filtering and sampling of API logs before export (from the config file)
+
+	SecurityDetectors SecurityDetectorsConfig // Heuristic detectors of the OWASP API Security Top 10 (from the config file)
+
+	Egress EgressConfig // Networks for classifying destinations outside of the cluster (from the config file)
+
+	Scope ScopeConfig // Namespaces and labels of the resources to watch, patch and process (from the config file)
+
+	Stages []StageConfig // Ordered stages processing API logs (from the config file, built-in stages if empty)
+
+	Restarts RestartConfig // Gradual restarts of workloads in patched namespaces for injecting sidecars (from the config file)
+
+	ConfigFile     string // Path to the config file for structured settings
+	ConfigResource string // SentryFlowConfig resource (namespace/name) overriding structured settings while running
+
 	Debug bool // Enable/Disable SentryFlow debug mode
 }
 
@@ -37,7 +81,7 @@ var GlobalConfig SentryFlowConfig
 
 // init Function
 func init() {
-	_ = LoadConfig()
+	registerFlags()
 }
 
 // Config const
@@ -48,9 +92,22 @@ const (
 	ExporterAddr string = "exporterAddr"
 	ExporterPort string = "exporterPort"
 
+	MetricsAddr string = "metricsAddr"
+	MetricsPort string = "metricsPort"
+
+	CollectorService string = "collectorService"
+
+	IstioPatchMode   string = "istioPatchMode"
+	TelemetryScope   string = "telemetryScope"
+	IstioPatchDryRun string = "istioPatchDryRun"
+
+	ZtunnelScrapePeriod string = "ztunnelScrapePeriod"
+
 	PatchingNamespaces           string = "patchingNamespaces"
 	RestartingPatchedDeployments string = "restartingPatchedDeployments"
 
+	IPHistoryHorizon string = "ipHistoryHorizon"
+
 	AggregationPeriod string = "aggregationPeriod"
 	CleanUpPeriod     string = "cleanUpPeriod"
 
@@ -58,28 +115,121 @@ const (
 	AIEngineServicePort string = "aiEngineServicePort"
 	AIEngineBatchSize   string = "aiEngineBatchSize"
 
+	APIInventoryFile       string = "apiInventoryFile"
+	APIInventorySavePeriod string = "apiInventorySavePeriod"
+
+	APISpecRefreshPeriod string = "apiSpecRefreshPeriod"
+
+	AnomalyLearningPeriod string = "anomalyLearningPeriod"
+	AnomalyThreshold      string = "anomalyThreshold"
+
+	ServiceGraphHalfLife string = "serviceGraphHalfLife"
+	RequestChainTimeout  string = "requestChainTimeout"
+
+	ConfigFile     string = "configFile"
+	ConfigResource string = "configResource"
+
 	Debug string = "debug"
 )
 
-func readCmdLineParams() {
-	collectorAddrStr := flag.String(CollectorAddr, "0.0.0.0", "Address for Collector gRPC")
-	collectorPortStr := flag.String(CollectorPort, "4317", "Port for Collector gRPC")
+// Command-line flags (registered in init, parsed by main)
+var (
+	collectorAddrStr *string
+	collectorPortStr *string
+
+	exporterAddrStr *string
+	exporterPortStr *string
+
+	metricsAddrStr *string
+	metricsPortStr *string
+
+	collectorServiceStr *string
+
+	istioPatchModeStr *string
+	telemetryScopeStr *string
+	istioPatchDryRunB *bool
+
+	ztunnelScrapePeriodInt *int
+
+	patchingNamespacesB           *bool
+	restartingPatchedDeploymentsB *bool
+
+	ipHistoryHorizonInt *int
+
+	aggregationPeriodInt *int
+	cleanUpPeriodInt     *int
+
+	aiEngineServiceStr     *string
+	aiEngineServicePortStr *string
+	aiEngineBatchSizeInt   *int
+
+	apiInventoryFileStr       *string
+	apiInventorySavePeriodInt *int
+
+	apiSpecRefreshPeriodInt *int
+
+	anomalyLearningPeriodInt *int
+	anomalyThresholdFloat    *float64
+
+	serviceGraphHalfLifeInt *int
+	requestChainTimeoutInt  *int
 
-	exporterAddrStr := flag.String(ExporterAddr, "0.0.0.0", "Address for Exporter gRPC")
-	exporterPortStr := flag.String(ExporterPort, "8080", "Port for Exporter gRPC")
+	configFileStr     *string
+	configResourceStr *string
 
-	patchingNamespacesB := flag.Bool(PatchingNamespaces, false, "Enable patching 'istio-injection' to all namespaces")
-	restartingPatchedDeploymentsB := flag.Bool(RestartingPatchedDeployments, false, "Enable restarting the deployments in all patched namespaces")
+	configDebugB *bool
+)
+
+// registerFlags Function
+func registerFlags() {
+	collectorAddrStr = flag.String(CollectorAddr, "0.0.0.0", "Address for Collector gRPC")
+	collectorPortStr = flag.String(CollectorPort, "4317", "Port for Collector gRPC")
+
+	exporterAddrStr = flag.String(ExporterAddr, "0.0.0.0", "Address for Exporter gRPC")
+	exporterPortStr = flag.String(ExporterPort, "8080", "Port for Exporter gRPC")
+
+	metricsAddrStr = flag.String(MetricsAddr, "0.0.0.0", "Address for Prometheus metrics")
+	metricsPortStr = flag.String(MetricsPort, "8081", "Port for Prometheus metrics (empty to disable)")
+
+	collectorServiceStr = flag.String(CollectorService, "sentryflow.sentryflow.svc.cluster.local:4317", "Address (host:port) of the SentryFlow collector for Istio proxies")
+
+	istioPatchModeStr = flag.String(IstioPatchMode, "configMap", "How to enable access logs in Istio (configMap: rewrite the mesh config, telemetry: create Telemetry resources)")
+	telemetryScopeStr = flag.String(TelemetryScope, "mesh", "Where to create Telemetry resources in the telemetry mode (mesh or namespace)")
+	istioPatchDryRunB = flag.Bool(IstioPatchDryRun, false, "Only log the changes that would be made to Istio (diff of the mesh config, Telemetry resources)")
+
+	ztunnelScrapePeriodInt = flag.Int(ZtunnelScrapePeriod, 30, "Period for scraping L4 metrics from ztunnel in the Istio ambient mode (0 to disable)")
+
+	patchingNamespacesB = flag.Bool(PatchingNamespaces, false, "Enable patching 'istio-injection' to all namespaces")
+	restartingPatchedDeploymentsB = flag.Bool(RestartingPatchedDeployments, false, "Enable restarting the workloads in patched namespaces (see restarts in the config file)")
+
+	ipHistoryHorizonInt = flag.Int(IPHistoryHorizon, 600, "Time to keep past assignments of IP addresses for resolving late API logs")
+
+	aggregationPeriodInt = flag.Int(AggregationPeriod, 1, "Period for aggregating metrics")
+	cleanUpPeriodInt = flag.Int(CleanUpPeriod, 5, "Period for cleanning up outdated metrics")
+
+	aiEngineServiceStr = flag.String(AIEngineService, "ai-engine.sentryflow.svc.cluster.local", "Address for SentryFlow AI Engine")
+	aiEngineServicePortStr = flag.String(AIEngineServicePort, "5000", "Port for SentryFlow AI Engine")
+	aiEngineBatchSizeInt = flag.Int(AIEngineBatchSize, 5, "Batch size to send APIs to SentryFlow AI Engine")
 
-	aggregationPeriodInt := flag.Int(AggregationPeriod, 1, "Period for aggregating metrics")
-	cleanUpPeriodInt := flag.Int(CleanUpPeriod, 5, "Period for cleanning up outdated metrics")
+	apiInventoryFileStr = flag.String(APIInventoryFile, "/var/lib/sentryflow/apiInventory.json", "File to persist the API inventory (empty to disable)")
+	apiInventorySavePeriodInt = flag.Int(APIInventorySavePeriod, 60, "Period for saving the API inventory")
 
-	aiEngineServiceStr := flag.String(AIEngineService, "ai-engine.sentryflow.svc.cluster.local", "Address for SentryFlow AI Engine")
-	aiEngineServicePortStr := flag.String(AIEngineServicePort, "5000", "Port for SentryFlow AI Engine")
-	aiEngineBatchSizeInt := flag.Int(AIEngineBatchSize, 5, "Batch size to send APIs to SentryFlow AI Engine")
+	apiSpecRefreshPeriodInt = flag.Int(APISpecRefreshPeriod, 60, "Period for reloading API specs from files and ConfigMaps")
 
-	configDebugB := flag.Bool(Debug, false, "Enable debugging mode")
+	anomalyLearningPeriodInt = flag.Int(AnomalyLearningPeriod, 3600, "Period for learning traffic baselines before detecting anomalies")
+	anomalyThresholdFloat = flag.Float64(AnomalyThreshold, 3.0, "Score (in standard deviations) to report traffic anomalies")
 
+	serviceGraphHalfLifeInt = flag.Int(ServiceGraphHalfLife, 600, "Half-life of the request counts in the service graph")
+	requestChainTimeoutInt = flag.Int(RequestChainTimeout, 10, "Time to wait for more hops of a request before exporting its chain")
+
+	configFileStr = flag.String(ConfigFile, "/etc/sentryflow/config.yaml", "Config file for structured settings (e.g., API specs)")
+	configResourceStr = flag.String(ConfigResource, "sentryflow/sentryflow", "SentryFlowConfig resource (namespace/name) applied while running (empty to disable)")
+
+	configDebugB = flag.Bool(Debug, false, "Enable debugging mode")
+}
+
+// readCmdLineParams Function
+func readCmdLineParams() {
 	var flags []string
 	flag.VisitAll(func(f *flag.Flag) {
 		kv := fmt.Sprintf("%s:%v", f.Name, f.Value)
@@ -87,17 +237,28 @@ func readCmdLineParams() {
 	})
 	log.Printf("Arguments [%s]", strings.Join(flags, " "))
 
-	flag.Parse()
-
 	viper.SetDefault(CollectorAddr, *collectorAddrStr)
 	viper.SetDefault(CollectorPort, *collectorPortStr)
 
 	viper.SetDefault(ExporterAddr, *exporterAddrStr)
 	viper.SetDefault(ExporterPort, *exporterPortStr)
 
+	viper.SetDefault(MetricsAddr, *metricsAddrStr)
+	viper.SetDefault(MetricsPort, *metricsPortStr)
+
+	viper.SetDefault(CollectorService, *collectorServiceStr)
+
+	viper.SetDefault(IstioPatchMode, *istioPatchModeStr)
+	viper.SetDefault(TelemetryScope, *telemetryScopeStr)
+	viper.SetDefault(IstioPatchDryRun, *istioPatchDryRunB)
+
+	viper.SetDefault(ZtunnelScrapePeriod, *ztunnelScrapePeriodInt)
+
 	viper.SetDefault(PatchingNamespaces, *patchingNamespacesB)
 	viper.SetDefault(RestartingPatchedDeployments, *restartingPatchedDeploymentsB)
 
+	viper.SetDefault(IPHistoryHorizon, *ipHistoryHorizonInt)
+
 	viper.SetDefault(AggregationPeriod, *aggregationPeriodInt)
 	viper.SetDefault(CleanUpPeriod, *cleanUpPeriodInt)
 
@@ -105,10 +266,24 @@ func readCmdLineParams() {
 	viper.SetDefault(AIEngineServicePort, *aiEngineServicePortStr)
 	viper.SetDefault(AIEngineBatchSize, *aiEngineBatchSizeInt)
 
+	viper.SetDefault(APIInventoryFile, *apiInventoryFileStr)
+	viper.SetDefault(APIInventorySavePeriod, *apiInventorySavePeriodInt)
+
+	viper.SetDefault(APISpecRefreshPeriod, *apiSpecRefreshPeriodInt)
+
+	viper.SetDefault(AnomalyLearningPeriod, *anomalyLearningPeriodInt)
+	viper.SetDefault(AnomalyThreshold, *anomalyThresholdFloat)
+
+	viper.SetDefault(ServiceGraphHalfLife, *serviceGraphHalfLifeInt)
+	viper.SetDefault(RequestChainTimeout, *requestChainTimeoutInt)
+
+	viper.SetDefault(ConfigFile, *configFileStr)
+	viper.SetDefault(ConfigResource, *configResourceStr)
+
 	viper.SetDefault(Debug, *configDebugB)
 }
 
-// LoadConfig Load configuration
+// LoadConfig Load configuration (after flags are parsed, tests load the defaults)
 func LoadConfig() error {
 	// Read configuration from command line
 	readCmdLineParams()
@@ -122,9 +297,22 @@ func LoadConfig() error {
 	GlobalConfig.ExporterAddr = viper.GetString(ExporterAddr)
 	GlobalConfig.ExporterPort = viper.GetString(ExporterPort)
 
+	GlobalConfig.MetricsAddr = viper.GetString(MetricsAddr)
+	GlobalConfig.MetricsPort = viper.GetString(MetricsPort)
+
+	GlobalConfig.CollectorService = viper.GetString(CollectorService)
+
+	GlobalConfig.IstioPatchMode = viper.GetString(IstioPatchMode)
+	GlobalConfig.TelemetryScope = viper.GetString(TelemetryScope)
+	GlobalConfig.IstioPatchDryRun = viper.GetBool(IstioPatchDryRun)
+
+	GlobalConfig.ZtunnelScrapePeriod = viper.GetInt(ZtunnelScrapePeriod)
+
 	GlobalConfig.PatchingNamespaces = viper.GetBool(PatchingNamespaces)
 	GlobalConfig.RestartingPatchedDeployments = viper.GetBool(RestartingPatchedDeployments)
 
+	GlobalConfig.IPHistoryHorizon = viper.GetInt(IPHistoryHorizon)
+
 	GlobalConfig.AggregationPeriod = viper.GetInt(AggregationPeriod)
 	GlobalConfig.CleanUpPeriod = viper.GetInt(CleanUpPeriod)
 
@@ -132,9 +320,56 @@ func LoadConfig() error {
 	GlobalConfig.AIEngineServicePort = viper.GetString(AIEngineServicePort)
 	GlobalConfig.AIEngineBatchSize = viper.GetInt(AIEngineBatchSize)
 
+	GlobalConfig.APIInventoryFile = viper.GetString(APIInventoryFile)
+	GlobalConfig.APIInventorySavePeriod = viper.GetInt(APIInventorySavePeriod)
+
+	GlobalConfig.APISpecRefreshPeriod = viper.GetInt(APISpecRefreshPeriod)
+
+	GlobalConfig.AnomalyLearningPeriod = viper.GetInt(AnomalyLearningPeriod)
+	GlobalConfig.AnomalyThreshold = viper.GetFloat64(AnomalyThreshold)
+
+	GlobalConfig.ServiceGraphHalfLife = viper.GetInt(ServiceGraphHalfLife)
+	GlobalConfig.RequestChainTimeout = viper.GetInt(RequestChainTimeout)
+
+	GlobalConfig.ConfigFile = viper.GetString(ConfigFile)
+	GlobalConfig.ConfigResource = viper.GetString(ConfigResource)
+
 	GlobalConfig.Debug = viper.GetBool(Debug)
 
-	log.Printf("Configuration [%+v]", GlobalConfig)
+	// Default structured settings
+	setDefaultSections(&GlobalConfig)
+
+	// Read structured settings from the config file
+	if err := loadConfigFile(GlobalConfig.ConfigFile); err != nil {
+		log.Printf("Failed to load the configuration file %s: %v", GlobalConfig.ConfigFile, err)
+		return err
+	}
+
+	log.Printf("Configuration [%+v]", GlobalConfig.masked())
 
 	return nil
 }
+
+// masked Function that gives a copy of the configuration without secrets (for logging)
+func (sfCfg SentryFlowConfig) masked() SentryFlowConfig {
+	sinks := make([]SinkConfig, len(sfCfg.Sinks))
+	for i, sink := range sfCfg.Sinks {
+		sinks[i] = sink
+
+		// Headers of webhooks carry credentials (e.g., Authorization: Bearer ...)
+		if len(sink.Headers) > 0 {
+			sinks[i].Headers = make(map[string]string, len(sink.Headers))
+			for key := range sink.Headers {
+				sinks[i].Headers[key] = "***"
+			}
+		}
+	}
+	sfCfg.Sinks = sinks
+
+	// The salt keeps hashed values from being reversed by hashing guesses
+	if sfCfg.Redaction.HashSalt != "" {
+		sfCfg.Redaction.HashSalt = "***"
+	}
+
+	return sfCfg
+}
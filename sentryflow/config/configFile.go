@@ -0,0 +1,267 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"reflect"
+	"time"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/spf13/viper"
+)
+
+// == //
+
+// APISpecConfig structure
+type APISpecConfig struct {
+	Namespace  string `mapstructure:"namespace"`  // Namespace of the workload
+	Workload   string `mapstructure:"workload"`   // Name of the workload
+	File       string `mapstructure:"file"`       // Path to an OpenAPI spec (YAML or JSON)
+	ConfigMap  string `mapstructure:"configMap"`  // ConfigMap containing an OpenAPI spec (in the namespace of the workload)
+	Key        string `mapstructure:"key"`        // Key of the OpenAPI spec in the ConfigMap
+	Deprecated bool   `mapstructure:"deprecated"` // Whether every API in the spec is deprecated
+}
+
+// AlertRuleConfig structure
+type AlertRuleConfig struct {
+	Name        string `mapstructure:"name"`        // Name of the rule
+	Description string `mapstructure:"description"` // Description of the rule
+	Severity    string `mapstructure:"severity"`    // Severity of alerts (Info, Low, Medium, High, Critical)
+	Condition   string `mapstructure:"condition"`   // Expression over API log fields (e.g., dstWorkload == "payments" && responseCode >= 500)
+
+	Window    time.Duration `mapstructure:"window"`    // Window for counting matches (e.g., 1m)
+	Threshold int           `mapstructure:"threshold"` // Alert when more than threshold matches happen within the window
+	GroupBy   []string      `mapstructure:"groupBy"`   // Fields to count matches separately (e.g., dstWorkload)
+
+	NewValueOf     string        `mapstructure:"newValueOf"`     // Alert when a field (e.g., srcWorkload) has a value never seen before
+	LearningPeriod time.Duration `mapstructure:"learningPeriod"` // Period after startup when new values are learned without alerting
+
+	Cooldown time.Duration `mapstructure:"cooldown"` // Period for suppressing alerts of the same group after an alert
+	Samples  int           `mapstructure:"samples"`  // Number of API logs attached to an alert as evidence
+}
+
+// SinkConfig structure
+type SinkConfig struct {
+	Type    string            `mapstructure:"type"`    // Type of the sink (file, webhook)
+	Path    string            `mapstructure:"path"`    // Path to the file (file)
+	URL     string            `mapstructure:"url"`     // URL to post events to (webhook)
+	Headers map[string]string `mapstructure:"headers"` // Additional HTTP headers (webhook)
+	Events  []string          `mapstructure:"events"`  // Kinds of events to send (alerts, findings, apiEvents), all if empty
+}
+
+// RedactionConfig structure
+type RedactionConfig struct {
+	Enabled     bool                     `mapstructure:"enabled"`     // Enable/Disable redacting sensitive data in API logs
+	Mode        string                   `mapstructure:"mode"`        // How to redact values (mask, hash, drop)
+	HashSalt    string                   `mapstructure:"hashSalt"`    // Salt for hashing values
+	Detectors   []string                 `mapstructure:"detectors"`   // Built-in detectors to use (all if empty)
+	Modes       map[string]string        `mapstructure:"modes"`       // Modes per detector (e.g., email: hash)
+	QueryParams []string                 `mapstructure:"queryParams"` // Additional query parameters whose values are always redacted
+	Patterns    []RedactionPatternConfig `mapstructure:"patterns"`    // Custom detectors
+}
+
+// RedactionPatternConfig structure
+type RedactionPatternConfig struct {
+	Name     string `mapstructure:"name"`     // Name of the detector
+	Regex    string `mapstructure:"regex"`    // Regular expression for sensitive values
+	Mode     string `mapstructure:"mode"`     // How to redact values (the default mode if empty)
+	Severity string `mapstructure:"severity"` // Severity of findings (Medium if empty)
+}
+
+// FilterConfig structure
+type FilterConfig struct {
+	AlwaysKeepErrors bool               `mapstructure:"alwaysKeepErrors"` // Keep API logs with error responses (4xx, 5xx, gRPC errors) regardless of rules
+	HeadSampling     float64            `mapstructure:"headSampling"`     // Ratio of API logs kept when no rule matches
+	Rules            []FilterRuleConfig `mapstructure:"rules"`            // Ordered rules (the first matching rule decides)
+}
+
+// FilterRuleConfig structure
+type FilterRuleConfig struct {
+	Name        string  `mapstructure:"name"`        // Name of the rule
+	Condition   string  `mapstructure:"condition"`   // Expression over API log fields (everything if empty)
+	Action      string  `mapstructure:"action"`      // What to do with matching API logs (keep, drop, sample)
+	Ratio       float64 `mapstructure:"ratio"`       // Ratio of matching API logs kept (sample)
+	PerEndpoint float64 `mapstructure:"perEndpoint"` // Maximum number of API logs kept per second for each endpoint (sample)
+}
+
+// SecurityDetectorsConfig structure
+type SecurityDetectorsConfig struct {
+	Disabled             []string `mapstructure:"disabled"`             // Detectors turned off (bola, auth, exposure, consumption)
+	EnumerationThreshold int      `mapstructure:"enumerationThreshold"` // Distinct sequential object IDs called by a caller to report enumeration
+	AuthRatio            float64  `mapstructure:"authRatio"`            // Ratio of authenticated calls above which an endpoint requires authentication
+	ExposureFactor       float64  `mapstructure:"exposureFactor"`       // Times the usual response size to report excessive data exposure
+	BurstRequests        int      `mapstructure:"burstRequests"`        // Requests by a caller within a burst window to report a burst
+	BurstFactor          float64  `mapstructure:"burstFactor"`          // Times the usual requests by a caller within a burst window to report a burst
+}
+
+// EgressConfig structure
+type EgressConfig struct {
+	ClusterCIDRs []string `mapstructure:"clusterCIDRs"` // Pod and Service networks of the cluster (e.g., 10.244.0.0/16)
+	PrivateCIDRs []string `mapstructure:"privateCIDRs"` // Networks outside of the cluster that are not on the internet
+}
+
+// ScopeConfig structure
+type ScopeConfig struct {
+	Namespaces        []string `mapstructure:"namespaces"`        // Namespaces to watch and process (all if empty, only these with namespace-scoped RBAC)
+	ExcludeNamespaces []string `mapstructure:"excludeNamespaces"` // Namespaces to ignore (e.g., kube-system)
+	NamespaceSelector string   `mapstructure:"namespaceSelector"` // Label selector of namespaces to process and patch (e.g., tenant=a), not with Namespaces
+	PodSelector       string   `mapstructure:"podSelector"`       // Label selector of pods to watch (e.g., sentryflow.io/monitor!=false)
+}
+
+// RestartConfig structure
+type RestartConfig struct {
+	Namespaces         []string      `mapstructure:"namespaces"`         // Namespaces to restart workloads in (all namespaces in scope with sidecar injection enabled if empty)
+	Selector           string        `mapstructure:"selector"`           // Label selector of workloads to restart (e.g., tier!=database)
+	Kinds              []string      `mapstructure:"kinds"`              // Kinds of workloads to restart (Deployment, StatefulSet, DaemonSet)
+	MaxConcurrent      int           `mapstructure:"maxConcurrent"`      // Maximum number of rollouts in progress at a time
+	Timeout            time.Duration `mapstructure:"timeout"`            // Time to wait for a rollout to become ready (e.g., 5m)
+	OnlyMissingSidecar bool          `mapstructure:"onlyMissingSidecar"` // Only restart workloads with pods lacking the istio-proxy container
+}
+
+// StageConfig structure
+type StageConfig struct {
+	Name   string                 `mapstructure:"name"`   // Name of a registered stage
+	Config map[string]interface{} `mapstructure:"config"` // Settings of the stage
+}
+
+// == //
+
+// loadConfigFile Function that loads structured settings (e.g., API specs) from a YAML file
+// Scalar settings keep coming from command line flags and environment variables
+func loadConfigFile(fileName string) error {
+	if fileName == "" {
+		return nil
+	}
+
+	if _, err := os.Stat(fileName); os.IsNotExist(err) {
+		log.Printf("Configuration file %s does not exist, skipping", fileName)
+		return nil
+	}
+
+	cfg := viper.New()
+	cfg.SetConfigFile(fileName)
+	cfg.SetConfigType("yaml")
+
+	if err := cfg.ReadInConfig(); err != nil {
+		return err
+	}
+
+	return unmarshalSections(cfg, &GlobalConfig)
+}
+
+// sectionsOf Function that gives the structured settings of a config by their names in the config file
+func sectionsOf(sfCfg *SentryFlowConfig) map[string]interface{} {
+	return map[string]interface{}{
+		"apiSpecs":          &sfCfg.APISpecs,
+		"alertRules":        &sfCfg.AlertRules,
+		"sinks":             &sfCfg.Sinks,
+		"redaction":         &sfCfg.Redaction,
+		"filter":            &sfCfg.Filter,
+		"securityDetectors": &sfCfg.SecurityDetectors,
+		"egress":            &sfCfg.Egress,
+		"scope":             &sfCfg.Scope,
+		"stages":            &sfCfg.Stages,
+		"restarts":          &sfCfg.Restarts,
+	}
+}
+
+// unmarshalSections Function that reads the sections set in a config (lists and maps replace the defaults instead of being merged)
+func unmarshalSections(cfg *viper.Viper, sfCfg *SentryFlowConfig) error {
+	zeroFields := viper.DecoderConfigOption(func(dc *mapstructure.DecoderConfig) { dc.ZeroFields = true })
+
+	for name, section := range sectionsOf(sfCfg) {
+		if !cfg.IsSet(name) {
+			continue
+		}
+		if err := cfg.UnmarshalKey(name, section, zeroFields); err != nil {
+			return fmt.Errorf("%s: %v", name, err)
+		}
+	}
+
+	return nil
+}
+
+// setDefaultSections Function
+func setDefaultSections(sfCfg *SentryFlowConfig) {
+	// Redaction changes the paths and headers sent to consumers, so it is opt-in
+	sfCfg.Redaction = RedactionConfig{Enabled: false, Mode: "mask"}
+
+	// Keep every API log unless the config file says otherwise
+	sfCfg.Filter = FilterConfig{AlwaysKeepErrors: true, HeadSampling: 1.0}
+
+	// Default thresholds of the security detectors
+	sfCfg.SecurityDetectors = SecurityDetectorsConfig{
+		EnumerationThreshold: 20,
+		AuthRatio:            0.95,
+		ExposureFactor:       10,
+		BurstRequests:        100,
+		BurstFactor:          5,
+	}
+
+	// One rollout at a time, only for workloads without sidecars
+	sfCfg.Restarts = RestartConfig{
+		Kinds:              []string{"Deployment", "StatefulSet", "DaemonSet"},
+		MaxConcurrent:      1,
+		Timeout:            5 * time.Minute,
+		OnlyMissingSidecar: true,
+	}
+
+	// Private networks (RFC 1918, carrier-grade NAT, loopback, link-local, unique local)
+	sfCfg.Egress = EgressConfig{
+		PrivateCIDRs: []string{
+			"10.0.0.0/8", "172.16.0.0/12", "192.168.0.0/16", "100.64.0.0/10", "127.0.0.0/8", "169.254.0.0/16",
+			"fc00::/7", "fe80::/10", "::1/128",
+		},
+	}
+}
+
+// runtimeSections are the sections that can be changed while running (e.g., by a SentryFlowConfig resource)
+var runtimeSections = map[string]bool{
+	"alertRules":        true,
+	"sinks":             true,
+	"redaction":         true,
+	"filter":            true,
+	"securityDetectors": true,
+	"egress":            true,
+	"scope":             true,
+	"stages":            true,
+}
+
+// ParseConfigSpec Function that applies the sections of a SentryFlowConfig resource on top of the given config
+// A section in the resource replaces the same section of the config file (starting from the defaults)
+func ParseConfigSpec(base SentryFlowConfig, spec map[string]interface{}) (SentryFlowConfig, error) {
+	parsed := base
+
+	defaults := SentryFlowConfig{}
+	setDefaultSections(&defaults)
+
+	sections := sectionsOf(&parsed)
+	defaultSections := sectionsOf(&defaults)
+
+	for name := range spec {
+		section, ok := sections[name]
+		if !ok {
+			return base, fmt.Errorf("unknown section %q", name)
+		}
+		if !runtimeSections[name] {
+			return base, fmt.Errorf("section %q cannot be changed while running", name)
+		}
+		reflect.ValueOf(section).Elem().Set(reflect.ValueOf(defaultSections[name]).Elem())
+	}
+
+	cfg := viper.New()
+	if err := cfg.MergeConfigMap(spec); err != nil {
+		return base, err
+	}
+
+	if err := unmarshalSections(cfg, &parsed); err != nil {
+		return base, err
+	}
+
+	return parsed, nil
+}
+
+// == //
@@ -0,0 +1,103 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package core
+
+import (
+	"fmt"
+	"log"
+	"reflect"
+	"sync"
+
+	"github.com/5gsec/SentryFlow/config"
+	"github.com/5gsec/SentryFlow/egress"
+	"github.com/5gsec/SentryFlow/exporter"
+	"github.com/5gsec/SentryFlow/filter"
+	"github.com/5gsec/SentryFlow/k8s"
+	"github.com/5gsec/SentryFlow/owasp"
+	"github.com/5gsec/SentryFlow/pipeline"
+	"github.com/5gsec/SentryFlow/redaction"
+	"github.com/5gsec/SentryFlow/rules"
+)
+
+// == //
+
+// configLock serializes live changes of the configuration
+var configLock sync.Mutex
+
+// sectionLoader Structure
+type sectionLoader struct {
+	section string
+	changed bool
+	load    func(sfCfg config.SentryFlowConfig) error
+}
+
+// stagesOf Function that gives the stages of a config (the default ones if none is set)
+func stagesOf(sfCfg config.SentryFlowConfig) []config.StageConfig {
+	if len(sfCfg.Stages) == 0 {
+		return pipeline.DefaultStages()
+	}
+	return sfCfg.Stages
+}
+
+// sectionLoaders Function that gives the loaders of the sections that can be changed while running, marking the ones differing from the current config
+func sectionLoaders(sfCfg, current config.SentryFlowConfig) []sectionLoader {
+	return []sectionLoader{
+		{"scope", !reflect.DeepEqual(sfCfg.Scope, current.Scope),
+			func(c config.SentryFlowConfig) error { return k8s.LoadScope(c.Scope) }},
+		{"redaction", !reflect.DeepEqual(sfCfg.Redaction, current.Redaction),
+			func(c config.SentryFlowConfig) error { return redaction.LoadRedaction(c.Redaction) }},
+		{"filter", !reflect.DeepEqual(sfCfg.Filter, current.Filter),
+			func(c config.SentryFlowConfig) error { return filter.LoadFilter(c.Filter) }},
+		{"alertRules", !reflect.DeepEqual(sfCfg.AlertRules, current.AlertRules),
+			func(c config.SentryFlowConfig) error { return rules.LoadRules(c.AlertRules) }},
+		{"securityDetectors", !reflect.DeepEqual(sfCfg.SecurityDetectors, current.SecurityDetectors),
+			func(c config.SentryFlowConfig) error { return owasp.LoadDetectors(c.SecurityDetectors) }},
+		{"egress", !reflect.DeepEqual(sfCfg.Egress, current.Egress),
+			func(c config.SentryFlowConfig) error { return egress.LoadNetworks(c.Egress) }},
+		{"sinks", !reflect.DeepEqual(sfCfg.Sinks, current.Sinks),
+			func(c config.SentryFlowConfig) error { return exporter.LoadSinks(c.Sinks) }},
+		{"stages", !reflect.DeepEqual(stagesOf(sfCfg), stagesOf(current)),
+			func(c config.SentryFlowConfig) error { return pipeline.LoadStages(stagesOf(c)) }},
+	}
+}
+
+// applyConfig Function that applies a new configuration while running (keeping the current one if it is rejected)
+// Only the sections that changed are loaded again, so the others keep their state (e.g., sink queues, detector windows)
+func applyConfig(sfCfg config.SentryFlowConfig) error {
+	configLock.Lock()
+	defer configLock.Unlock()
+
+	loaded := make([]sectionLoader, 0)
+
+	for _, loader := range sectionLoaders(sfCfg, config.GlobalConfig) {
+		if !loader.changed {
+			continue
+		}
+
+		if err := loader.load(sfCfg); err != nil {
+			// The failing section and the ones loaded before it go back to the current configuration
+			for _, prev := range append(loaded, loader) {
+				if rollbackErr := prev.load(config.GlobalConfig); rollbackErr != nil {
+					log.Printf("[SentryFlow] Failed to restore the %s section: %v", prev.section, rollbackErr)
+				}
+			}
+			return fmt.Errorf("%s: %v", loader.section, err)
+		}
+
+		log.Printf("[SentryFlow] Reloaded the %s section", loader.section)
+		loaded = append(loaded, loader)
+	}
+
+	config.GlobalConfig.Scope = sfCfg.Scope
+	config.GlobalConfig.Redaction = sfCfg.Redaction
+	config.GlobalConfig.Filter = sfCfg.Filter
+	config.GlobalConfig.AlertRules = sfCfg.AlertRules
+	config.GlobalConfig.SecurityDetectors = sfCfg.SecurityDetectors
+	config.GlobalConfig.Egress = sfCfg.Egress
+	config.GlobalConfig.Sinks = sfCfg.Sinks
+	config.GlobalConfig.Stages = sfCfg.Stages
+
+	return nil
+}
+
+// == //
@@ -9,11 +9,25 @@ import (
 	"sync"
 	"syscall"
 
+	"github.com/5gsec/SentryFlow/anomaly"
+	"github.com/5gsec/SentryFlow/chains"
 	"github.com/5gsec/SentryFlow/collector"
 	"github.com/5gsec/SentryFlow/config"
+	"github.com/5gsec/SentryFlow/coverage"
+	"github.com/5gsec/SentryFlow/egress"
 	"github.com/5gsec/SentryFlow/exporter"
+	"github.com/5gsec/SentryFlow/filter"
+	"github.com/5gsec/SentryFlow/graph"
+	"github.com/5gsec/SentryFlow/inventory"
 	"github.com/5gsec/SentryFlow/k8s"
+	"github.com/5gsec/SentryFlow/metrics"
+	"github.com/5gsec/SentryFlow/openapi"
+	"github.com/5gsec/SentryFlow/owasp"
+	"github.com/5gsec/SentryFlow/pipeline"
 	"github.com/5gsec/SentryFlow/processor"
+	"github.com/5gsec/SentryFlow/redaction"
+	"github.com/5gsec/SentryFlow/risk"
+	"github.com/5gsec/SentryFlow/rules"
 )
 
 // == //
@@ -42,11 +56,18 @@ func NewSentryFlow() *SentryFlowService {
 func (sf *SentryFlowService) DestroySentryFlow() {
 	close(StopChan)
 
+	// Stop config controller
+	if k8s.StopConfigController() {
+		log.Print("[SentryFlow] Stopped Config Controller")
+	} else {
+		log.Print("[SentryFlow] Failed to stop Config Controller")
+	}
+
 	// Remove SentryFlow collector config from Kubernetes
-	if k8s.UnpatchIstioConfigMap() {
-		log.Print("[SentryFlow] Unpatched Istio ConfigMap")
+	if k8s.UnpatchIstio() {
+		log.Print("[SentryFlow] Unpatched Istio")
 	} else {
-		log.Print("[SentryFlow] Failed to unpatch Istio ConfigMap")
+		log.Print("[SentryFlow] Failed to unpatch Istio")
 	}
 
 	// Stop collector
@@ -63,6 +84,69 @@ func (sf *SentryFlowService) DestroySentryFlow() {
 		log.Print("[SentryFlow] Failed to stop Log Processors")
 	}
 
+	// Stop API inventory
+	if inventory.StopAPIInventory() {
+		log.Print("[SentryFlow] Stopped API Inventory")
+	} else {
+		log.Print("[SentryFlow] Failed to stop API Inventory")
+	}
+
+	// Stop API spec registry
+	if openapi.StopSpecRegistry() {
+		log.Print("[SentryFlow] Stopped API Spec Registry")
+	} else {
+		log.Print("[SentryFlow] Failed to stop API Spec Registry")
+	}
+
+	// Stop rule engine
+	if rules.StopRuleEngine() {
+		log.Print("[SentryFlow] Stopped Rule Engine")
+	} else {
+		log.Print("[SentryFlow] Failed to stop Rule Engine")
+	}
+
+	// Stop anomaly detector
+	if anomaly.StopAnomalyDetector() {
+		log.Print("[SentryFlow] Stopped Anomaly Detector")
+	} else {
+		log.Print("[SentryFlow] Failed to stop Anomaly Detector")
+	}
+
+	// Stop security detectors
+	if owasp.StopSecurityDetectors() {
+		log.Print("[SentryFlow] Stopped Security Detectors")
+	} else {
+		log.Print("[SentryFlow] Failed to stop Security Detectors")
+	}
+
+	// Stop service graph
+	if graph.StopServiceGraph() {
+		log.Print("[SentryFlow] Stopped Service Graph")
+	} else {
+		log.Print("[SentryFlow] Failed to stop Service Graph")
+	}
+
+	// Stop chain assembler
+	if chains.StopChainAssembler() {
+		log.Print("[SentryFlow] Stopped Chain Assembler")
+	} else {
+		log.Print("[SentryFlow] Failed to stop Chain Assembler")
+	}
+
+	// Stop risk scorer
+	if risk.StopRiskScorer() {
+		log.Print("[SentryFlow] Stopped Risk Scorer")
+	} else {
+		log.Print("[SentryFlow] Failed to stop Risk Scorer")
+	}
+
+	// Stop coverage reporter
+	if coverage.StopCoverageReporter() {
+		log.Print("[SentryFlow] Stopped Coverage Reporter")
+	} else {
+		log.Print("[SentryFlow] Failed to stop Coverage Reporter")
+	}
+
 	// Stop API Aanalyzer
 	if processor.StopAPIAnalyzer() {
 		log.Print("[SentryFlow] Stopped API Analyzer")
@@ -84,6 +168,13 @@ func (sf *SentryFlowService) DestroySentryFlow() {
 		log.Print("[SentryFlow] Failed to stop Exporters")
 	}
 
+	// Stop metrics server
+	if metrics.StopMetricsServer() {
+		log.Print("[SentryFlow] Stopped Metrics Server")
+	} else {
+		log.Print("[SentryFlow] Failed to stop Metrics Server")
+	}
+
 	log.Print("[SentryFlow] Waiting for routine terminations")
 
 	sf.waitGroup.Wait()
@@ -126,8 +217,8 @@ func SentryFlow() {
 	// Start Kubernetes informers
 	k8s.RunInformers(StopChan, sf.waitGroup)
 
-	// Patch Istio ConfigMap
-	if !k8s.PatchIstioConfigMap() {
+	// Patch Istio (mesh config or Telemetry resources)
+	if !k8s.PatchIstio() {
 		sf.DestroySentryFlow()
 		return
 	}
@@ -140,9 +231,9 @@ func SentryFlow() {
 		}
 	}
 
-	// Patch Deployments
+	// Restart workloads in patched namespaces (gradually, in the background)
 	if config.GlobalConfig.RestartingPatchedDeployments {
-		if !k8s.RestartDeployments() {
+		if !k8s.RestartWorkloads(StopChan, sf.waitGroup) {
 			sf.DestroySentryFlow()
 			return
 		}
@@ -151,7 +242,85 @@ func SentryFlow() {
 	// == //
 
 	// Start collector
-	if !collector.StartCollector() {
+	if !collector.StartCollector(sf.waitGroup) {
+		sf.DestroySentryFlow()
+		return
+	}
+
+	// Start API inventory
+	if !inventory.StartAPIInventory(sf.waitGroup) {
+		sf.DestroySentryFlow()
+		return
+	}
+
+	// Start API spec registry
+	if !openapi.StartSpecRegistry(sf.waitGroup) {
+		sf.DestroySentryFlow()
+		return
+	}
+
+	// Start data redactor
+	if !redaction.StartDataRedactor() {
+		sf.DestroySentryFlow()
+		return
+	}
+
+	// Start API filter
+	if !filter.StartAPIFilter() {
+		sf.DestroySentryFlow()
+		return
+	}
+
+	// Start egress inventory
+	if !egress.StartEgressInventory() {
+		sf.DestroySentryFlow()
+		return
+	}
+
+	// Start rule engine
+	if !rules.StartRuleEngine(sf.waitGroup) {
+		sf.DestroySentryFlow()
+		return
+	}
+
+	// Start anomaly detector
+	if !anomaly.StartAnomalyDetector(sf.waitGroup, processor.ReportAPIFinding) {
+		sf.DestroySentryFlow()
+		return
+	}
+
+	// Start security detectors
+	if !owasp.StartSecurityDetectors(sf.waitGroup) {
+		sf.DestroySentryFlow()
+		return
+	}
+
+	// Start service graph
+	if !graph.StartServiceGraph(sf.waitGroup) {
+		sf.DestroySentryFlow()
+		return
+	}
+
+	// Start chain assembler
+	if !chains.StartChainAssembler(sf.waitGroup, exporter.InsertRequestChain) {
+		sf.DestroySentryFlow()
+		return
+	}
+
+	// Start risk scorer
+	if !risk.StartRiskScorer(sf.waitGroup) {
+		sf.DestroySentryFlow()
+		return
+	}
+
+	// Start coverage reporter
+	if !coverage.StartCoverageReporter(sf.waitGroup) {
+		sf.DestroySentryFlow()
+		return
+	}
+
+	// Start pipeline (after the components of its built-in stages)
+	if !pipeline.StartPipeline() {
 		sf.DestroySentryFlow()
 		return
 	}
@@ -180,6 +349,18 @@ func SentryFlow() {
 		return
 	}
 
+	// Start metrics server
+	if !metrics.StartMetricsServer() {
+		sf.DestroySentryFlow()
+		return
+	}
+
+	// Start config controller (after the components it configures)
+	if !k8s.StartConfigController(sf.waitGroup, applyConfig) {
+		sf.DestroySentryFlow()
+		return
+	}
+
 	log.Print("[SentryFlow] Initialization is completed")
 
 	// == //
@@ -0,0 +1,102 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/5gsec/SentryFlow/anomaly"
+	"github.com/5gsec/SentryFlow/chains"
+	"github.com/5gsec/SentryFlow/collector"
+	"github.com/5gsec/SentryFlow/config"
+	"github.com/5gsec/SentryFlow/coverage"
+	"github.com/5gsec/SentryFlow/egress"
+	"github.com/5gsec/SentryFlow/exporter"
+	"github.com/5gsec/SentryFlow/filter"
+	"github.com/5gsec/SentryFlow/graph"
+	"github.com/5gsec/SentryFlow/inventory"
+	"github.com/5gsec/SentryFlow/metrics"
+	"github.com/5gsec/SentryFlow/openapi"
+	"github.com/5gsec/SentryFlow/owasp"
+	"github.com/5gsec/SentryFlow/pipeline"
+	"github.com/5gsec/SentryFlow/processor"
+	"github.com/5gsec/SentryFlow/redaction"
+	"github.com/5gsec/SentryFlow/risk"
+	"github.com/5gsec/SentryFlow/rules"
+)
+
+// destroyed tells if the components were already started and stopped (they are global and stop only once, e.g., with -count)
+var destroyed bool
+
+// TestDestroySentryFlow checks that the components started by SentryFlow (other than the ones needing Kubernetes) stop
+// The AI engine is unreachable, so the API classifier stays disconnected
+func TestDestroySentryFlow(t *testing.T) {
+	if destroyed {
+		t.Skip("the components were already stopped in this process")
+	}
+	destroyed = true
+
+	if err := config.LoadConfig(); err != nil {
+		t.Fatalf("failed to load the default configuration: %v", err)
+	}
+
+	config.GlobalConfig.CollectorAddr = "127.0.0.1"
+	config.GlobalConfig.CollectorPort = "0"
+	config.GlobalConfig.ExporterAddr = "127.0.0.1"
+	config.GlobalConfig.ExporterPort = "0"
+	config.GlobalConfig.MetricsPort = "" // disabled
+	config.GlobalConfig.AIEngineService = "127.0.0.1"
+	config.GlobalConfig.AIEngineServicePort = "1"
+	config.GlobalConfig.ZtunnelScrapePeriod = 0
+	config.GlobalConfig.APIInventoryFile = ""
+	config.GlobalConfig.ConfigResource = ""
+
+	sf := NewSentryFlow()
+
+	components := []struct {
+		name  string
+		start func() bool
+	}{
+		{"collector", func() bool { return collector.StartCollector(sf.waitGroup) }},
+		{"API inventory", func() bool { return inventory.StartAPIInventory(sf.waitGroup) }},
+		{"API spec registry", func() bool { return openapi.StartSpecRegistry(sf.waitGroup) }},
+		{"data redactor", redaction.StartDataRedactor},
+		{"API filter", filter.StartAPIFilter},
+		{"egress inventory", egress.StartEgressInventory},
+		{"rule engine", func() bool { return rules.StartRuleEngine(sf.waitGroup) }},
+		{"anomaly detector", func() bool { return anomaly.StartAnomalyDetector(sf.waitGroup, processor.ReportAPIFinding) }},
+		{"security detectors", func() bool { return owasp.StartSecurityDetectors(sf.waitGroup) }},
+		{"service graph", func() bool { return graph.StartServiceGraph(sf.waitGroup) }},
+		{"chain assembler", func() bool { return chains.StartChainAssembler(sf.waitGroup, exporter.InsertRequestChain) }},
+		{"risk scorer", func() bool { return risk.StartRiskScorer(sf.waitGroup) }},
+		{"coverage reporter", func() bool { return coverage.StartCoverageReporter(sf.waitGroup) }},
+		{"pipeline", pipeline.StartPipeline},
+		{"log processor", func() bool { return processor.StartLogProcessor(sf.waitGroup) }},
+		{"API analyzer", func() bool { return processor.StartAPIAnalyzer(sf.waitGroup) }},
+		{"API classifier", func() bool { return processor.StartAPIClassifier(sf.waitGroup) }},
+		{"exporter", func() bool { return exporter.StartExporter(sf.waitGroup) }},
+		{"metrics server", metrics.StartMetricsServer},
+	}
+
+	for _, component := range components {
+		if !component.start() {
+			t.Fatalf("failed to start the %s", component.name)
+		}
+	}
+
+	// Routines join the wait group once they run
+	time.Sleep(100 * time.Millisecond)
+
+	done := make(chan struct{})
+	go func() {
+		sf.DestroySentryFlow()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(10 * time.Second):
+		t.Fatal("SentryFlow did not stop within 10 seconds")
+	}
+}
@@ -0,0 +1,326 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package coverage
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/5gsec/SentryFlow/k8s"
+	"github.com/5gsec/SentryFlow/protobuf"
+	"github.com/5gsec/SentryFlow/types"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// == //
+
+// Statuses of workloads in coverage reports
+const (
+	StatusCovered   = "covered"   // all pods have the sidecar (or are in the ambient mesh), and access logs were seen
+	StatusSilent    = "silent"    // all pods have the sidecar, but no access log was seen since SentryFlow started
+	StatusAmbient   = "ambient"   // all pods are in the ambient mesh, but no access log was seen (e.g., without a waypoint proxy)
+	StatusPartial   = "partial"   // some pods lack the sidecar
+	StatusUncovered = "uncovered" // no pod has the sidecar
+	StatusOptedOut  = "optedOut"  // no pod has the sidecar, as all of them opted out of the injection
+)
+
+// refreshPeriod is the period for updating the sidecar coverage in Prometheus metrics
+const refreshPeriod = time.Minute
+
+var (
+	workloadPods = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "sentryflow_workload_pods",
+		Help: "Pods of each workload in scope",
+	}, []string{"namespace", "kind", "workload"})
+
+	workloadPodsWithSidecar = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "sentryflow_workload_pods_with_sidecar",
+		Help: "Pods of each workload in scope with the Istio proxy",
+	}, []string{"namespace", "kind", "workload"})
+
+	workloadLastAccessLog = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "sentryflow_workload_last_access_log_timestamp_seconds",
+		Help: "Time of the last access log of each workload in scope (0 if none was seen since SentryFlow started)",
+	}, []string{"namespace", "kind", "workload"})
+
+	namespaceInjectionEnabled = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "sentryflow_namespace_injection_enabled",
+		Help: "Whether sidecar injection is enabled (1) or not (0) in each namespace in scope",
+	}, []string{"namespace"})
+
+	coverageWorkloads = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "sentryflow_sidecar_coverage_workloads",
+		Help: "Workloads in scope by sidecar coverage status",
+	}, []string{"status"})
+)
+
+// podType is the type of API log ends that are pods
+var podType = types.K8sResourceTypeToString(types.K8sResourceTypePod)
+
+// CoverageR global reference for Coverage Reporter
+var CoverageR *CoverageReporter
+
+// init Function
+func init() {
+	CoverageR = NewCoverageReporter()
+}
+
+// CoverageReporter Structure
+type CoverageReporter struct {
+	stopChan chan struct{}
+
+	lastSeen     map[string]time.Time // the last access log of each workload (namespace/kind/name)
+	lastSeenLock sync.Mutex
+}
+
+// NewCoverageReporter Function
+func NewCoverageReporter() *CoverageReporter {
+	cr := &CoverageReporter{
+		stopChan: make(chan struct{}),
+
+		lastSeen:     make(map[string]time.Time),
+		lastSeenLock: sync.Mutex{},
+	}
+
+	return cr
+}
+
+// == //
+
+// StartCoverageReporter Function
+func StartCoverageReporter(wg *sync.WaitGroup) bool {
+	// update sidecar coverage in Prometheus metrics
+	wg.Add(1)
+	go refreshCoverage(wg)
+
+	log.Print("[CoverageReporter] Started Coverage Reporter")
+
+	return true
+}
+
+// StopCoverageReporter Function
+func StopCoverageReporter() bool {
+	close(CoverageR.stopChan)
+
+	log.Print("[CoverageReporter] Stopped Coverage Reporter")
+
+	return true
+}
+
+// refreshCoverage Function
+func refreshCoverage(wg *sync.WaitGroup) {
+	defer wg.Done()
+
+	ticker := time.NewTicker(refreshPeriod)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			updateMetrics()
+		case <-CoverageR.stopChan:
+			return
+		}
+	}
+}
+
+// == //
+
+// workloadKey Function
+func workloadKey(namespace, kind, name string) string {
+	return namespace + "/" + kind + "/" + name
+}
+
+// ObserveAPILog Function that records the pods at both ends of an API log as seen in access logs
+// The proxy reporting an access log is not carried in API logs, so either end counts as seen
+func ObserveAPILog(apiLog *protobuf.APILog) {
+	now := time.Now()
+
+	CoverageR.lastSeenLock.Lock()
+	defer CoverageR.lastSeenLock.Unlock()
+
+	if apiLog.SrcType == podType && apiLog.SrcWorkloadName != "" {
+		CoverageR.lastSeen[workloadKey(apiLog.SrcNamespace, apiLog.SrcWorkloadKind, apiLog.SrcWorkloadName)] = now
+	}
+
+	if apiLog.DstType == podType && apiLog.DstWorkloadName != "" {
+		CoverageR.lastSeen[workloadKey(apiLog.DstNamespace, apiLog.DstWorkloadKind, apiLog.DstWorkloadName)] = now
+	}
+}
+
+// workloadStatus Function
+func workloadStatus(status k8s.SidecarStatus, lastSeen time.Time) string {
+	proxied := status.WithSidecar + status.Ambient
+
+	switch {
+	case proxied == 0 && status.OptedOut == status.Pods:
+		return StatusOptedOut
+	case proxied == 0:
+		return StatusUncovered
+	case proxied < status.Pods:
+		return StatusPartial
+	case !lastSeen.IsZero():
+		return StatusCovered
+	case status.WithSidecar == 0:
+		return StatusAmbient
+	}
+	return StatusSilent
+}
+
+// buildReport Function that puts together the sidecars of workloads, the access logs seen and the injection of namespaces
+func buildReport() *protobuf.CoverageReport {
+	report := &protobuf.CoverageReport{
+		Workloads:  make([]*protobuf.WorkloadCoverage, 0),
+		Namespaces: make([]*protobuf.NamespaceCoverage, 0),
+		Notes:      make([]string, 0),
+	}
+
+	statuses := k8s.SidecarCoverage()
+
+	CoverageR.lastSeenLock.Lock()
+	for _, status := range statuses {
+		lastSeen := CoverageR.lastSeen[workloadKey(status.Namespace, status.WorkloadKind, status.WorkloadName)]
+
+		workload := &protobuf.WorkloadCoverage{
+			Namespace:       status.Namespace,
+			Kind:            status.WorkloadKind,
+			Name:            status.WorkloadName,
+			Pods:            uint32(status.Pods),
+			PodsWithSidecar: uint32(status.WithSidecar),
+			PodsOptedOut:    uint32(status.OptedOut),
+			PodsAmbient:     uint32(status.Ambient),
+			Status:          workloadStatus(status, lastSeen),
+		}
+		if !lastSeen.IsZero() {
+			workload.LastAccessLog = lastSeen.Unix()
+		}
+
+		report.Workloads = append(report.Workloads, workload)
+	}
+	CoverageR.lastSeenLock.Unlock()
+
+	namespaces := make(map[string]*protobuf.NamespaceCoverage)
+
+	injection, err := k8s.NamespaceInjection()
+	if err != nil {
+		report.Notes = append(report.Notes, fmt.Sprintf("Unable to get the injection labels of namespaces: %v", err))
+	}
+	for name, label := range injection {
+		namespaces[name] = &protobuf.NamespaceCoverage{
+			Name:             name,
+			InjectionEnabled: label != "",
+			InjectionLabel:   label,
+		}
+	}
+
+	for _, workload := range report.Workloads {
+		namespace, ok := namespaces[workload.Namespace]
+		if !ok {
+			namespace = &protobuf.NamespaceCoverage{Name: workload.Namespace}
+			namespaces[workload.Namespace] = namespace
+		}
+
+		namespace.Workloads++
+		if workload.Status == StatusCovered {
+			namespace.Covered++
+		}
+	}
+
+	for _, namespace := range namespaces {
+		report.Namespaces = append(report.Namespaces, namespace)
+	}
+
+	sort.Slice(report.Namespaces, func(i, j int) bool {
+		return report.Namespaces[i].Name < report.Namespaces[j].Name
+	})
+
+	return report
+}
+
+// GetCoverage Function that gives the sidecar coverage of workloads and namespaces in scope
+func GetCoverage(query *protobuf.CoverageQuery) *protobuf.CoverageReport {
+	report := buildReport()
+
+	if query.Namespace == "" && query.Status == "" {
+		return report
+	}
+
+	workloads := make([]*protobuf.WorkloadCoverage, 0)
+	for _, workload := range report.Workloads {
+		if query.Namespace != "" && workload.Namespace != query.Namespace {
+			continue
+		}
+		if query.Status != "" && workload.Status != query.Status {
+			continue
+		}
+		workloads = append(workloads, workload)
+	}
+	report.Workloads = workloads
+
+	if query.Namespace != "" {
+		namespaces := make([]*protobuf.NamespaceCoverage, 0)
+		for _, namespace := range report.Namespaces {
+			if namespace.Name == query.Namespace {
+				namespaces = append(namespaces, namespace)
+			}
+		}
+		report.Namespaces = namespaces
+	}
+
+	return report
+}
+
+// updateMetrics Function
+func updateMetrics() {
+	report := buildReport()
+
+	for _, note := range report.Notes {
+		log.Printf("[CoverageReporter] %s", note)
+	}
+
+	workloadPods.Reset()
+	workloadPodsWithSidecar.Reset()
+	workloadLastAccessLog.Reset()
+	namespaceInjectionEnabled.Reset()
+	coverageWorkloads.Reset()
+
+	counts := map[string]int{StatusCovered: 0, StatusSilent: 0, StatusAmbient: 0, StatusPartial: 0, StatusUncovered: 0, StatusOptedOut: 0}
+	current := make(map[string]bool)
+
+	for _, workload := range report.Workloads {
+		workloadPods.WithLabelValues(workload.Namespace, workload.Kind, workload.Name).Set(float64(workload.Pods))
+		workloadPodsWithSidecar.WithLabelValues(workload.Namespace, workload.Kind, workload.Name).Set(float64(workload.PodsWithSidecar))
+		workloadLastAccessLog.WithLabelValues(workload.Namespace, workload.Kind, workload.Name).Set(float64(workload.LastAccessLog))
+
+		counts[workload.Status]++
+		current[workloadKey(workload.Namespace, workload.Kind, workload.Name)] = true
+	}
+
+	for status, count := range counts {
+		coverageWorkloads.WithLabelValues(status).Set(float64(count))
+	}
+
+	for _, namespace := range report.Namespaces {
+		if namespace.InjectionEnabled {
+			namespaceInjectionEnabled.WithLabelValues(namespace.Name).Set(1)
+		} else {
+			namespaceInjectionEnabled.WithLabelValues(namespace.Name).Set(0)
+		}
+	}
+
+	// Forget the access logs of workloads that are gone
+	CoverageR.lastSeenLock.Lock()
+	for key := range CoverageR.lastSeen {
+		if !current[key] {
+			delete(CoverageR.lastSeen, key)
+		}
+	}
+	CoverageR.lastSeenLock.Unlock()
+}
+
+// == //
@@ -0,0 +1,270 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package egress
+
+import (
+	"fmt"
+	"log"
+	"net/netip"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/5gsec/SentryFlow/config"
+	"github.com/5gsec/SentryFlow/inventory"
+	"github.com/5gsec/SentryFlow/protobuf"
+	"github.com/5gsec/SentryFlow/types"
+)
+
+// == //
+
+const (
+	// maxDestinations is the maximum number of pairs of workloads and external hosts kept
+	maxDestinations = 10000
+
+	// maxValuesPerDestination is the maximum number of IP addresses, ports and methods kept per destination
+	maxValuesPerDestination = 32
+)
+
+// EgressInv global reference for Egress Inventory
+var EgressInv *Inventory
+
+// init Function
+func init() {
+	EgressInv = NewEgressInventory()
+}
+
+// destinationRecord Structure (calls from a workload to a host outside of the cluster)
+type destinationRecord struct {
+	namespace string
+	workload  string
+	host      string
+	network   string
+
+	ports   map[string]bool
+	ips     map[string]bool
+	methods map[string]uint64
+
+	calls         uint64
+	errors        uint64
+	requestBytes  uint64
+	responseBytes uint64
+
+	firstSeen int64
+	lastSeen  int64
+}
+
+// Inventory Structure
+type Inventory struct {
+	destinations     map[string]*destinationRecord
+	destinationsLock sync.RWMutex
+	full             bool
+
+	clusterNets  []netip.Prefix
+	privateNets  []netip.Prefix
+	networksLock sync.RWMutex
+}
+
+// NewEgressInventory Function
+func NewEgressInventory() *Inventory {
+	inv := &Inventory{
+		destinations:     make(map[string]*destinationRecord),
+		destinationsLock: sync.RWMutex{},
+
+		networksLock: sync.RWMutex{},
+	}
+
+	return inv
+}
+
+// == //
+
+// StartEgressInventory Function
+func StartEgressInventory() bool {
+	if err := LoadNetworks(config.GlobalConfig.Egress); err != nil {
+		log.Printf("[EgressInventory] Failed to load networks: %v", err)
+		return false
+	}
+
+	log.Printf("[EgressInventory] Started Egress Inventory (%d cluster networks, %d private networks)",
+		len(config.GlobalConfig.Egress.ClusterCIDRs), len(config.GlobalConfig.Egress.PrivateCIDRs))
+
+	return true
+}
+
+// == //
+
+// destinationKey Function
+func destinationKey(namespace, workload, host string) string {
+	return fmt.Sprintf("%s/%s -> %s", namespace, workload, host)
+}
+
+// addValue Function (up to the maximum number of values)
+func addValue(values map[string]bool, value string) {
+	if value != "" && len(values) < maxValuesPerDestination {
+		values[value] = true
+	}
+}
+
+// ObserveAPILog Function that records a call to a host outside of the cluster and returns an event if the pair is new
+func ObserveAPILog(apiLog *protobuf.APILog) *protobuf.APIEvent {
+	if apiLog.DstNetwork != types.NetworkPrivate && apiLog.DstNetwork != types.NetworkInternet {
+		return nil
+	}
+
+	host := apiLog.DstHost
+	if host == "" {
+		host = apiLog.DstIP
+	}
+
+	namespace := apiLog.SrcNamespace
+	workload := inventory.SrcWorkload(apiLog)
+	key := destinationKey(namespace, workload, host)
+
+	seen := types.ParseTimeStamp(apiLog.TimeStamp).Unix()
+
+	EgressInv.destinationsLock.Lock()
+	defer EgressInv.destinationsLock.Unlock()
+
+	record, ok := EgressInv.destinations[key]
+	if !ok {
+		if len(EgressInv.destinations) >= maxDestinations {
+			if !EgressInv.full {
+				log.Printf("[EgressInventory] Too many external destinations, ignoring new ones (max: %d)", maxDestinations)
+				EgressInv.full = true
+			}
+			return nil
+		}
+
+		record = &destinationRecord{
+			namespace: namespace,
+			workload:  workload,
+			host:      host,
+			ports:     make(map[string]bool),
+			ips:       make(map[string]bool),
+			methods:   make(map[string]uint64),
+			firstSeen: seen,
+		}
+		EgressInv.destinations[key] = record
+	}
+
+	// a host on the internet may also resolve to private addresses, the internet wins
+	if record.network != types.NetworkInternet {
+		record.network = apiLog.DstNetwork
+	}
+
+	addValue(record.ports, apiLog.DstPort)
+	addValue(record.ips, apiLog.DstIP)
+	if _, exist := record.methods[apiLog.Method]; exist || len(record.methods) < maxValuesPerDestination {
+		record.methods[apiLog.Method]++
+	}
+
+	record.calls++
+	if inventory.IsError(apiLog) {
+		record.errors++
+	}
+	record.requestBytes += apiLog.RequestBytes
+	record.responseBytes += apiLog.ResponseBytes
+
+	if seen < record.firstSeen {
+		record.firstSeen = seen
+	}
+	if seen > record.lastSeen {
+		record.lastSeen = seen
+	}
+
+	if ok {
+		return nil
+	}
+
+	return &protobuf.APIEvent{
+		TimeStamp:   apiLog.TimeStamp,
+		Type:        "NewEgress",
+		Description: fmt.Sprintf("Discovered calls from %s/%s to %s (%s)", namespace, workload, host, record.network),
+		Egress:      record.toDestination(key),
+	}
+}
+
+// sortedKeys Function
+func sortedKeys(values map[string]bool) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// toDestination Function
+func (rec *destinationRecord) toDestination(key string) *protobuf.EgressDestination {
+	dest := &protobuf.EgressDestination{
+		Id:        key,
+		Namespace: rec.namespace,
+		Workload:  rec.workload,
+		Host:      rec.host,
+		Network:   rec.network,
+		Ports:     sortedKeys(rec.ports),
+		IPs:       sortedKeys(rec.ips),
+		Methods:   make(map[string]uint64, len(rec.methods)),
+
+		Calls:         rec.calls,
+		Errors:        rec.errors,
+		RequestBytes:  rec.requestBytes,
+		ResponseBytes: rec.responseBytes,
+
+		FirstSeen: rec.firstSeen,
+		LastSeen:  rec.lastSeen,
+	}
+
+	for method, count := range rec.methods {
+		dest.Methods[method] = count
+	}
+
+	return dest
+}
+
+// matchQuery Function (hosts match by suffix, e.g., stripe.com matches api.stripe.com)
+func (rec *destinationRecord) matchQuery(query *protobuf.EgressQuery) bool {
+	if query.Namespace != "" && query.Namespace != rec.namespace {
+		return false
+	}
+
+	if query.Workload != "" && query.Workload != rec.workload {
+		return false
+	}
+
+	if query.Host != "" && rec.host != query.Host && !strings.HasSuffix(rec.host, "."+query.Host) {
+		return false
+	}
+
+	if query.Network != "" && query.Network != rec.network {
+		return false
+	}
+
+	return true
+}
+
+// ListEgress Function that gives the external destinations of workloads (most called first)
+func ListEgress(query *protobuf.EgressQuery) []*protobuf.EgressDestination {
+	EgressInv.destinationsLock.RLock()
+	defer EgressInv.destinationsLock.RUnlock()
+
+	destinations := make([]*protobuf.EgressDestination, 0)
+
+	for key, record := range EgressInv.destinations {
+		if record.matchQuery(query) {
+			destinations = append(destinations, record.toDestination(key))
+		}
+	}
+
+	sort.Slice(destinations, func(i, j int) bool {
+		if destinations[i].Calls != destinations[j].Calls {
+			return destinations[i].Calls > destinations[j].Calls
+		}
+		return destinations[i].Id < destinations[j].Id
+	})
+
+	return destinations
+}
+
+// == //
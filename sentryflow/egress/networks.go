@@ -0,0 +1,100 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package egress
+
+import (
+	"fmt"
+	"net/netip"
+	"strings"
+
+	"github.com/5gsec/SentryFlow/config"
+	"github.com/5gsec/SentryFlow/protobuf"
+	"github.com/5gsec/SentryFlow/types"
+)
+
+// == //
+
+// clusterDomain is the suffix of the DNS names of Services in the cluster
+const clusterDomain = ".svc.cluster.local"
+
+// parseCIDRs Function
+func parseCIDRs(cidrs []string) ([]netip.Prefix, error) {
+	prefixes := make([]netip.Prefix, 0, len(cidrs))
+
+	for _, cidr := range cidrs {
+		prefix, err := netip.ParsePrefix(strings.TrimSpace(cidr))
+		if err != nil {
+			return nil, fmt.Errorf("invalid CIDR %q: %v", cidr, err)
+		}
+		prefixes = append(prefixes, prefix.Masked())
+	}
+
+	return prefixes, nil
+}
+
+// LoadNetworks Function that replaces the networks for classifying destinations
+func LoadNetworks(egressCfg config.EgressConfig) error {
+	clusterNets, err := parseCIDRs(egressCfg.ClusterCIDRs)
+	if err != nil {
+		return fmt.Errorf("clusterCIDRs: %v", err)
+	}
+
+	privateNets, err := parseCIDRs(egressCfg.PrivateCIDRs)
+	if err != nil {
+		return fmt.Errorf("privateCIDRs: %v", err)
+	}
+
+	EgressInv.networksLock.Lock()
+	defer EgressInv.networksLock.Unlock()
+
+	EgressInv.clusterNets = clusterNets
+	EgressInv.privateNets = privateNets
+
+	return nil
+}
+
+// contains Function
+func contains(prefixes []netip.Prefix, addr netip.Addr) bool {
+	for _, prefix := range prefixes {
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// ClassifyAPILog Function that sets the network (cluster, private, internet) of the destination of an API log
+func ClassifyAPILog(apiLog *protobuf.APILog) {
+	apiLog.DstNetwork = classify(apiLog)
+}
+
+// classify Function
+func classify(apiLog *protobuf.APILog) string {
+	// destinations known to Kubernetes
+	if apiLog.DstType != types.K8sResourceTypeToString(types.K8sResourceTypeUnknown) {
+		return types.NetworkCluster
+	}
+	if strings.HasSuffix(apiLog.DstHost, clusterDomain) {
+		return types.NetworkCluster
+	}
+
+	addr, err := netip.ParseAddr(apiLog.DstIP)
+	if err != nil {
+		return "" // not known
+	}
+	addr = addr.Unmap()
+
+	EgressInv.networksLock.RLock()
+	defer EgressInv.networksLock.RUnlock()
+
+	switch {
+	case contains(EgressInv.clusterNets, addr):
+		return types.NetworkCluster
+	case contains(EgressInv.privateNets, addr):
+		return types.NetworkPrivate
+	}
+
+	return types.NetworkInternet
+}
+
+// == //
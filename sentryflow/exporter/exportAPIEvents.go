@@ -0,0 +1,69 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package exporter
+
+import (
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/5gsec/SentryFlow/protobuf"
+)
+
+// == //
+
+// apiEventStreamInform structure
+type apiEventStreamInform struct {
+	Hostname  string
+	IPAddress string
+
+	apiEventStream protobuf.SentryFlow_GetAPIEventsServer
+
+	error chan error
+}
+
+// GetAPIEvents Function (for gRPC)
+func (exs *ExpService) GetAPIEvents(info *protobuf.ClientInfo, stream protobuf.SentryFlow_GetAPIEventsServer) error {
+	log.Printf("[Exporter] Client %s (%s) connected (GetAPIEvents)", info.HostName, info.IPAddress)
+
+	currExporter := &apiEventStreamInform{
+		Hostname:       info.HostName,
+		IPAddress:      info.IPAddress,
+		apiEventStream: stream,
+	}
+
+	ExpH.exporterLock.Lock()
+	ExpH.apiEventExporters = append(ExpH.apiEventExporters, currExporter)
+	ExpH.exporterLock.Unlock()
+
+	return <-currExporter.error
+}
+
+// SendAPIEvents Function
+func (exp *ExpHandler) SendAPIEvents(apiEvent *protobuf.APIEvent) error {
+	failed := 0
+	total := len(exp.apiEventExporters)
+
+	for _, exporter := range exp.apiEventExporters {
+		if err := exporter.apiEventStream.Send(apiEvent); err != nil {
+			log.Printf("[Exporter] Failed to export an API event to %s (%s): %v", exporter.Hostname, exporter.IPAddress, err)
+			failed++
+		}
+	}
+
+	if failed != 0 {
+		msg := fmt.Sprintf("[Exporter] Failed to export API events properly (%d/%d failed)", failed, total)
+		return errors.New(msg)
+	}
+
+	return nil
+}
+
+// == //
+
+// InsertAPIEvent Function
+func InsertAPIEvent(apiEvent *protobuf.APIEvent) {
+	ExpH.exporterAPIEvents <- apiEvent
+}
+
+// == //
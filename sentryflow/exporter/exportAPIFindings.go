@@ -0,0 +1,69 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package exporter
+
+import (
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/5gsec/SentryFlow/protobuf"
+)
+
+// == //
+
+// apiFindingStreamInform structure
+type apiFindingStreamInform struct {
+	Hostname  string
+	IPAddress string
+
+	apiFindingStream protobuf.SentryFlow_GetAPIFindingsServer
+
+	error chan error
+}
+
+// GetAPIFindings Function (for gRPC)
+func (exs *ExpService) GetAPIFindings(info *protobuf.ClientInfo, stream protobuf.SentryFlow_GetAPIFindingsServer) error {
+	log.Printf("[Exporter] Client %s (%s) connected (GetAPIFindings)", info.HostName, info.IPAddress)
+
+	currExporter := &apiFindingStreamInform{
+		Hostname:         info.HostName,
+		IPAddress:        info.IPAddress,
+		apiFindingStream: stream,
+	}
+
+	ExpH.exporterLock.Lock()
+	ExpH.apiFindingExporters = append(ExpH.apiFindingExporters, currExporter)
+	ExpH.exporterLock.Unlock()
+
+	return <-currExporter.error
+}
+
+// SendAPIFindings Function
+func (exp *ExpHandler) SendAPIFindings(apiFinding *protobuf.APIFinding) error {
+	failed := 0
+	total := len(exp.apiFindingExporters)
+
+	for _, exporter := range exp.apiFindingExporters {
+		if err := exporter.apiFindingStream.Send(apiFinding); err != nil {
+			log.Printf("[Exporter] Failed to export an API finding to %s (%s): %v", exporter.Hostname, exporter.IPAddress, err)
+			failed++
+		}
+	}
+
+	if failed != 0 {
+		msg := fmt.Sprintf("[Exporter] Failed to export API findings properly (%d/%d failed)", failed, total)
+		return errors.New(msg)
+	}
+
+	return nil
+}
+
+// == //
+
+// InsertAPIFinding Function
+func InsertAPIFinding(apiFinding *protobuf.APIFinding) {
+	ExpH.exporterAPIFindings <- apiFinding
+}
+
+// == //
@@ -0,0 +1,37 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package exporter
+
+import (
+	"context"
+	"log"
+
+	"github.com/5gsec/SentryFlow/inventory"
+	"github.com/5gsec/SentryFlow/protobuf"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// == //
+
+// ListAPIs Function (for gRPC)
+func (exs *ExpService) ListAPIs(_ context.Context, query *protobuf.APIQuery) (*protobuf.APIList, error) {
+	apis := inventory.ListAPIs(query)
+
+	log.Printf("[Exporter] Listed %d APIs (ListAPIs)", len(apis))
+
+	return &protobuf.APIList{APIs: apis}, nil
+}
+
+// GetAPI Function (for gRPC)
+func (exs *ExpService) GetAPI(_ context.Context, query *protobuf.APIQuery) (*protobuf.APIEndpoint, error) {
+	api, ok := inventory.GetAPI(query)
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "API not found (id: %q, %s/%s %s %s)", query.Id, query.Namespace, query.Workload, query.Method, query.Path)
+	}
+
+	return api, nil
+}
+
+// == //
@@ -0,0 +1,69 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package exporter
+
+import (
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/5gsec/SentryFlow/protobuf"
+)
+
+// == //
+
+// alertStreamInform structure
+type alertStreamInform struct {
+	Hostname  string
+	IPAddress string
+
+	alertStream protobuf.SentryFlow_GetAlertsServer
+
+	error chan error
+}
+
+// GetAlerts Function (for gRPC)
+func (exs *ExpService) GetAlerts(info *protobuf.ClientInfo, stream protobuf.SentryFlow_GetAlertsServer) error {
+	log.Printf("[Exporter] Client %s (%s) connected (GetAlerts)", info.HostName, info.IPAddress)
+
+	currExporter := &alertStreamInform{
+		Hostname:    info.HostName,
+		IPAddress:   info.IPAddress,
+		alertStream: stream,
+	}
+
+	ExpH.exporterLock.Lock()
+	ExpH.alertExporters = append(ExpH.alertExporters, currExporter)
+	ExpH.exporterLock.Unlock()
+
+	return <-currExporter.error
+}
+
+// SendAlerts Function
+func (exp *ExpHandler) SendAlerts(alert *protobuf.Alert) error {
+	failed := 0
+	total := len(exp.alertExporters)
+
+	for _, exporter := range exp.alertExporters {
+		if err := exporter.alertStream.Send(alert); err != nil {
+			log.Printf("[Exporter] Failed to export an alert to %s (%s): %v", exporter.Hostname, exporter.IPAddress, err)
+			failed++
+		}
+	}
+
+	if failed != 0 {
+		msg := fmt.Sprintf("[Exporter] Failed to export alerts properly (%d/%d failed)", failed, total)
+		return errors.New(msg)
+	}
+
+	return nil
+}
+
+// == //
+
+// InsertAlert Function
+func InsertAlert(alert *protobuf.Alert) {
+	ExpH.exporterAlerts <- alert
+}
+
+// == //
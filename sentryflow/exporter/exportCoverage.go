@@ -0,0 +1,24 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package exporter
+
+import (
+	"context"
+	"log"
+
+	"github.com/5gsec/SentryFlow/coverage"
+	"github.com/5gsec/SentryFlow/protobuf"
+)
+
+// == //
+
+// GetSidecarCoverage Function (for gRPC)
+func (exs *ExpService) GetSidecarCoverage(_ context.Context, query *protobuf.CoverageQuery) (*protobuf.CoverageReport, error) {
+	report := coverage.GetCoverage(query)
+
+	log.Printf("[Exporter] Reported the sidecar coverage of %d workloads (GetSidecarCoverage)", len(report.Workloads))
+
+	return report, nil
+}
+
+// == //
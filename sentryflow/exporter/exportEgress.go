@@ -0,0 +1,24 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package exporter
+
+import (
+	"context"
+	"log"
+
+	"github.com/5gsec/SentryFlow/egress"
+	"github.com/5gsec/SentryFlow/protobuf"
+)
+
+// == //
+
+// ListEgress Function (for gRPC)
+func (exs *ExpService) ListEgress(_ context.Context, query *protobuf.EgressQuery) (*protobuf.EgressList, error) {
+	destinations := egress.ListEgress(query)
+
+	log.Printf("[Exporter] Listed %d external destinations (ListEgress)", len(destinations))
+
+	return &protobuf.EgressList{Destinations: destinations}, nil
+}
+
+// == //
@@ -0,0 +1,49 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package exporter
+
+import (
+	"context"
+	"log"
+
+	"github.com/5gsec/SentryFlow/openapi"
+	"github.com/5gsec/SentryFlow/protobuf"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// == //
+
+// GetOpenAPISpec Function (for gRPC)
+func (exs *ExpService) GetOpenAPISpec(_ context.Context, query *protobuf.OpenAPIQuery) (*protobuf.OpenAPISpec, error) {
+	if query.Namespace == "" || query.Workload == "" {
+		return nil, status.Error(codes.InvalidArgument, "namespace and workload are required")
+	}
+
+	format := query.Format
+	if format == "" {
+		format = openapi.FormatYAML
+	}
+
+	doc, ok := openapi.GenerateSpec(query.Namespace, query.Workload)
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "no API observed for %s/%s", query.Namespace, query.Workload)
+	}
+
+	data, err := openapi.MarshalSpec(doc, format)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	log.Printf("[Exporter] Generated an OpenAPI spec for %s/%s (GetOpenAPISpec)", query.Namespace, query.Workload)
+
+	return &protobuf.OpenAPISpec{
+		Namespace: query.Namespace,
+		Workload:  query.Workload,
+		Format:    format,
+		Document:  string(data),
+	}, nil
+}
+
+// == //
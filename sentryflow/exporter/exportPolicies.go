@@ -0,0 +1,30 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package exporter
+
+import (
+	"context"
+	"log"
+
+	"github.com/5gsec/SentryFlow/policy"
+	"github.com/5gsec/SentryFlow/protobuf"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// == //
+
+// GeneratePolicies Function (for gRPC)
+func (exs *ExpService) GeneratePolicies(_ context.Context, query *protobuf.PolicyQuery) (*protobuf.PolicySet, error) {
+	policySet, err := policy.GeneratePolicies(query)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	log.Printf("[Exporter] Generated %d policies (GeneratePolicies)", len(policySet.Policies))
+
+	return policySet, nil
+}
+
+// == //
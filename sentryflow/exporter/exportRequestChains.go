@@ -0,0 +1,69 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package exporter
+
+import (
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/5gsec/SentryFlow/protobuf"
+)
+
+// == //
+
+// requestChainStreamInform structure
+type requestChainStreamInform struct {
+	Hostname  string
+	IPAddress string
+
+	chainStream protobuf.SentryFlow_GetRequestChainsServer
+
+	error chan error
+}
+
+// GetRequestChains Function (for gRPC)
+func (exs *ExpService) GetRequestChains(info *protobuf.ClientInfo, stream protobuf.SentryFlow_GetRequestChainsServer) error {
+	log.Printf("[Exporter] Client %s (%s) connected (GetRequestChains)", info.HostName, info.IPAddress)
+
+	currExporter := &requestChainStreamInform{
+		Hostname:    info.HostName,
+		IPAddress:   info.IPAddress,
+		chainStream: stream,
+	}
+
+	ExpH.exporterLock.Lock()
+	ExpH.requestChainExporters = append(ExpH.requestChainExporters, currExporter)
+	ExpH.exporterLock.Unlock()
+
+	return <-currExporter.error
+}
+
+// SendRequestChains Function
+func (exp *ExpHandler) SendRequestChains(chain *protobuf.RequestChain) error {
+	failed := 0
+	total := len(exp.requestChainExporters)
+
+	for _, exporter := range exp.requestChainExporters {
+		if err := exporter.chainStream.Send(chain); err != nil {
+			log.Printf("[Exporter] Failed to export a request chain to %s (%s): %v", exporter.Hostname, exporter.IPAddress, err)
+			failed++
+		}
+	}
+
+	if failed != 0 {
+		msg := fmt.Sprintf("[Exporter] Failed to export request chains properly (%d/%d failed)", failed, total)
+		return errors.New(msg)
+	}
+
+	return nil
+}
+
+// == //
+
+// InsertRequestChain Function
+func InsertRequestChain(chain *protobuf.RequestChain) {
+	ExpH.exporterRequestChains <- chain
+}
+
+// == //
@@ -0,0 +1,27 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package exporter
+
+import (
+	"context"
+
+	"github.com/5gsec/SentryFlow/protobuf"
+	"github.com/5gsec/SentryFlow/risk"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// == //
+
+// GetRiskScores Function (for gRPC)
+func (exs *ExpService) GetRiskScores(_ context.Context, query *protobuf.RiskQuery) (*protobuf.RiskScores, error) {
+	scores, err := risk.GetRiskScores(query)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	return scores, nil
+}
+
+// == //
@@ -0,0 +1,34 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package exporter
+
+import (
+	"context"
+
+	"github.com/5gsec/SentryFlow/graph"
+	"github.com/5gsec/SentryFlow/protobuf"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// == //
+
+// GetServiceGraph Function (for gRPC)
+func (exs *ExpService) GetServiceGraph(_ context.Context, query *protobuf.ServiceGraphQuery) (*protobuf.ServiceGraph, error) {
+	sg := graph.GetServiceGraph(query.Namespace)
+
+	if query.Format != "" {
+		data, err := graph.MarshalGraph(sg, query.Format)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+
+		sg.Format = query.Format
+		sg.Document = string(data)
+	}
+
+	return sg, nil
+}
+
+// == //
@@ -0,0 +1,302 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package exporter
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/5gsec/SentryFlow/config"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// == //
+
+// Kinds of events sent to sinks
+const (
+	SinkEventAlerts    = "alerts"
+	SinkEventFindings  = "findings"
+	SinkEventAPIEvents = "apiEvents"
+	SinkEventChains    = "requestChains"
+)
+
+// webhookQueueSize is the number of events buffered per webhook
+const webhookQueueSize = 1024
+
+// webhookTimeout is the timeout of a webhook request
+const webhookTimeout = 5 * time.Second
+
+// sinkRecord Structure
+type sinkRecord struct {
+	Kind string          `json:"kind"`
+	Data json.RawMessage `json:"data"`
+}
+
+// sink Interface
+type sink interface {
+	name() string
+	send(record []byte) error
+	close()
+}
+
+// sinkInform Structure
+type sinkInform struct {
+	sink   sink
+	events map[string]bool
+}
+
+// == //
+
+// fileSink Structure
+type fileSink struct {
+	path string
+	file *os.File
+	lock sync.Mutex
+}
+
+// newFileSink Function
+func newFileSink(path string) (*fileSink, error) {
+	if path == "" {
+		return nil, fmt.Errorf("path is required")
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0750); err != nil {
+		return nil, err
+	}
+
+	file, err := os.OpenFile(filepath.Clean(path), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0640)
+	if err != nil {
+		return nil, err
+	}
+
+	return &fileSink{path: path, file: file}, nil
+}
+
+func (fs *fileSink) name() string { return "file:" + fs.path }
+
+// send Function that appends a record as a JSON line
+func (fs *fileSink) send(record []byte) error {
+	fs.lock.Lock()
+	defer fs.lock.Unlock()
+
+	_, err := fs.file.Write(append(record, '\n'))
+	return err
+}
+
+func (fs *fileSink) close() {
+	fs.lock.Lock()
+	defer fs.lock.Unlock()
+
+	_ = fs.file.Close()
+}
+
+// == //
+
+// webhookSink Structure
+type webhookSink struct {
+	url     string
+	headers map[string]string
+	client  *http.Client
+
+	queue chan []byte
+	done  chan struct{}
+}
+
+// newWebhookSink Function
+func newWebhookSink(url string, headers map[string]string) (*webhookSink, error) {
+	if url == "" {
+		return nil, fmt.Errorf("url is required")
+	}
+
+	ws := &webhookSink{
+		url:     url,
+		headers: headers,
+		client:  &http.Client{Timeout: webhookTimeout},
+
+		queue: make(chan []byte, webhookQueueSize),
+		done:  make(chan struct{}),
+	}
+
+	// post records in the background so that slow webhooks do not block exporting
+	go ws.postRecords()
+
+	return ws, nil
+}
+
+func (ws *webhookSink) name() string { return "webhook:" + ws.url }
+
+// send Function that queues a record for the webhook
+func (ws *webhookSink) send(record []byte) error {
+	select {
+	case ws.queue <- record:
+		return nil
+	default:
+		return fmt.Errorf("queue is full, dropping a record")
+	}
+}
+
+// postRecords Function
+func (ws *webhookSink) postRecords() {
+	for record := range ws.queue {
+		req, err := http.NewRequest(http.MethodPost, ws.url, bytes.NewReader(record))
+		if err != nil {
+			log.Printf("[Exporter] Failed to create a request for %s: %v", ws.name(), err)
+			continue
+		}
+
+		req.Header.Set("Content-Type", "application/json")
+		for key, value := range ws.headers {
+			req.Header.Set(key, value)
+		}
+
+		resp, err := ws.client.Do(req)
+		if err != nil {
+			log.Printf("[Exporter] Failed to send a record to %s: %v", ws.name(), err)
+			continue
+		}
+		_ = resp.Body.Close()
+
+		if resp.StatusCode >= 300 {
+			log.Printf("[Exporter] Failed to send a record to %s: %s", ws.name(), resp.Status)
+		}
+	}
+
+	close(ws.done)
+}
+
+func (ws *webhookSink) close() {
+	close(ws.queue)
+	<-ws.done
+}
+
+// == //
+
+// newSink Function
+func newSink(sinkCfg config.SinkConfig) (sink, error) {
+	switch sinkCfg.Type {
+	case "file":
+		return newFileSink(sinkCfg.Path)
+	case "webhook":
+		return newWebhookSink(sinkCfg.URL, sinkCfg.Headers)
+	}
+
+	return nil, fmt.Errorf("unsupported sink type %q (file|webhook)", sinkCfg.Type)
+}
+
+// newSinkInform Function
+func newSinkInform(sinkCfg config.SinkConfig) (*sinkInform, error) {
+	s, err := newSink(sinkCfg)
+	if err != nil {
+		return nil, err
+	}
+
+	events := make(map[string]bool)
+	for _, event := range sinkCfg.Events {
+		events[event] = true
+	}
+
+	return &sinkInform{sink: s, events: events}, nil
+}
+
+// startSinks Function
+func (exp *ExpHandler) startSinks() {
+	exp.sinksLock.Lock()
+	defer exp.sinksLock.Unlock()
+
+	for _, sinkCfg := range config.GlobalConfig.Sinks {
+		si, err := newSinkInform(sinkCfg)
+		if err != nil {
+			log.Printf("[Exporter] Failed to create a %s sink: %v", sinkCfg.Type, err)
+			continue
+		}
+
+		exp.sinks = append(exp.sinks, si)
+
+		log.Printf("[Exporter] Exporting events to %s", si.sink.name())
+	}
+}
+
+// LoadSinks Function that replaces the sinks with the given ones (none are replaced if one fails)
+func LoadSinks(sinkCfgs []config.SinkConfig) error {
+	sinks := make([]*sinkInform, 0, len(sinkCfgs))
+
+	for _, sinkCfg := range sinkCfgs {
+		si, err := newSinkInform(sinkCfg)
+		if err != nil {
+			for _, created := range sinks {
+				created.sink.close()
+			}
+			return fmt.Errorf("%s sink: %v", sinkCfg.Type, err)
+		}
+		sinks = append(sinks, si)
+	}
+
+	ExpH.sinksLock.Lock()
+	oldSinks := ExpH.sinks
+	ExpH.sinks = sinks
+	ExpH.sinksLock.Unlock()
+
+	for _, si := range oldSinks {
+		si.sink.close()
+	}
+
+	for _, si := range sinks {
+		log.Printf("[Exporter] Exporting events to %s", si.sink.name())
+	}
+
+	return nil
+}
+
+// stopSinks Function
+func (exp *ExpHandler) stopSinks() {
+	exp.sinksLock.Lock()
+	defer exp.sinksLock.Unlock()
+
+	for _, si := range exp.sinks {
+		si.sink.close()
+	}
+	exp.sinks = nil
+}
+
+// sendToSinks Function that sends an event to the sinks subscribed to its kind
+func (exp *ExpHandler) sendToSinks(kind string, msg proto.Message) {
+	exp.sinksLock.RLock()
+	defer exp.sinksLock.RUnlock()
+
+	if len(exp.sinks) == 0 {
+		return
+	}
+
+	data, err := protojson.Marshal(msg)
+	if err != nil {
+		log.Printf("[Exporter] Failed to marshal an event for sinks: %v", err)
+		return
+	}
+
+	record, err := json.Marshal(sinkRecord{Kind: kind, Data: data})
+	if err != nil {
+		log.Printf("[Exporter] Failed to marshal an event for sinks: %v", err)
+		return
+	}
+
+	for _, si := range exp.sinks {
+		if len(si.events) > 0 && !si.events[kind] {
+			continue
+		}
+
+		if err := si.sink.send(record); err != nil {
+			log.Printf("[Exporter] Failed to export an event to %s: %v", si.sink.name(), err)
+		}
+	}
+}
+
+// == //
@@ -34,12 +34,23 @@ type ExpHandler struct {
 	apiLogExporters       []*apiLogStreamInform
 	apiMetricsExporters   []*apiMetricStreamInform
 	envoyMetricsExporters []*envoyMetricsStreamInform
+	apiEventExporters     []*apiEventStreamInform
+	apiFindingExporters   []*apiFindingStreamInform
+	alertExporters        []*alertStreamInform
+	requestChainExporters []*requestChainStreamInform
+
+	sinks     []*sinkInform
+	sinksLock sync.RWMutex
 
 	exporterLock sync.Mutex
 
-	exporterAPILogs    chan *protobuf.APILog
-	exporterAPIMetrics chan *protobuf.APIMetrics
-	exporterMetrics    chan *protobuf.EnvoyMetrics
+	exporterAPILogs       chan *protobuf.APILog
+	exporterAPIMetrics    chan *protobuf.APIMetrics
+	exporterMetrics       chan *protobuf.EnvoyMetrics
+	exporterAPIEvents     chan *protobuf.APIEvent
+	exporterAPIFindings   chan *protobuf.APIFinding
+	exporterAlerts        chan *protobuf.Alert
+	exporterRequestChains chan *protobuf.RequestChain
 
 	statsPerLabel     map[string]StatsPerLabel
 	statsPerLabelLock sync.RWMutex
@@ -62,12 +73,22 @@ func NewExporterHandler() *ExpHandler {
 		apiLogExporters:       make([]*apiLogStreamInform, 0),
 		apiMetricsExporters:   make([]*apiMetricStreamInform, 0),
 		envoyMetricsExporters: make([]*envoyMetricsStreamInform, 0),
+		apiEventExporters:     make([]*apiEventStreamInform, 0),
+		apiFindingExporters:   make([]*apiFindingStreamInform, 0),
+		alertExporters:        make([]*alertStreamInform, 0),
+		requestChainExporters: make([]*requestChainStreamInform, 0),
+
+		sinks: make([]*sinkInform, 0),
 
 		exporterLock: sync.Mutex{},
 
-		exporterAPILogs:    make(chan *protobuf.APILog),
-		exporterAPIMetrics: make(chan *protobuf.APIMetrics),
-		exporterMetrics:    make(chan *protobuf.EnvoyMetrics),
+		exporterAPILogs:       make(chan *protobuf.APILog),
+		exporterAPIMetrics:    make(chan *protobuf.APIMetrics),
+		exporterMetrics:       make(chan *protobuf.EnvoyMetrics),
+		exporterAPIEvents:     make(chan *protobuf.APIEvent),
+		exporterAPIFindings:   make(chan *protobuf.APIFinding),
+		exporterAlerts:        make(chan *protobuf.Alert),
+		exporterRequestChains: make(chan *protobuf.RequestChain),
 
 		statsPerLabel:     make(map[string]StatsPerLabel),
 		statsPerLabelLock: sync.RWMutex{},
@@ -123,6 +144,29 @@ func StartExporter(wg *sync.WaitGroup) bool {
 
 	log.Printf("[Exporter] Exporting Envoy metrics through gRPC services")
 
+	// Export APIEvents
+	go ExpH.exportAPIEvents(wg)
+
+	log.Printf("[Exporter] Exporting API events through gRPC services")
+
+	// Export APIFindings
+	go ExpH.exportAPIFindings(wg)
+
+	log.Printf("[Exporter] Exporting API findings through gRPC services")
+
+	// Export Alerts
+	go ExpH.exportAlerts(wg)
+
+	log.Printf("[Exporter] Exporting alerts through gRPC services")
+
+	// Export RequestChains
+	go ExpH.exportRequestChains(wg)
+
+	log.Printf("[Exporter] Exporting request chains through gRPC services")
+
+	// Start sinks (e.g., files and webhooks)
+	ExpH.startSinks()
+
 	// Start Export Time Ticker Routine
 	go AggregateAPIMetrics()
 	go CleanUpOutdatedStats()
@@ -132,17 +176,16 @@ func StartExporter(wg *sync.WaitGroup) bool {
 
 // StopExporter Function
 func StopExporter() bool {
-	// One for exportAPILogs
-	ExpH.stopChan <- struct{}{}
-
-	// One for exportAPIMetrics
-	ExpH.stopChan <- struct{}{}
+	// For exportAPILogs, exportAPIMetrics, exportEnvoyMetrics, exportAPIEvents, exportAPIFindings, exportAlerts and exportRequestChains
+	close(ExpH.stopChan)
 
-	// One for exportEnvoyMetrics
-	ExpH.stopChan <- struct{}{}
+	// Stop sinks
+	ExpH.stopSinks()
 
 	// Stop gRPC server
-	ExpH.grpcServer.GracefulStop()
+	if ExpH.grpcServer != nil {
+		ExpH.grpcServer.GracefulStop()
+	}
 
 	log.Printf("[Exporter] Gracefully stopped Exporter gRPC services")
 
@@ -222,4 +265,112 @@ func (exp *ExpHandler) exportEnvoyMetrics(wg *sync.WaitGroup) {
 	}
 }
 
+// exportAPIEvents Function
+func (exp *ExpHandler) exportAPIEvents(wg *sync.WaitGroup) {
+	wg.Add(1)
+
+	for {
+		select {
+		case apiEvent, ok := <-exp.exporterAPIEvents:
+			if !ok {
+				log.Printf("[Exporter] Failed to fetch events from API Events channel")
+				wg.Done()
+				return
+			}
+
+			if err := exp.SendAPIEvents(apiEvent); err != nil {
+				log.Printf("[Exporter] Failed to export API events: %v", err)
+			}
+
+			exp.sendToSinks(SinkEventAPIEvents, apiEvent)
+
+		case <-exp.stopChan:
+			wg.Done()
+			return
+		}
+	}
+}
+
+// exportAPIFindings Function
+func (exp *ExpHandler) exportAPIFindings(wg *sync.WaitGroup) {
+	wg.Add(1)
+
+	for {
+		select {
+		case apiFinding, ok := <-exp.exporterAPIFindings:
+			if !ok {
+				log.Printf("[Exporter] Failed to fetch findings from API Findings channel")
+				wg.Done()
+				return
+			}
+
+			if err := exp.SendAPIFindings(apiFinding); err != nil {
+				log.Printf("[Exporter] Failed to export API findings: %v", err)
+			}
+
+			exp.sendToSinks(SinkEventFindings, apiFinding)
+
+		case <-exp.stopChan:
+			wg.Done()
+			return
+		}
+	}
+}
+
+// == //
+
+// exportAlerts Function
+func (exp *ExpHandler) exportAlerts(wg *sync.WaitGroup) {
+	wg.Add(1)
+
+	for {
+		select {
+		case alert, ok := <-exp.exporterAlerts:
+			if !ok {
+				log.Printf("[Exporter] Failed to fetch alerts from Alerts channel")
+				wg.Done()
+				return
+			}
+
+			if err := exp.SendAlerts(alert); err != nil {
+				log.Printf("[Exporter] Failed to export alerts: %v", err)
+			}
+
+			exp.sendToSinks(SinkEventAlerts, alert)
+
+		case <-exp.stopChan:
+			wg.Done()
+			return
+		}
+	}
+}
+
+// == //
+
+// exportRequestChains Function
+func (exp *ExpHandler) exportRequestChains(wg *sync.WaitGroup) {
+	wg.Add(1)
+
+	for {
+		select {
+		case chain, ok := <-exp.exporterRequestChains:
+			if !ok {
+				log.Printf("[Exporter] Failed to fetch request chains from RequestChains channel")
+				wg.Done()
+				return
+			}
+
+			if err := exp.SendRequestChains(chain); err != nil {
+				log.Printf("[Exporter] Failed to export request chains: %v", err)
+			}
+
+			exp.sendToSinks(SinkEventChains, chain)
+
+		case <-exp.stopChan:
+			wg.Done()
+			return
+		}
+	}
+}
+
 // == //
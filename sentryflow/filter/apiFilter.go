@@ -0,0 +1,258 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package filter
+
+import (
+	"fmt"
+	"hash/fnv"
+	"log"
+	"math"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/5gsec/SentryFlow/config"
+	"github.com/5gsec/SentryFlow/inventory"
+	"github.com/5gsec/SentryFlow/protobuf"
+	"github.com/5gsec/SentryFlow/rules"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// == //
+
+// Filter actions
+const (
+	ActionKeep   = "keep"
+	ActionDrop   = "drop"
+	ActionSample = "sample"
+)
+
+// Names of the built-in decisions in metrics
+const (
+	ruleAlwaysKeepErrors = "alwaysKeepErrors"
+	ruleHeadSampling     = "headSampling"
+)
+
+// maxEndpoints is the maximum number of endpoints tracked for rate-limited sampling per rule
+const maxEndpoints = 10000
+
+// filterRecords counts API logs kept and dropped by each rule
+var filterRecords = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "sentryflow_filter_records_total",
+	Help: "Number of API logs kept or dropped by each filter rule",
+}, []string{"rule", "result"})
+
+// APIFil global reference for API Filter
+var APIFil *APIFilter
+
+// init Function
+func init() {
+	APIFil = NewAPIFilter()
+}
+
+// tokenBucket Structure
+type tokenBucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// allow Function that takes a token if any (refilled at the given rate per second)
+func (tb *tokenBucket) allow(rate float64, now time.Time) bool {
+	burst := math.Max(rate, 1)
+
+	tb.tokens = math.Min(burst, tb.tokens+now.Sub(tb.last).Seconds()*rate)
+	tb.last = now
+
+	if tb.tokens < 1 {
+		return false
+	}
+
+	tb.tokens--
+	return true
+}
+
+// filterRule Structure
+type filterRule struct {
+	config.FilterRuleConfig
+
+	condition *rules.Expression
+	buckets   map[string]*tokenBucket
+}
+
+// APIFilter Structure
+type APIFilter struct {
+	alwaysKeepErrors bool
+	headSampling     float64
+
+	rules     []*filterRule
+	rulesLock sync.Mutex
+}
+
+// NewAPIFilter Function
+func NewAPIFilter() *APIFilter {
+	af := &APIFilter{
+		headSampling: 1.0,
+
+		rules:     make([]*filterRule, 0),
+		rulesLock: sync.Mutex{},
+	}
+
+	return af
+}
+
+// == //
+
+// StartAPIFilter Function
+func StartAPIFilter() bool {
+	if err := LoadFilter(config.GlobalConfig.Filter); err != nil {
+		log.Printf("[APIFilter] Failed to load filter rules: %v", err)
+		return false
+	}
+
+	log.Printf("[APIFilter] Started API Filter (%d rules)", len(config.GlobalConfig.Filter.Rules))
+
+	return true
+}
+
+// LoadFilter Function that replaces the filter rules with the given config
+func LoadFilter(filterCfg config.FilterConfig) error {
+	if filterCfg.HeadSampling < 0 || filterCfg.HeadSampling > 1 {
+		return fmt.Errorf("headSampling should be between 0 and 1")
+	}
+
+	filterRules := make([]*filterRule, 0, len(filterCfg.Rules))
+
+	for idx, ruleCfg := range filterCfg.Rules {
+		if ruleCfg.Name == "" {
+			ruleCfg.Name = fmt.Sprintf("rule-%d", idx)
+		}
+
+		condition, err := rules.Compile(ruleCfg.Condition)
+		if err != nil {
+			return fmt.Errorf("rule %q: invalid condition: %v", ruleCfg.Name, err)
+		}
+
+		switch ruleCfg.Action {
+		case ActionKeep, ActionDrop:
+		case ActionSample:
+			if ruleCfg.Ratio <= 0 && ruleCfg.PerEndpoint <= 0 {
+				return fmt.Errorf("rule %q: ratio or perEndpoint is required to sample", ruleCfg.Name)
+			}
+			if ruleCfg.Ratio > 1 {
+				return fmt.Errorf("rule %q: ratio should be between 0 and 1", ruleCfg.Name)
+			}
+		default:
+			return fmt.Errorf("rule %q: unsupported action %q (keep|drop|sample)", ruleCfg.Name, ruleCfg.Action)
+		}
+
+		filterRules = append(filterRules, &filterRule{
+			FilterRuleConfig: ruleCfg,
+
+			condition: condition,
+			buckets:   make(map[string]*tokenBucket),
+		})
+	}
+
+	APIFil.rulesLock.Lock()
+	defer APIFil.rulesLock.Unlock()
+
+	APIFil.alwaysKeepErrors = filterCfg.AlwaysKeepErrors
+	APIFil.headSampling = filterCfg.HeadSampling
+	APIFil.rules = filterRules
+
+	return nil
+}
+
+// == //
+
+// samplingValue Function that gives a value in [0, 1) to sample an API log
+// The request ID is hashed so that every hop of the same request gets the same decision
+func samplingValue(apiLog *protobuf.APILog) float64 {
+	if requestID := apiLog.RequestHeaders["x-request-id"]; requestID != "" {
+		h := fnv.New64a()
+		_, _ = h.Write([]byte(requestID))
+
+		// mix the bits since FNV spreads similar short IDs poorly (the finalizer of MurmurHash3)
+		x := h.Sum64()
+		x ^= x >> 33
+		x *= 0xff51afd7ed558ccd
+		x ^= x >> 33
+		x *= 0xc4ceb9fe1a85ec53
+		x ^= x >> 33
+
+		return float64(x>>11) / float64(uint64(1)<<53)
+	}
+
+	// #nosec G404 -- sampling does not need a cryptographically secure random number
+	return rand.Float64()
+}
+
+// sample Function
+func (rule *filterRule) sample(apiLog *protobuf.APILog, now time.Time) bool {
+	if rule.Ratio > 0 && samplingValue(apiLog) >= rule.Ratio {
+		return false
+	}
+
+	if rule.PerEndpoint > 0 {
+		endpoint, _ := inventory.EndpointTemplate(apiLog)
+		key := fmt.Sprintf("%s/%s %s %s", apiLog.DstNamespace, inventory.DstWorkload(apiLog), apiLog.Method, endpoint)
+
+		tb, ok := rule.buckets[key]
+		if !ok {
+			if len(rule.buckets) >= maxEndpoints {
+				rule.buckets = make(map[string]*tokenBucket)
+			}
+			tb = &tokenBucket{tokens: math.Max(rule.PerEndpoint, 1), last: now}
+			rule.buckets[key] = tb
+		}
+
+		return tb.allow(rule.PerEndpoint, now)
+	}
+
+	return true
+}
+
+// decide Function that gives the rule deciding on an API log and whether to keep it
+func (af *APIFilter) decide(apiLog *protobuf.APILog) (string, bool) {
+	if af.alwaysKeepErrors && inventory.IsError(apiLog) {
+		return ruleAlwaysKeepErrors, true
+	}
+
+	now := time.Now()
+
+	for _, rule := range af.rules {
+		if !rule.condition.Match(apiLog) {
+			continue
+		}
+
+		switch rule.Action {
+		case ActionKeep:
+			return rule.Name, true
+		case ActionDrop:
+			return rule.Name, false
+		case ActionSample:
+			return rule.Name, rule.sample(apiLog, now)
+		}
+	}
+
+	return ruleHeadSampling, af.headSampling >= 1 || samplingValue(apiLog) < af.headSampling
+}
+
+// FilterAPILog Function that decides whether to export an API log
+func FilterAPILog(apiLog *protobuf.APILog) bool {
+	APIFil.rulesLock.Lock()
+	rule, keep := APIFil.decide(apiLog)
+	APIFil.rulesLock.Unlock()
+
+	if keep {
+		filterRecords.WithLabelValues(rule, "kept").Inc()
+	} else {
+		filterRecords.WithLabelValues(rule, "dropped").Inc()
+	}
+
+	return keep
+}
+
+// == //
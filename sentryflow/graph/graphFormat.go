@@ -0,0 +1,163 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package graph
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/5gsec/SentryFlow/protobuf"
+)
+
+// == //
+
+// Export formats
+const (
+	FormatJSON = "json"
+	FormatDOT  = "dot"
+)
+
+// MarshalGraph Function that exports a service graph in the given format
+func MarshalGraph(sg *protobuf.ServiceGraph, format string) ([]byte, error) {
+	switch format {
+	case FormatJSON:
+		return marshalJSONGraph(sg)
+	case FormatDOT:
+		return marshalDOT(sg), nil
+	default:
+		return nil, fmt.Errorf("unsupported format %q (json|dot)", format)
+	}
+}
+
+// == //
+
+// jsonGraph Structure (JSON Graph Format, https://jsongraphformat.info)
+type jsonGraph struct {
+	Graph jsonGraphBody `json:"graph"`
+}
+
+// jsonGraphBody Structure
+type jsonGraphBody struct {
+	Directed bool                     `json:"directed"`
+	Metadata map[string]interface{}   `json:"metadata"`
+	Nodes    map[string]jsonGraphNode `json:"nodes"`
+	Edges    []jsonGraphEdge          `json:"edges"`
+}
+
+// jsonGraphNode Structure
+type jsonGraphNode struct {
+	Label    string            `json:"label"`
+	Metadata map[string]string `json:"metadata"`
+}
+
+// jsonGraphEdge Structure
+type jsonGraphEdge struct {
+	Source   string                 `json:"source"`
+	Target   string                 `json:"target"`
+	Relation string                 `json:"relation"`
+	Metadata map[string]interface{} `json:"metadata"`
+}
+
+// edgeMetadata Function
+func edgeMetadata(requests, errorRate, latency float64, total uint64, lastSeen int64) map[string]interface{} {
+	return map[string]interface{}{
+		"requests":      requests,
+		"errorRate":     errorRate,
+		"latency":       latency,
+		"totalRequests": total,
+		"lastSeen":      lastSeen,
+	}
+}
+
+// marshalJSONGraph Function
+func marshalJSONGraph(sg *protobuf.ServiceGraph) ([]byte, error) {
+	jg := jsonGraph{Graph: jsonGraphBody{
+		Directed: true,
+		Metadata: map[string]interface{}{"timeStamp": sg.TimeStamp, "halfLife": sg.HalfLife},
+		Nodes:    make(map[string]jsonGraphNode, len(sg.Nodes)),
+		Edges:    make([]jsonGraphEdge, 0, len(sg.Edges)),
+	}}
+
+	for _, node := range sg.Nodes {
+		jg.Graph.Nodes[node.Id] = jsonGraphNode{
+			Label:    node.Name,
+			Metadata: map[string]string{"namespace": node.Namespace, "type": node.Type},
+		}
+	}
+
+	for _, edge := range sg.Edges {
+		metadata := edgeMetadata(edge.Requests, edge.ErrorRate, edge.Latency, edge.TotalRequests, edge.LastSeen)
+
+		endpoints := make([]map[string]interface{}, 0, len(edge.Endpoints))
+		for _, ep := range edge.Endpoints {
+			epMetadata := edgeMetadata(ep.Requests, ep.ErrorRate, ep.Latency, ep.TotalRequests, ep.LastSeen)
+			epMetadata["method"] = ep.Method
+			epMetadata["path"] = ep.Path
+			endpoints = append(endpoints, epMetadata)
+		}
+		metadata["endpoints"] = endpoints
+
+		jg.Graph.Edges = append(jg.Graph.Edges, jsonGraphEdge{
+			Source:   edge.Source,
+			Target:   edge.Target,
+			Relation: "calls",
+			Metadata: metadata,
+		})
+	}
+
+	return json.MarshalIndent(jg, "", "  ")
+}
+
+// == //
+
+// dotEscape Function
+func dotEscape(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s)
+}
+
+// dotQuote Function
+func dotQuote(s string) string {
+	return `"` + dotEscape(s) + `"`
+}
+
+// marshalDOT Function (Graphviz)
+func marshalDOT(sg *protobuf.ServiceGraph) []byte {
+	var b strings.Builder
+
+	b.WriteString("digraph sentryflow {\n")
+	b.WriteString("  rankdir=LR;\n")
+	b.WriteString("  node [shape=box];\n")
+
+	for _, node := range sg.Nodes {
+		// a line break between the namespace and the name
+		label := dotEscape(node.Name)
+		if node.Namespace != "" {
+			label = dotEscape(node.Namespace) + `\n` + label
+		}
+
+		attrs := fmt.Sprintf(`label="%s"`, label)
+		if node.Type == "External" {
+			attrs += ", style=dashed"
+		}
+
+		fmt.Fprintf(&b, "  %s [%s];\n", dotQuote(node.Id), attrs)
+	}
+
+	for _, edge := range sg.Edges {
+		label := fmt.Sprintf("%.1f req, %.1f%% err, %.0f ms", edge.Requests, edge.ErrorRate*100, edge.Latency)
+
+		attrs := fmt.Sprintf("label=%s", dotQuote(label))
+		if edge.ErrorRate >= 0.05 {
+			attrs += ", color=red"
+		}
+
+		fmt.Fprintf(&b, "  %s -> %s [%s];\n", dotQuote(edge.Source), dotQuote(edge.Target), attrs)
+	}
+
+	b.WriteString("}\n")
+
+	return []byte(b.String())
+}
+
+// == //
@@ -0,0 +1,355 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package graph
+
+import (
+	"log"
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/5gsec/SentryFlow/config"
+	"github.com/5gsec/SentryFlow/inventory"
+	"github.com/5gsec/SentryFlow/protobuf"
+	"github.com/5gsec/SentryFlow/types"
+)
+
+// == //
+
+// minRequests is the decayed request count below which an edge is forgotten
+const minRequests = 0.01
+
+// maxEndpointsPerEdge is the maximum number of endpoints tracked per edge
+const maxEndpointsPerEdge = 256
+
+// SvcGraph global reference for Service Graph
+var SvcGraph *ServiceGraph
+
+// init Function
+func init() {
+	SvcGraph = NewServiceGraph()
+}
+
+// decayedStats Structure (request counts decaying exponentially over time)
+type decayedStats struct {
+	requests   float64
+	errors     float64
+	latencySum float64
+
+	total    uint64
+	lastSeen time.Time
+	updated  time.Time
+}
+
+// decay Function that brings the counts up to the given time
+func (ds *decayedStats) decay(now time.Time, halfLife time.Duration) {
+	if !ds.updated.IsZero() && now.After(ds.updated) && halfLife > 0 {
+		factor := math.Exp2(-now.Sub(ds.updated).Seconds() / halfLife.Seconds())
+		ds.requests *= factor
+		ds.errors *= factor
+		ds.latencySum *= factor
+	}
+	ds.updated = now
+}
+
+// observe Function
+func (ds *decayedStats) observe(apiLog *protobuf.APILog, now time.Time, halfLife time.Duration) {
+	ds.decay(now, halfLife)
+
+	ds.requests++
+	if inventory.IsServerError(apiLog) {
+		ds.errors++
+	}
+	ds.latencySum += float64(apiLog.Latency)
+
+	ds.total++
+	ds.lastSeen = now
+}
+
+// rates Function that gives the decayed request count, error rate and average latency
+func (ds *decayedStats) rates() (float64, float64, float64) {
+	if ds.requests <= 0 {
+		return 0, 0, 0
+	}
+	return ds.requests, ds.errors / ds.requests, ds.latencySum / ds.requests
+}
+
+// serviceNode Structure
+type serviceNode struct {
+	namespace string
+	name      string
+	nodeType  string
+}
+
+// serviceEdge Structure
+type serviceEdge struct {
+	source string
+	target string
+
+	stats     decayedStats
+	endpoints map[string]*endpointStats
+}
+
+// endpointStats Structure
+type endpointStats struct {
+	method string
+	path   string
+
+	stats decayedStats
+}
+
+// ServiceGraph Structure
+type ServiceGraph struct {
+	stopChan chan struct{}
+
+	nodes     map[string]*serviceNode
+	edges     map[string]*serviceEdge
+	graphLock sync.Mutex
+}
+
+// NewServiceGraph Function
+func NewServiceGraph() *ServiceGraph {
+	sg := &ServiceGraph{
+		stopChan: make(chan struct{}),
+
+		nodes:     make(map[string]*serviceNode),
+		edges:     make(map[string]*serviceEdge),
+		graphLock: sync.Mutex{},
+	}
+
+	return sg
+}
+
+// == //
+
+// StartServiceGraph Function
+func StartServiceGraph(wg *sync.WaitGroup) bool {
+	// forget edges without recent calls
+	go cleanUpGraph(wg)
+
+	log.Printf("[ServiceGraph] Started Service Graph (half-life: %ds)", config.GlobalConfig.ServiceGraphHalfLife)
+
+	return true
+}
+
+// StopServiceGraph Function
+func StopServiceGraph() bool {
+	close(SvcGraph.stopChan)
+
+	log.Print("[ServiceGraph] Stopped Service Graph")
+
+	return true
+}
+
+// cleanUpGraph Function
+func cleanUpGraph(wg *sync.WaitGroup) {
+	wg.Add(1)
+
+	ticker := time.NewTicker(time.Minute)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			SvcGraph.cleanUp(time.Now())
+		case <-SvcGraph.stopChan:
+			wg.Done()
+			return
+		}
+	}
+}
+
+// halfLife Function
+func halfLife() time.Duration {
+	return time.Duration(config.GlobalConfig.ServiceGraphHalfLife) * time.Second
+}
+
+// == //
+
+// nodeOf Function that identifies the workload at one end of a call
+func nodeOf(namespace, workload, ip, resourceType string) (string, *serviceNode) {
+	if resourceType == types.K8sResourceTypeToString(types.K8sResourceTypeUnknown) || workload == "" {
+		// unknown peers are identified by their IP addresses
+		return "external/" + ip, &serviceNode{namespace: "", name: ip, nodeType: "External"}
+	}
+
+	return namespace + "/" + workload, &serviceNode{namespace: namespace, name: workload, nodeType: "Workload"}
+}
+
+// NodeID Function that gives the ID of a workload (or an external peer) in the service graph
+func NodeID(namespace, workload, ip, resourceType string) string {
+	id, _ := nodeOf(namespace, workload, ip, resourceType)
+	return id
+}
+
+// ObserveAPILog Function that adds a call to the service graph
+func ObserveAPILog(apiLog *protobuf.APILog) {
+	srcID, srcNode := nodeOf(apiLog.SrcNamespace, inventory.SrcWorkload(apiLog), apiLog.SrcIP, apiLog.SrcType)
+	dstID, dstNode := nodeOf(apiLog.DstNamespace, inventory.DstWorkload(apiLog), apiLog.DstIP, apiLog.DstType)
+
+	// external destinations are named by their hosts when known (e.g., external/api.stripe.com)
+	if dstNode.nodeType == "External" && apiLog.DstHost != "" {
+		dstID, dstNode = "external/"+apiLog.DstHost, &serviceNode{namespace: "", name: apiLog.DstHost, nodeType: "External"}
+	}
+
+	endpoint, _ := inventory.EndpointTemplate(apiLog)
+	endpointKey := apiLog.Method + " " + endpoint
+	edgeKey := srcID + " -> " + dstID
+
+	now := time.Now()
+	hl := halfLife()
+
+	SvcGraph.graphLock.Lock()
+	defer SvcGraph.graphLock.Unlock()
+
+	if _, ok := SvcGraph.nodes[srcID]; !ok {
+		SvcGraph.nodes[srcID] = srcNode
+	}
+	if _, ok := SvcGraph.nodes[dstID]; !ok {
+		SvcGraph.nodes[dstID] = dstNode
+	}
+
+	edge, ok := SvcGraph.edges[edgeKey]
+	if !ok {
+		edge = &serviceEdge{source: srcID, target: dstID, endpoints: make(map[string]*endpointStats)}
+		SvcGraph.edges[edgeKey] = edge
+	}
+	edge.stats.observe(apiLog, now, hl)
+
+	ep, ok := edge.endpoints[endpointKey]
+	if !ok {
+		if len(edge.endpoints) >= maxEndpointsPerEdge {
+			return
+		}
+		ep = &endpointStats{method: apiLog.Method, path: endpoint}
+		edge.endpoints[endpointKey] = ep
+	}
+	ep.stats.observe(apiLog, now, hl)
+}
+
+// cleanUp Function that forgets edges (and nodes) without recent calls
+func (sg *ServiceGraph) cleanUp(now time.Time) {
+	hl := halfLife()
+
+	sg.graphLock.Lock()
+	defer sg.graphLock.Unlock()
+
+	used := make(map[string]bool)
+
+	for key, edge := range sg.edges {
+		edge.stats.decay(now, hl)
+		if edge.stats.requests < minRequests {
+			delete(sg.edges, key)
+			continue
+		}
+
+		for epKey, ep := range edge.endpoints {
+			ep.stats.decay(now, hl)
+			if ep.stats.requests < minRequests {
+				delete(edge.endpoints, epKey)
+			}
+		}
+
+		used[edge.source] = true
+		used[edge.target] = true
+	}
+
+	for id := range sg.nodes {
+		if !used[id] {
+			delete(sg.nodes, id)
+		}
+	}
+}
+
+// == //
+
+// GetServiceGraph Function that takes a snapshot of the service graph (edges from or to the namespace if given)
+func GetServiceGraph(namespace string) *protobuf.ServiceGraph {
+	now := time.Now()
+	hl := halfLife()
+
+	result := &protobuf.ServiceGraph{
+		TimeStamp: now.Unix(),
+		HalfLife:  int64(hl.Seconds()),
+		Nodes:     make([]*protobuf.ServiceNode, 0),
+		Edges:     make([]*protobuf.ServiceEdge, 0),
+	}
+
+	SvcGraph.graphLock.Lock()
+	defer SvcGraph.graphLock.Unlock()
+
+	used := make(map[string]bool)
+
+	for _, edge := range SvcGraph.edges {
+		src, dst := SvcGraph.nodes[edge.source], SvcGraph.nodes[edge.target]
+		if namespace != "" && (src == nil || src.namespace != namespace) && (dst == nil || dst.namespace != namespace) {
+			continue
+		}
+
+		edge.stats.decay(now, hl)
+		requests, errorRate, latency := edge.stats.rates()
+
+		se := &protobuf.ServiceEdge{
+			Source:        edge.source,
+			Target:        edge.target,
+			Requests:      requests,
+			ErrorRate:     errorRate,
+			Latency:       latency,
+			TotalRequests: edge.stats.total,
+			LastSeen:      edge.stats.lastSeen.Unix(),
+			Endpoints:     make([]*protobuf.EndpointStats, 0, len(edge.endpoints)),
+		}
+
+		for _, ep := range edge.endpoints {
+			ep.stats.decay(now, hl)
+			requests, errorRate, latency := ep.stats.rates()
+
+			se.Endpoints = append(se.Endpoints, &protobuf.EndpointStats{
+				Method:        ep.method,
+				Path:          ep.path,
+				Requests:      requests,
+				ErrorRate:     errorRate,
+				Latency:       latency,
+				TotalRequests: ep.stats.total,
+				LastSeen:      ep.stats.lastSeen.Unix(),
+			})
+		}
+
+		sort.Slice(se.Endpoints, func(i, j int) bool {
+			if se.Endpoints[i].Path != se.Endpoints[j].Path {
+				return se.Endpoints[i].Path < se.Endpoints[j].Path
+			}
+			return se.Endpoints[i].Method < se.Endpoints[j].Method
+		})
+
+		result.Edges = append(result.Edges, se)
+		used[edge.source] = true
+		used[edge.target] = true
+	}
+
+	for id := range used {
+		node, ok := SvcGraph.nodes[id]
+		if !ok {
+			continue
+		}
+		result.Nodes = append(result.Nodes, &protobuf.ServiceNode{
+			Id:        id,
+			Namespace: node.namespace,
+			Name:      node.name,
+			Type:      node.nodeType,
+		})
+	}
+
+	sort.Slice(result.Nodes, func(i, j int) bool { return result.Nodes[i].Id < result.Nodes[j].Id })
+	sort.Slice(result.Edges, func(i, j int) bool {
+		if result.Edges[i].Source != result.Edges[j].Source {
+			return result.Edges[i].Source < result.Edges[j].Source
+		}
+		return result.Edges[i].Target < result.Edges[j].Target
+	})
+
+	return result
+}
+
+// == //
@@ -0,0 +1,54 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package detection
+
+import (
+	"math"
+)
+
+// == //
+
+// EWMA Structure (exponentially weighted moving average and variance)
+type EWMA struct {
+	Mean     float64
+	Variance float64
+	Samples  uint64
+}
+
+// Update Function
+func (e *EWMA) Update(value, alpha float64) {
+	if e.Samples == 0 {
+		e.Mean = value
+		e.Samples = 1
+		return
+	}
+
+	diff := value - e.Mean
+	incr := alpha * diff
+
+	e.Mean += incr
+	e.Variance = (1 - alpha) * (e.Variance + diff*incr)
+	e.Samples++
+}
+
+// StdDev Function that gives the standard deviation (10% of the mean and the given minimum at least)
+func (e *EWMA) StdDev(minStdDev float64) float64 {
+	std := math.Sqrt(e.Variance)
+
+	// allow 10% of fluctuation around the mean at least
+	if floor := 0.1 * math.Abs(e.Mean); std < floor {
+		std = floor
+	}
+	if std < minStdDev {
+		std = minStdDev
+	}
+
+	return std
+}
+
+// Score Function that gives the deviation of a value from the mean in standard deviations
+func (e *EWMA) Score(value, minStdDev float64) float64 {
+	return (value - e.Mean) / e.StdDev(minStdDev)
+}
+
+// == //
@@ -0,0 +1,56 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package detection
+
+import (
+	"sync"
+	"time"
+)
+
+// == //
+
+// Suppressor Structure (when each finding was reported last, so that it is not reported again within a period)
+type Suppressor struct {
+	period time.Duration
+
+	lastReported map[string]time.Time
+	lastPruned   time.Time
+	reportedLock sync.Mutex
+}
+
+// NewSuppressor Function
+func NewSuppressor(period time.Duration) *Suppressor {
+	s := &Suppressor{
+		period: period,
+
+		lastReported: make(map[string]time.Time),
+		reportedLock: sync.Mutex{},
+	}
+
+	return s
+}
+
+// ShouldReport Function that checks if a finding was not reported within the period (and records it as reported if so)
+// Findings reported before the period are forgotten once per period, so only the recent ones are kept
+func (s *Suppressor) ShouldReport(key string, now time.Time) bool {
+	s.reportedLock.Lock()
+	defer s.reportedLock.Unlock()
+
+	if now.Sub(s.lastPruned) >= s.period {
+		for k, last := range s.lastReported {
+			if now.Sub(last) >= s.period {
+				delete(s.lastReported, k)
+			}
+		}
+		s.lastPruned = now
+	}
+
+	if last, ok := s.lastReported[key]; ok && now.Sub(last) < s.period {
+		return false
+	}
+	s.lastReported[key] = now
+
+	return true
+}
+
+// == //
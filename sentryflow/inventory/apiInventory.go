@@ -0,0 +1,546 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package inventory
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/5gsec/SentryFlow/config"
+	"github.com/5gsec/SentryFlow/protobuf"
+	"github.com/5gsec/SentryFlow/types"
+)
+
+// == //
+
+// APIInv global reference for API Inventory
+var APIInv *Inventory
+
+// init Function
+func init() {
+	APIInv = NewAPIInventory()
+}
+
+const (
+	// maxCallers is the maximum number of callers kept per API
+	maxCallers = 256
+
+	// maxAPIs is the maximum number of APIs kept, the least recently seen ones are evicted beyond it
+	maxAPIs = 10000
+
+	// evictedAPIs is the number of APIs evicted at once when the inventory is full
+	evictedAPIs = maxAPIs / 10
+)
+
+// APIRecord Structure
+type APIRecord struct {
+	Namespace string `json:"namespace"`
+	Workload  string `json:"workload"`
+
+	Method       string `json:"method"`
+	PathTemplate string `json:"pathTemplate"`
+
+	APIType       string            `json:"apiType,omitempty"`
+	Service       string            `json:"service,omitempty"`
+	Operation     string            `json:"operation,omitempty"`
+	OperationType string            `json:"operationType,omitempty"`
+	GRPCStatuses  map[string]uint64 `json:"grpcStatuses,omitempty"`
+
+	Protocols   map[string]uint64 `json:"protocols"`
+	StatusCodes map[int32]uint64  `json:"statusCodes"`
+	Callers     map[string]uint64 `json:"callers"`
+	Ports       map[string]uint64 `json:"ports,omitempty"`
+
+	PathParams           map[string]*ParamRecord     `json:"pathParams"`
+	QueryParams          map[string]*ParamRecord     `json:"queryParams"`
+	RequestContentTypes  map[string]uint64           `json:"requestContentTypes"`
+	ResponseContentTypes map[int32]map[string]uint64 `json:"responseContentTypes"`
+
+	FirstSeen          int64  `json:"firstSeen"`
+	LastSeen           int64  `json:"lastSeen"`
+	CallCount          uint64 `json:"callCount"`
+	AuthenticatedCalls uint64 `json:"authenticatedCalls"`
+}
+
+// Inventory Structure
+type Inventory struct {
+	stopChan chan struct{}
+
+	apis     map[string]*APIRecord
+	apisLock sync.RWMutex
+
+	changes      uint64 // incremented by every update
+	savedChanges uint64 // changes written to the file
+	full         bool
+}
+
+// NewAPIInventory Function
+func NewAPIInventory() *Inventory {
+	inv := &Inventory{
+		stopChan: make(chan struct{}),
+
+		apis:     make(map[string]*APIRecord),
+		apisLock: sync.RWMutex{},
+	}
+
+	return inv
+}
+
+// == //
+
+// StartAPIInventory Function
+func StartAPIInventory(wg *sync.WaitGroup) bool {
+	if err := APIInv.load(config.GlobalConfig.APIInventoryFile); err != nil {
+		log.Printf("[APIInventory] Failed to load the API inventory from %s: %v", config.GlobalConfig.APIInventoryFile, err)
+	}
+
+	// keep saving the API inventory
+	go saveAPIInventory(wg)
+
+	log.Print("[APIInventory] Started API Inventory")
+
+	return true
+}
+
+// StopAPIInventory Function
+func StopAPIInventory() bool {
+	close(APIInv.stopChan)
+
+	if err := APIInv.save(config.GlobalConfig.APIInventoryFile); err != nil {
+		log.Printf("[APIInventory] Failed to save the API inventory to %s: %v", config.GlobalConfig.APIInventoryFile, err)
+	}
+
+	log.Print("[APIInventory] Stopped API Inventory")
+
+	return true
+}
+
+// saveAPIInventory Function
+func saveAPIInventory(wg *sync.WaitGroup) {
+	wg.Add(1)
+
+	ticker := time.NewTicker(time.Duration(config.GlobalConfig.APIInventorySavePeriod) * time.Second)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if err := APIInv.save(config.GlobalConfig.APIInventoryFile); err != nil {
+				log.Printf("[APIInventory] Failed to save the API inventory to %s: %v", config.GlobalConfig.APIInventoryFile, err)
+			}
+		case <-APIInv.stopChan:
+			wg.Done()
+			return
+		}
+	}
+}
+
+// == //
+
+// WorkloadName Function that gives a name which survives pod restarts
+func WorkloadName(name string, labels map[string]string) string {
+	for _, key := range []string{"app.kubernetes.io/name", "app"} {
+		if value, ok := labels[key]; ok && value != "" {
+			return value
+		}
+	}
+
+	return name
+}
+
+// WorkloadOf Function that prefers the top-level owner of a pod (e.g., its Deployment) resolved through owner references
+func WorkloadOf(kind, workload, name string, labels map[string]string) string {
+	if workload != "" && kind != "Pod" && kind != "Service" {
+		return workload
+	}
+	return WorkloadName(name, labels)
+}
+
+// SrcWorkload Function that gives the workload of the source of an API log
+func SrcWorkload(apiLog *protobuf.APILog) string {
+	return WorkloadOf(apiLog.SrcWorkloadKind, apiLog.SrcWorkloadName, apiLog.SrcName, apiLog.SrcLabel)
+}
+
+// DstWorkload Function that gives the workload of the destination of an API log
+func DstWorkload(apiLog *protobuf.APILog) string {
+	return WorkloadOf(apiLog.DstWorkloadKind, apiLog.DstWorkloadName, apiLog.DstName, apiLog.DstLabel)
+}
+
+// apiKey Function (with the logical endpoint, e.g., a GraphQL operation on its path)
+func apiKey(namespace, workload, method, endpoint string) string {
+	return fmt.Sprintf("%s/%s %s %s", namespace, workload, method, endpoint)
+}
+
+// UpdateAPI Function that records an API log and returns an event if the API was never seen before
+func UpdateAPI(apiLog *protobuf.APILog) *protobuf.APIEvent {
+	pathTemplate, pathParams := PathTemplate(apiLog.Path)
+	endpoint, _ := EndpointTemplate(apiLog)
+	_, query := SplitPath(apiLog.Path)
+
+	namespace := apiLog.DstNamespace
+	workload := DstWorkload(apiLog)
+	caller := fmt.Sprintf("%s/%s", apiLog.SrcNamespace, SrcWorkload(apiLog))
+
+	seen := types.ParseTimeStamp(apiLog.TimeStamp).Unix()
+	key := apiKey(namespace, workload, apiLog.Method, endpoint)
+
+	APIInv.apisLock.Lock()
+	defer APIInv.apisLock.Unlock()
+
+	APIInv.changes++
+
+	record, ok := APIInv.apis[key]
+	if !ok {
+		if len(APIInv.apis) >= maxAPIs {
+			if !APIInv.full {
+				log.Printf("[APIInventory] Too many APIs, evicting the least recently seen ones (max: %d)", maxAPIs)
+				APIInv.full = true
+			}
+			APIInv.evict(len(APIInv.apis) - maxAPIs + evictedAPIs)
+		}
+
+		record = &APIRecord{
+			Namespace:    namespace,
+			Workload:     workload,
+			Method:       apiLog.Method,
+			PathTemplate: pathTemplate,
+			APIType:      apiLog.ApiType,
+			Service:      apiLog.Service,
+			Operation:    apiLog.Operation,
+			Protocols:    make(map[string]uint64),
+			StatusCodes:  make(map[int32]uint64),
+			Callers:      make(map[string]uint64),
+			FirstSeen:    seen,
+		}
+		APIInv.apis[key] = record
+	}
+	record.initParams()
+
+	record.Protocols[apiLog.Protocol]++
+
+	if record.OperationType == "" {
+		record.OperationType = apiLog.OperationType
+	}
+	if apiLog.GrpcStatus != "" {
+		record.GRPCStatuses[apiLog.GrpcStatus]++
+	}
+	record.StatusCodes[apiLog.ResponseCode]++
+
+	// ports of Services differ from those of pods, so only the ports of pods are kept
+	if apiLog.DstType == types.K8sResourceTypeToString(types.K8sResourceTypePod) && apiLog.DstPort != "" {
+		record.Ports[apiLog.DstPort]++
+	}
+	if _, exist := record.Callers[caller]; exist || len(record.Callers) < maxCallers {
+		record.Callers[caller]++
+	}
+
+	for name, value := range pathParams {
+		observeParam(record.PathParams, name, value)
+	}
+	observeQuery(record.QueryParams, query)
+
+	if contentType := MediaType(apiLog.RequestHeaders["content-type"]); contentType != "" {
+		record.RequestContentTypes[contentType]++
+	}
+	if contentType := MediaType(apiLog.ResponseHeaders["content-type"]); contentType != "" {
+		if _, ok := record.ResponseContentTypes[apiLog.ResponseCode]; !ok {
+			record.ResponseContentTypes[apiLog.ResponseCode] = make(map[string]uint64)
+		}
+		record.ResponseContentTypes[apiLog.ResponseCode][contentType]++
+	}
+
+	if seen < record.FirstSeen {
+		record.FirstSeen = seen
+	}
+	if seen > record.LastSeen {
+		record.LastSeen = seen
+	}
+	record.CallCount++
+	if HasCredentials(apiLog.RequestHeaders) {
+		record.AuthenticatedCalls++
+	}
+
+	if ok {
+		return nil
+	}
+
+	return &protobuf.APIEvent{
+		TimeStamp:   apiLog.TimeStamp,
+		Type:        "NewAPI",
+		Description: fmt.Sprintf("Discovered %s %s on %s/%s", record.Method, endpoint, namespace, workload),
+		API:         record.toEndpoint(key),
+	}
+}
+
+// evict Function that removes the least recently seen APIs (the caller holds the lock)
+func (inv *Inventory) evict(count int) {
+	if count <= 0 {
+		return
+	}
+
+	keys := make([]string, 0, len(inv.apis))
+	for key := range inv.apis {
+		keys = append(keys, key)
+	}
+
+	sort.Slice(keys, func(i, j int) bool {
+		return inv.apis[keys[i]].LastSeen < inv.apis[keys[j]].LastSeen
+	})
+
+	if count > len(keys) {
+		count = len(keys)
+	}
+	for _, key := range keys[:count] {
+		delete(inv.apis, key)
+	}
+}
+
+// initParams Function that initializes the fields missing in records persisted by older versions
+func (rec *APIRecord) initParams() {
+	if rec.APIType == "" {
+		rec.APIType = types.APITypeREST
+	}
+	if rec.PathParams == nil {
+		rec.PathParams = make(map[string]*ParamRecord)
+	}
+	if rec.QueryParams == nil {
+		rec.QueryParams = make(map[string]*ParamRecord)
+	}
+	if rec.RequestContentTypes == nil {
+		rec.RequestContentTypes = make(map[string]uint64)
+	}
+	if rec.ResponseContentTypes == nil {
+		rec.ResponseContentTypes = make(map[int32]map[string]uint64)
+	}
+	if rec.GRPCStatuses == nil {
+		rec.GRPCStatuses = make(map[string]uint64)
+	}
+	if rec.Ports == nil {
+		rec.Ports = make(map[string]uint64)
+	}
+}
+
+// toEndpoint Function
+func (rec *APIRecord) toEndpoint(key string) *protobuf.APIEndpoint {
+	ep := &protobuf.APIEndpoint{
+		Id:            key,
+		Namespace:     rec.Namespace,
+		Workload:      rec.Workload,
+		Method:        rec.Method,
+		PathTemplate:  rec.PathTemplate,
+		Protocols:     make([]string, 0, len(rec.Protocols)),
+		StatusCodes:   make(map[int32]uint64, len(rec.StatusCodes)),
+		Callers:       make(map[string]uint64, len(rec.Callers)),
+		ApiType:       rec.APIType,
+		Service:       rec.Service,
+		Operation:     rec.Operation,
+		OperationType: rec.OperationType,
+		GrpcStatuses:  make(map[string]uint64, len(rec.GRPCStatuses)),
+		Ports:         make([]string, 0, len(rec.Ports)),
+		FirstSeen:     rec.FirstSeen,
+		LastSeen:      rec.LastSeen,
+		CallCount:     rec.CallCount,
+
+		AuthenticatedCalls: rec.AuthenticatedCalls,
+	}
+
+	for protocol := range rec.Protocols {
+		ep.Protocols = append(ep.Protocols, protocol)
+	}
+	sort.Strings(ep.Protocols)
+
+	for code, count := range rec.StatusCodes {
+		ep.StatusCodes[code] = count
+	}
+
+	for caller, count := range rec.Callers {
+		ep.Callers[caller] = count
+	}
+
+	for grpcStatus, count := range rec.GRPCStatuses {
+		ep.GrpcStatuses[grpcStatus] = count
+	}
+
+	for port := range rec.Ports {
+		ep.Ports = append(ep.Ports, port)
+	}
+	sort.Strings(ep.Ports)
+
+	return ep
+}
+
+// matchQuery Function
+func (rec *APIRecord) matchQuery(query *protobuf.APIQuery) bool {
+	if query.Namespace != "" && query.Namespace != rec.Namespace {
+		return false
+	}
+
+	if query.Workload != "" && query.Workload != rec.Workload {
+		return false
+	}
+
+	if query.Method != "" && !strings.EqualFold(query.Method, rec.Method) {
+		return false
+	}
+
+	if query.Path != "" {
+		if pathTemplate, _ := PathTemplate(query.Path); pathTemplate != rec.PathTemplate && query.Path != rec.PathTemplate {
+			return false
+		}
+	}
+
+	return true
+}
+
+// ListAPIs Function
+func ListAPIs(query *protobuf.APIQuery) []*protobuf.APIEndpoint {
+	APIInv.apisLock.RLock()
+	defer APIInv.apisLock.RUnlock()
+
+	apis := make([]*protobuf.APIEndpoint, 0)
+
+	for key, record := range APIInv.apis {
+		if query.Id != "" && query.Id != key {
+			continue
+		}
+
+		if !record.matchQuery(query) {
+			continue
+		}
+
+		apis = append(apis, record.toEndpoint(key))
+	}
+
+	sort.Slice(apis, func(i, j int) bool {
+		return apis[i].Id < apis[j].Id
+	})
+
+	return apis
+}
+
+// GetAPI Function
+func GetAPI(query *protobuf.APIQuery) (*protobuf.APIEndpoint, bool) {
+	APIInv.apisLock.RLock()
+	defer APIInv.apisLock.RUnlock()
+
+	if query.Id != "" {
+		record, ok := APIInv.apis[query.Id]
+		if !ok {
+			return nil, false
+		}
+		return record.toEndpoint(query.Id), true
+	}
+
+	for _, endpoint := range queryEndpoints(query.Path) {
+		key := apiKey(query.Namespace, query.Workload, strings.ToUpper(query.Method), endpoint)
+		if record, ok := APIInv.apis[key]; ok {
+			return record.toEndpoint(key), true
+		}
+	}
+
+	return nil, false
+}
+
+// queryEndpoints Function that gives the endpoints an API may be recorded under for a queried path (the same as UpdateAPI)
+// The type of the API is not part of queries, so the path is tried as a REST path, a gRPC method and a logical endpoint as is
+func queryEndpoints(path string) []string {
+	endpoints := make([]string, 0, 3)
+	for _, apiType := range []string{types.APITypeREST, types.APITypeGRPC} {
+		endpoint, _ := EndpointTemplate(&protobuf.APILog{Path: path, ApiType: apiType})
+		endpoints = append(endpoints, endpoint)
+	}
+	return append(endpoints, path) // e.g., /graphql (query GetUser)
+}
+
+// VisitAPIs Function that calls the given function for each API of a workload while holding the inventory lock
+// The function must not keep references to the given record
+func VisitAPIs(namespace, workload string, visit func(key string, record *APIRecord)) {
+	APIInv.apisLock.RLock()
+	defer APIInv.apisLock.RUnlock()
+
+	for key, record := range APIInv.apis {
+		if record.Namespace == namespace && record.Workload == workload {
+			visit(key, record)
+		}
+	}
+}
+
+// == //
+
+// load Function
+func (inv *Inventory) load(fileName string) error {
+	if fileName == "" {
+		return nil
+	}
+
+	data, err := os.ReadFile(filepath.Clean(fileName))
+	if os.IsNotExist(err) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	apis := make(map[string]*APIRecord)
+	if err := json.Unmarshal(data, &apis); err != nil {
+		return err
+	}
+
+	inv.apisLock.Lock()
+	inv.apis = apis
+	inv.evict(len(apis) - maxAPIs)
+	inv.apisLock.Unlock()
+
+	log.Printf("[APIInventory] Loaded %d APIs from %s", len(apis), fileName)
+
+	return nil
+}
+
+// save Function
+func (inv *Inventory) save(fileName string) error {
+	if fileName == "" {
+		return nil
+	}
+
+	inv.apisLock.Lock()
+	changes := inv.changes
+	if changes == inv.savedChanges {
+		inv.apisLock.Unlock()
+		return nil
+	}
+	data, err := json.Marshal(inv.apis)
+	inv.apisLock.Unlock()
+
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(fileName), 0750); err != nil {
+		return err
+	}
+
+	// write to a temporary file first so that a crash never leaves a partial inventory behind
+	tmpFile := fileName + ".tmp"
+	if err := os.WriteFile(tmpFile, data, 0600); err != nil {
+		return err
+	}
+
+	if err := os.Rename(tmpFile, fileName); err != nil {
+		return err
+	}
+
+	// only the changes written are saved (updates made while writing are written next time)
+	inv.apisLock.Lock()
+	inv.savedChanges = changes
+	inv.apisLock.Unlock()
+
+	return nil
+}
+
+// == //
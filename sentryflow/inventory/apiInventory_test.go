@@ -0,0 +1,119 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package inventory
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// newTestInventory Function that gives an inventory of APIs last seen at 1, 2, ... count
+func newTestInventory(count int) *Inventory {
+	inv := NewAPIInventory()
+	for seen := 1; seen <= count; seen++ {
+		inv.apis[fmt.Sprintf("api-%d", seen)] = &APIRecord{LastSeen: int64(seen)}
+	}
+	return inv
+}
+
+// TestEvict checks that the least recently seen APIs are evicted
+func TestEvict(t *testing.T) {
+	tests := []struct {
+		name    string
+		apis    int
+		count   int
+		evicted []string
+	}{
+		{"nothing", 5, 0, []string{}},
+		{"negative", 5, -3, []string{}},
+		{"oldest", 5, 2, []string{"api-1", "api-2"}},
+		{"everything", 3, 10, []string{"api-1", "api-2", "api-3"}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			inv := newTestInventory(tc.apis)
+			inv.evict(tc.count)
+
+			if len(inv.apis) != tc.apis-len(tc.evicted) {
+				t.Errorf("expected %d APIs, got %d", tc.apis-len(tc.evicted), len(inv.apis))
+			}
+			for _, key := range tc.evicted {
+				if _, ok := inv.apis[key]; ok {
+					t.Errorf("%s was not evicted", key)
+				}
+			}
+		})
+	}
+}
+
+// TestLoadEvicts checks that loading an inventory over the limit keeps the most recently seen APIs
+func TestLoadEvicts(t *testing.T) {
+	fileName := filepath.Join(t.TempDir(), "inventory.json")
+
+	data, err := json.Marshal(newTestInventory(maxAPIs + 10).apis)
+	if err != nil {
+		t.Fatalf("failed to marshal the inventory: %v", err)
+	}
+	if err := os.WriteFile(fileName, data, 0600); err != nil {
+		t.Fatalf("failed to write the inventory: %v", err)
+	}
+
+	inv := NewAPIInventory()
+	if err := inv.load(fileName); err != nil {
+		t.Fatalf("failed to load the inventory: %v", err)
+	}
+
+	if len(inv.apis) != maxAPIs {
+		t.Errorf("expected %d APIs, got %d", maxAPIs, len(inv.apis))
+	}
+	if _, ok := inv.apis["api-10"]; ok {
+		t.Error("api-10 was not evicted")
+	}
+	if _, ok := inv.apis["api-11"]; !ok {
+		t.Error("api-11 was evicted")
+	}
+}
+
+// TestSave checks that the inventory is written only when it changed, and again after a failed write
+func TestSave(t *testing.T) {
+	dir := t.TempDir()
+	fileName := filepath.Join(dir, "inventory.json")
+
+	inv := newTestInventory(3)
+
+	if err := inv.save(fileName); err != nil {
+		t.Fatalf("failed to save an unchanged inventory: %v", err)
+	}
+	if _, err := os.Stat(fileName); !os.IsNotExist(err) {
+		t.Fatal("unchanged inventory was written")
+	}
+
+	inv.changes++
+
+	// a directory in place of the file makes the write fail
+	if err := os.Mkdir(fileName, 0700); err != nil {
+		t.Fatalf("failed to create a directory: %v", err)
+	}
+	if err := inv.save(fileName); err == nil {
+		t.Fatal("expected an error")
+	}
+	if err := os.Remove(fileName); err != nil {
+		t.Fatalf("failed to remove the directory: %v", err)
+	}
+
+	if err := inv.save(fileName); err != nil {
+		t.Fatalf("failed to save the inventory: %v", err)
+	}
+
+	saved := NewAPIInventory()
+	if err := saved.load(fileName); err != nil {
+		t.Fatalf("failed to load the inventory: %v", err)
+	}
+	if len(saved.apis) != 3 {
+		t.Errorf("expected 3 APIs, got %d", len(saved.apis))
+	}
+}
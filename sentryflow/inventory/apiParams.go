@@ -0,0 +1,126 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package inventory
+
+import (
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// == //
+
+// maxParams is the maximum number of query parameters kept per API
+const maxParams = 64
+
+// Value types inferred from observed parameters
+const (
+	ValueTypeInteger  = "integer"
+	ValueTypeNumber   = "number"
+	ValueTypeBoolean  = "boolean"
+	ValueTypeUUID     = "uuid"
+	ValueTypeDate     = "date"
+	ValueTypeDateTime = "date-time"
+	ValueTypeString   = "string"
+)
+
+// ParamRecord Structure
+type ParamRecord struct {
+	Types   map[string]uint64 `json:"types"`
+	Count   uint64            `json:"count"`
+	Example string            `json:"example"`
+}
+
+// InferValueType Function that infers the type of a parameter value
+func InferValueType(value string) string {
+	if _, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return ValueTypeInteger
+	}
+
+	if _, err := strconv.ParseFloat(value, 64); err == nil {
+		return ValueTypeNumber
+	}
+
+	if value == "true" || value == "false" {
+		return ValueTypeBoolean
+	}
+
+	if uuidSegment.MatchString(value) {
+		return ValueTypeUUID
+	}
+
+	if _, err := time.Parse("2006-01-02", value); err == nil {
+		return ValueTypeDate
+	}
+
+	if _, err := time.Parse(time.RFC3339, value); err == nil {
+		return ValueTypeDateTime
+	}
+
+	return ValueTypeString
+}
+
+// observeParam Function
+func observeParam(params map[string]*ParamRecord, name, value string) {
+	param, ok := params[name]
+	if !ok {
+		if len(params) >= maxParams {
+			return
+		}
+
+		param = &ParamRecord{
+			Types:   make(map[string]uint64),
+			Example: value,
+		}
+		params[name] = param
+	}
+
+	param.Types[InferValueType(value)]++
+	param.Count++
+}
+
+// DominantType Function that gives the most general type covering all observed values
+func (param *ParamRecord) DominantType() string {
+	if len(param.Types) == 1 {
+		for valueType := range param.Types {
+			return valueType
+		}
+	}
+
+	// integers and numbers can be mixed
+	if len(param.Types) == 2 && param.Types[ValueTypeInteger] > 0 && param.Types[ValueTypeNumber] > 0 {
+		return ValueTypeNumber
+	}
+
+	return ValueTypeString
+}
+
+// observeQuery Function
+func observeQuery(params map[string]*ParamRecord, query string) {
+	if query == "" {
+		return
+	}
+
+	values, err := url.ParseQuery(query)
+	if err != nil && len(values) == 0 {
+		return
+	}
+
+	for name, vals := range values {
+		if name == "" || len(vals) == 0 {
+			continue
+		}
+		observeParam(params, name, vals[0])
+	}
+}
+
+// MediaType Function that strips parameters from a content type (e.g., "application/json; charset=utf-8")
+func MediaType(contentType string) string {
+	if idx := strings.Index(contentType, ";"); idx >= 0 {
+		contentType = contentType[:idx]
+	}
+	return strings.ToLower(strings.TrimSpace(contentType))
+}
+
+// == //
@@ -0,0 +1,65 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package inventory
+
+import (
+	"strings"
+
+	"github.com/5gsec/SentryFlow/protobuf"
+)
+
+// == //
+
+// grpcServerErrors are the gRPC status codes caused by servers rather than clients
+var grpcServerErrors = map[string]bool{
+	"2":  true, // UNKNOWN
+	"4":  true, // DEADLINE_EXCEEDED
+	"12": true, // UNIMPLEMENTED
+	"13": true, // INTERNAL
+	"14": true, // UNAVAILABLE
+	"15": true, // DATA_LOSS
+}
+
+// IsError Function that checks if a call failed (gRPC calls fail with HTTP 200 and a non-zero gRPC status)
+func IsError(apiLog *protobuf.APILog) bool {
+	return apiLog.ResponseCode >= 400 || (apiLog.GrpcStatus != "" && apiLog.GrpcStatus != "0")
+}
+
+// HasCredentials Function that checks if a request carries credentials
+func HasCredentials(headers map[string]string) bool {
+	for _, name := range []string{"authorization", "proxy-authorization", "x-api-key", "x-auth-token", "cookie"} {
+		if strings.TrimSpace(headers[name]) != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// IsServerError Function that checks if a call failed because of the server
+func IsServerError(apiLog *protobuf.APILog) bool {
+	return apiLog.ResponseCode >= 500 || grpcServerErrors[apiLog.GrpcStatus]
+}
+
+// ServerErrorCalls Function that counts the calls to an API that failed because of the server
+// HTTP and gRPC statuses are counted separately, so the larger count is taken
+func ServerErrorCalls(api *protobuf.APIEndpoint) uint64 {
+	httpErrors, grpcErrors := uint64(0), uint64(0)
+
+	for code, count := range api.StatusCodes {
+		if code >= 500 {
+			httpErrors += count
+		}
+	}
+	for grpcStatus, count := range api.GrpcStatuses {
+		if grpcServerErrors[grpcStatus] {
+			grpcErrors += count
+		}
+	}
+
+	if grpcErrors > httpErrors {
+		return grpcErrors
+	}
+	return httpErrors
+}
+
+// == //
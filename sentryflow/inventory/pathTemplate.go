@@ -0,0 +1,253 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package inventory
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/5gsec/SentryFlow/protobuf"
+	"github.com/5gsec/SentryFlow/types"
+)
+
+// == //
+
+var (
+	numericSegment = regexp.MustCompile(`^[0-9]+$`)
+	uuidSegment    = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+	hexSegment     = regexp.MustCompile(`^[0-9a-fA-F]{16,}$`)
+
+	// values replaced by the redaction (e.g., [REDACTED:email], [email:1a2b3c4d5e6f])
+	redactedSegment = regexp.MustCompile(`^\[[A-Za-z]+:[0-9A-Za-z]+\]$`)
+
+	// words joined by separators (e.g., hello-world, release_2024.1)
+	slugSegment = regexp.MustCompile(`^[0-9A-Za-z]+([-_.][0-9A-Za-z]+)+$`)
+)
+
+// minSlugValues is the number of slugs from which a segment shared by path templates is merged into a parameter
+const minSlugValues = 5
+
+// SplitPath Function that splits a raw path into the path itself and its query string
+func SplitPath(rawPath string) (string, string) {
+	path := rawPath
+	query := ""
+
+	if idx := strings.Index(path, "?"); idx >= 0 {
+		query = path[idx+1:]
+		path = path[:idx]
+	}
+
+	if idx := strings.Index(path, "#"); idx >= 0 {
+		path = path[:idx]
+	}
+
+	if path == "" {
+		path = "/"
+	}
+
+	return path, query
+}
+
+// isParamSegment Function that checks if a path segment looks like an identifier rather than a static name
+func isParamSegment(segment string) bool {
+	if numericSegment.MatchString(segment) || uuidSegment.MatchString(segment) || hexSegment.MatchString(segment) || redactedSegment.MatchString(segment) {
+		return true
+	}
+
+	// long tokens mixing letters and digits (e.g., object keys, hashes)
+	if len(segment) >= 16 {
+		hasLetter, hasDigit := false, false
+		for _, c := range segment {
+			if unicode.IsLetter(c) {
+				hasLetter = true
+			} else if unicode.IsDigit(c) {
+				hasDigit = true
+			}
+		}
+		return hasLetter && hasDigit
+	}
+
+	return false
+}
+
+// paramName Function that names a path parameter after the static segment in front of it
+func paramName(prev string, used map[string]bool) string {
+	base := "param"
+
+	if prev != "" {
+		prev = strings.TrimSuffix(strings.ToLower(prev), "s")
+
+		var sb strings.Builder
+		upper := false
+		for _, c := range prev {
+			if unicode.IsLetter(c) || unicode.IsDigit(c) {
+				if upper {
+					sb.WriteRune(unicode.ToUpper(c))
+				} else {
+					sb.WriteRune(c)
+				}
+				upper = false
+			} else {
+				upper = sb.Len() > 0
+			}
+		}
+
+		if sb.Len() > 0 && unicode.IsLetter(rune(sb.String()[0])) {
+			base = sb.String() + "Id"
+		}
+	}
+
+	name := base
+	for i := 2; used[name]; i++ {
+		name = fmt.Sprintf("%s%d", base, i)
+	}
+	used[name] = true
+
+	return name
+}
+
+// PathTemplate Function that converts a concrete path into a path template (e.g., /users/42 -> /users/{userId})
+// It returns the template and the values of the templated segments keyed by parameter name
+func PathTemplate(rawPath string) (string, map[string]string) {
+	path, _ := SplitPath(rawPath)
+	params := map[string]string{}
+
+	segments := strings.Split(path, "/")
+	used := map[string]bool{}
+	prev := ""
+
+	for idx, segment := range segments {
+		if segment == "" {
+			continue
+		}
+
+		if isParamSegment(segment) {
+			name := paramName(prev, used)
+			params[name] = segment
+			segments[idx] = "{" + name + "}"
+			prev = ""
+			continue
+		}
+
+		prev = segment
+	}
+
+	return strings.Join(segments, "/"), params
+}
+
+// isParamTemplate Function that checks if a segment of a path template is a parameter (e.g., {userId})
+func isParamTemplate(segment string) bool {
+	return strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}")
+}
+
+// MergeTemplates Function that merges path templates differing only in a segment taking many slugs
+// Slugs (e.g., /articles/hello-world) do not look like identifiers, so each of them is a path template of its own
+// Other literal segments (e.g., /articles/latest) are kept as static names
+// It returns the merged template of each given template (e.g., /articles/hello-world -> /articles/{articleId})
+func MergeTemplates(pathTemplates []string) map[string]string {
+	segments := make(map[string][]string, len(pathTemplates))
+	for _, pathTemplate := range pathTemplates {
+		segments[pathTemplate] = strings.Split(pathTemplate, "/")
+	}
+
+	for pos := 1; ; pos++ {
+		// group the templates by their other segments, and gather the slugs at the position
+		groups := make(map[string]map[string]bool)
+		found := false
+
+		for _, segs := range segments {
+			if pos >= len(segs) {
+				continue
+			}
+			found = true
+
+			if !slugSegment.MatchString(segs[pos]) {
+				continue
+			}
+
+			key := strings.Join(segs[:pos], "/") + "\x00" + strings.Join(segs[pos+1:], "/")
+			if _, ok := groups[key]; !ok {
+				groups[key] = make(map[string]bool)
+			}
+			groups[key][segs[pos]] = true
+		}
+
+		if !found {
+			break
+		}
+
+		// slugs merged under each prefix, so that longer paths with the same slugs are merged too (e.g., /articles/hello-world/comments)
+		prefixes := make(map[string]map[string]bool)
+		for key, values := range groups {
+			if len(values) < minSlugValues {
+				continue
+			}
+
+			prefix, _, _ := strings.Cut(key, "\x00")
+			if _, ok := prefixes[prefix]; !ok {
+				prefixes[prefix] = make(map[string]bool)
+			}
+			for value := range values {
+				prefixes[prefix][value] = true
+			}
+		}
+
+		for pathTemplate, segs := range segments {
+			if pos >= len(segs) || !prefixes[strings.Join(segs[:pos], "/")][segs[pos]] {
+				continue
+			}
+
+			used := map[string]bool{}
+			for _, segment := range segs {
+				if isParamTemplate(segment) {
+					used[strings.Trim(segment, "{}")] = true
+				}
+			}
+
+			prev := segs[pos-1]
+			if isParamTemplate(prev) {
+				prev = ""
+			}
+
+			merged := append([]string(nil), segs...)
+			merged[pos] = "{" + paramName(prev, used) + "}"
+			segments[pathTemplate] = merged
+		}
+	}
+
+	merged := make(map[string]string, len(segments))
+	for pathTemplate, segs := range segments {
+		merged[pathTemplate] = strings.Join(segs, "/")
+	}
+
+	return merged
+}
+
+// == //
+
+// GraphQLOperation Function that gives the type and the name of a GraphQL operation (e.g., query GetUser)
+func GraphQLOperation(apiLog *protobuf.APILog) string {
+	return strings.TrimSpace(apiLog.OperationType + " " + apiLog.Operation)
+}
+
+// EndpointTemplate Function that gives the logical endpoint of an API log
+// gRPC methods are kept as they are, and GraphQL operations on the same path are told apart (e.g., /graphql (query GetUser))
+func EndpointTemplate(apiLog *protobuf.APILog) (string, map[string]string) {
+	switch apiLog.ApiType {
+	case types.APITypeGRPC:
+		path, _ := SplitPath(apiLog.Path)
+		return path, map[string]string{}
+	case types.APITypeGraphQL:
+		pathTemplate, params := PathTemplate(apiLog.Path)
+		if operation := GraphQLOperation(apiLog); operation != "" {
+			return fmt.Sprintf("%s (%s)", pathTemplate, operation), params
+		}
+		return pathTemplate, params
+	}
+
+	return PathTemplate(apiLog.Path)
+}
+
+// == //
@@ -0,0 +1,155 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package k8s
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
+
+	"github.com/5gsec/SentryFlow/types"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// == //
+
+// GatewayGVR is the Gateway API resource deploying waypoint proxies in the ambient mode
+var GatewayGVR = schema.GroupVersionResource{Group: "gateway.networking.k8s.io", Version: "v1", Resource: "gateways"}
+
+// Labels and annotations of the Istio ambient mode
+const (
+	DataplaneModeLabel   = "istio.io/dataplane-mode" // on namespaces (or pods) enrolled in the ambient mesh
+	DataplaneModeAmbient = "ambient"
+
+	ambientRedirectionAnnotation = "ambient.istio.io/redirection" // set by the Istio CNI on pods whose traffic goes through ztunnel
+	ambientRedirectionEnabled    = "enabled"
+
+	waypointGatewayLabel = "gateway.networking.k8s.io/gateway-name"
+	waypointManagedLabel = "gateway.istio.io/managed"
+	waypointManagedValue = "istio.io-mesh-controller"
+	waypointGatewayClass = "istio-waypoint"
+
+	ztunnelSelector = "app=ztunnel"
+)
+
+// == //
+
+// isWaypoint Function that checks if the labels are the ones of a waypoint proxy
+func isWaypoint(labels map[string]string) bool {
+	return labels[waypointManagedLabel] == waypointManagedValue && labels[waypointGatewayLabel] != ""
+}
+
+// IsWaypoint Function that checks if a resource is a waypoint proxy
+func IsWaypoint(resource types.K8sResource) bool {
+	return resource.Type == types.K8sResourceTypePod && isWaypoint(resource.Labels)
+}
+
+// InAmbientMesh Function that checks if the traffic of a pod goes through ztunnel (or if it is a waypoint proxy)
+func InAmbientMesh(pod types.K8sResource) bool {
+	return pod.Annotations[ambientRedirectionAnnotation] == ambientRedirectionEnabled || isWaypoint(pod.Labels)
+}
+
+// IsAmbientNamespace Function that checks if the labels of a namespace enroll it in the ambient mesh
+func IsAmbientNamespace(labels map[string]string) bool {
+	return labels[DataplaneModeLabel] == DataplaneModeAmbient
+}
+
+// == //
+
+// LookupIdentity Function that gives the workload behind a SPIFFE identity (spiffe://<trust domain>/ns/<namespace>/sa/<service account>)
+// Waypoint proxies and ztunnel stand between clients and servers in the ambient mode, so identities tell the real ends
+// If workloads share the service account, the identity itself is given with the workload left unresolved
+func LookupIdentity(identity string) (types.K8sResource, bool) {
+	namespace, serviceAccount, ok := parseIdentity(identity)
+	if !ok {
+		return types.K8sResource{}, false
+	}
+
+	resource, workloads := K8sH.index.lookupServiceAccount(namespace, serviceAccount)
+	switch workloads {
+	case 0:
+		return types.K8sResource{}, false
+	case 1:
+		return resource, true
+	}
+
+	return types.K8sResource{
+		Type:      types.K8sResourceTypeUnknown,
+		Namespace: namespace,
+		Name:      identity,
+	}, true
+}
+
+// parseIdentity Function that gives the namespace and the service account of a SPIFFE identity
+func parseIdentity(identity string) (string, string, bool) {
+	path, ok := strings.CutPrefix(identity, "spiffe://")
+	if !ok {
+		return "", "", false
+	}
+
+	// <trust domain>/ns/<namespace>/sa/<service account>
+	parts := strings.Split(path, "/")
+	if len(parts) != 5 || parts[1] != "ns" || parts[3] != "sa" || parts[2] == "" || parts[4] == "" {
+		return "", "", false
+	}
+
+	return parts[2], parts[4], true
+}
+
+// == //
+
+// ZtunnelPods Function that gives the running ztunnel pods (the node proxies of the ambient mode)
+// With namespace-scoped RBAC, ztunnel is only looked for in the Istio root namespace
+func ZtunnelPods() ([]corev1.Pod, error) {
+	if K8sH.clientSet == nil {
+		return nil, errors.New("kubernetes client is not initialized")
+	}
+
+	namespace := corev1.NamespaceAll
+	if K8sH.currentScope().namespaceScoped() {
+		namespace = istioRootNamespace
+	}
+
+	pods, err := K8sH.clientSet.CoreV1().Pods(namespace).List(context.TODO(), v1.ListOptions{LabelSelector: ztunnelSelector})
+	if err != nil {
+		return nil, err
+	}
+
+	running := make([]corev1.Pod, 0, len(pods.Items))
+	for _, pod := range pods.Items {
+		if pod.Status.Phase == corev1.PodRunning && pod.Status.PodIP != "" {
+			running = append(running, pod)
+		}
+	}
+
+	return running, nil
+}
+
+// waypointGateways Function that gives the names of the waypoint proxies (Gateways of the istio-waypoint class) in a namespace
+func waypointGateways(namespace string) ([]string, error) {
+	gateways, err := K8sH.dynamicClient.Resource(GatewayGVR).Namespace(namespace).List(context.TODO(), v1.ListOptions{})
+	if apierrors.IsNotFound(err) {
+		return nil, nil // the Gateway API is not installed
+	} else if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0)
+	for _, gateway := range gateways.Items {
+		if class, _, _ := unstructured.NestedString(gateway.Object, "spec", "gatewayClassName"); class == waypointGatewayClass {
+			names = append(names, gateway.GetName())
+		}
+	}
+
+	sort.Strings(names)
+
+	return names, nil
+}
+
+// == //
@@ -0,0 +1,235 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/5gsec/SentryFlow/config"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/tools/cache"
+)
+
+// == //
+
+// ConfigResourceGVR is the custom resource declaring the configuration of SentryFlow
+var ConfigResourceGVR = schema.GroupVersionResource{Group: "sentryflow.io", Version: "v1alpha1", Resource: "sentryflowconfigs"}
+
+// Phases reported in the status of SentryFlowConfig resources
+const (
+	ConfigPhaseAccepted = "Accepted"
+	ConfigPhaseRejected = "Rejected"
+)
+
+// ConfigCtrl global reference for Config Controller
+var ConfigCtrl *ConfigController
+
+// init Function
+func init() {
+	ConfigCtrl = NewConfigController()
+}
+
+// ConfigController Structure
+type ConfigController struct {
+	stopChan chan struct{}
+
+	namespace string
+	name      string
+
+	apply    func(config.SentryFlowConfig) error
+	baseline config.SentryFlowConfig // config (from flags and the config file) restored when the resource is deleted
+
+	appliedUID        types.UID
+	appliedGeneration int64
+	appliedLock       sync.Mutex
+}
+
+// NewConfigController Function
+func NewConfigController() *ConfigController {
+	cc := &ConfigController{
+		stopChan:    make(chan struct{}),
+		appliedLock: sync.Mutex{},
+	}
+
+	return cc
+}
+
+// == //
+
+// StartConfigController Function that applies the SentryFlowConfig resource (and its changes) with the given function
+func StartConfigController(wg *sync.WaitGroup, apply func(config.SentryFlowConfig) error) bool {
+	if config.GlobalConfig.ConfigResource == "" {
+		log.Print("[ConfigController] Disabled Config Controller")
+		return true
+	}
+
+	namespace, name, ok := strings.Cut(config.GlobalConfig.ConfigResource, "/")
+	if !ok || namespace == "" || name == "" {
+		log.Printf("[ConfigController] Invalid config resource %q (expected namespace/name)", config.GlobalConfig.ConfigResource)
+		return false
+	}
+
+	if K8sH.dynamicClient == nil {
+		log.Print("[ConfigController] Kubernetes client is not initialized")
+		return false
+	}
+
+	// The controller is optional, so go without it if the CRD is not installed
+	if _, err := K8sH.clientSet.Discovery().ServerResourcesForGroupVersion(ConfigResourceGVR.GroupVersion().String()); err != nil {
+		log.Printf("[ConfigController] Skipped Config Controller (%s is not installed: %v)", ConfigResourceGVR.GroupResource(), err)
+		return true
+	}
+
+	ConfigCtrl.namespace = namespace
+	ConfigCtrl.name = name
+	ConfigCtrl.apply = apply
+	ConfigCtrl.baseline = config.GlobalConfig
+
+	client := K8sH.dynamicClient.Resource(ConfigResourceGVR).Namespace(namespace)
+	selector := fields.OneTermEqualSelector("metadata.name", name).String()
+
+	watcher := &cache.ListWatch{
+		ListFunc: func(options v1.ListOptions) (runtime.Object, error) {
+			options.FieldSelector = selector
+			return client.List(context.TODO(), options)
+		},
+		WatchFunc: func(options v1.ListOptions) (watch.Interface, error) {
+			options.FieldSelector = selector
+			return client.Watch(context.TODO(), options)
+		},
+	}
+
+	_, informer := cache.NewInformer(
+		watcher,
+		&unstructured.Unstructured{},
+		time.Second*0,
+		cache.ResourceEventHandlerFuncs{
+			AddFunc: func(obj interface{}) {
+				ConfigCtrl.reconcile(obj.(*unstructured.Unstructured))
+			},
+			UpdateFunc: func(_, newObj interface{}) {
+				ConfigCtrl.reconcile(newObj.(*unstructured.Unstructured))
+			},
+			DeleteFunc: func(obj interface{}) {
+				ConfigCtrl.restoreBaseline()
+			},
+		},
+	)
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		informer.Run(ConfigCtrl.stopChan)
+	}()
+
+	log.Printf("[ConfigController] Started Config Controller (%s %s/%s)", ConfigResourceGVR.GroupResource(), namespace, name)
+
+	return true
+}
+
+// StopConfigController Function
+func StopConfigController() bool {
+	close(ConfigCtrl.stopChan)
+
+	log.Print("[ConfigController] Stopped Config Controller")
+
+	return true
+}
+
+// == //
+
+// reconcile Function that applies the spec of a SentryFlowConfig resource and reports the result in its status
+func (cc *ConfigController) reconcile(obj *unstructured.Unstructured) {
+	cc.appliedLock.Lock()
+	defer cc.appliedLock.Unlock()
+
+	// Status updates do not change the generation
+	if obj.GetUID() == cc.appliedUID && obj.GetGeneration() == cc.appliedGeneration {
+		return
+	}
+
+	phase, message := ConfigPhaseAccepted, "Applied the configuration"
+
+	if err := cc.applySpec(obj); err != nil {
+		phase, message = ConfigPhaseRejected, err.Error()
+		log.Printf("[ConfigController] Rejected %s/%s (generation %d): %v", obj.GetNamespace(), obj.GetName(), obj.GetGeneration(), err)
+	} else {
+		log.Printf("[ConfigController] Applied %s/%s (generation %d)", obj.GetNamespace(), obj.GetName(), obj.GetGeneration())
+	}
+
+	cc.appliedUID = obj.GetUID()
+	cc.appliedGeneration = obj.GetGeneration()
+
+	if err := cc.updateStatus(obj, phase, message); err != nil {
+		log.Printf("[ConfigController] Failed to update the status of %s/%s: %v", obj.GetNamespace(), obj.GetName(), err)
+	}
+}
+
+// applySpec Function
+func (cc *ConfigController) applySpec(obj *unstructured.Unstructured) error {
+	spec, _, err := unstructured.NestedMap(obj.Object, "spec")
+	if err != nil {
+		return fmt.Errorf("invalid spec: %v", err)
+	}
+
+	// Sections missing in the spec keep their values from the baseline
+	sfCfg, err := config.ParseConfigSpec(cc.baseline, spec)
+	if err != nil {
+		return err
+	}
+
+	return cc.apply(sfCfg)
+}
+
+// updateStatus Function
+func (cc *ConfigController) updateStatus(obj *unstructured.Unstructured, phase, message string) error {
+	status := map[string]interface{}{
+		"phase":              phase,
+		"message":            message,
+		"observedGeneration": obj.GetGeneration(),
+		"lastUpdateTime":     time.Now().UTC().Format(time.RFC3339),
+	}
+
+	updated := obj.DeepCopy()
+	if err := unstructured.SetNestedField(updated.Object, status, "status"); err != nil {
+		return err
+	}
+
+	_, err := K8sH.dynamicClient.Resource(ConfigResourceGVR).Namespace(obj.GetNamespace()).UpdateStatus(context.TODO(), updated, v1.UpdateOptions{})
+	if apierrors.IsNotFound(err) {
+		return nil
+	}
+
+	return err
+}
+
+// restoreBaseline Function that goes back to the config from flags and the config file
+func (cc *ConfigController) restoreBaseline() {
+	cc.appliedLock.Lock()
+	defer cc.appliedLock.Unlock()
+
+	cc.appliedUID = ""
+	cc.appliedGeneration = 0
+
+	if err := cc.apply(cc.baseline); err != nil {
+		log.Printf("[ConfigController] Failed to restore the configuration: %v", err)
+		return
+	}
+
+	log.Printf("[ConfigController] Restored the configuration (%s/%s is deleted)", cc.namespace, cc.name)
+}
+
+// == //
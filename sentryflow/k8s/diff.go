@@ -0,0 +1,147 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package k8s
+
+import (
+	"fmt"
+	"strings"
+)
+
+// == //
+
+// diffContext is the number of unchanged lines around the changes in a hunk
+const diffContext = 3
+
+// diffLine Structure
+type diffLine struct {
+	op   byte // ' ', '-' or '+'
+	text string
+}
+
+// UnifiedDiff Function that gives the line differences between two documents in the unified format (empty if none)
+func UnifiedDiff(before, after, beforeName, afterName string) string {
+	lines := diffLines(splitLines(before), splitLines(after))
+
+	var sb strings.Builder
+
+	for start := 0; start < len(lines); {
+		// skip unchanged lines up to the context of the next change
+		change := start
+		for change < len(lines) && lines[change].op == ' ' {
+			change++
+		}
+		if change == len(lines) {
+			break
+		}
+
+		// extend the hunk while the unchanged lines between changes do not exceed twice the context
+		hunkStart := max(start, change-diffContext)
+		hunkEnd := change
+		for idx := change; idx < len(lines); idx++ {
+			if lines[idx].op != ' ' {
+				hunkEnd = idx + 1
+			} else if idx-hunkEnd >= 2*diffContext {
+				break
+			}
+		}
+		hunkEnd = min(len(lines), hunkEnd+diffContext)
+
+		if sb.Len() == 0 {
+			fmt.Fprintf(&sb, "--- %s\n+++ %s\n", beforeName, afterName)
+		}
+		writeHunk(&sb, lines, hunkStart, hunkEnd)
+
+		start = hunkEnd
+	}
+
+	return sb.String()
+}
+
+// diffLines Function that gives the lines of two documents marked as unchanged, removed or added
+func diffLines(a, b []string) []diffLine {
+	// longest common subsequence of lines
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else if lcs[i+1][j] >= lcs[i][j+1] {
+				lcs[i][j] = lcs[i+1][j]
+			} else {
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+
+	lines := make([]diffLine, 0, len(a)+len(b))
+
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		switch {
+		case i < len(a) && j < len(b) && a[i] == b[j]:
+			lines = append(lines, diffLine{op: ' ', text: a[i]})
+			i++
+			j++
+		case i < len(a) && (j == len(b) || lcs[i+1][j] >= lcs[i][j+1]):
+			lines = append(lines, diffLine{op: '-', text: a[i]})
+			i++
+		default:
+			lines = append(lines, diffLine{op: '+', text: b[j]})
+			j++
+		}
+	}
+
+	return lines
+}
+
+// writeHunk Function that writes the lines from start to end with a header of their ranges (e.g., @@ -3,7 +3,8 @@)
+func writeHunk(sb *strings.Builder, lines []diffLine, start, end int) {
+	// line numbers of the first line of the hunk in both documents
+	aStart, bStart := 1, 1
+	for _, line := range lines[:start] {
+		if line.op != '+' {
+			aStart++
+		}
+		if line.op != '-' {
+			bStart++
+		}
+	}
+
+	aLen, bLen := 0, 0
+	for _, line := range lines[start:end] {
+		if line.op != '+' {
+			aLen++
+		}
+		if line.op != '-' {
+			bLen++
+		}
+	}
+
+	// an empty range starts at the line before it
+	if aLen == 0 {
+		aStart--
+	}
+	if bLen == 0 {
+		bStart--
+	}
+
+	fmt.Fprintf(sb, "@@ -%d,%d +%d,%d @@\n", aStart, aLen, bStart, bLen)
+	for _, line := range lines[start:end] {
+		sb.WriteByte(line.op)
+		sb.WriteString(line.text + "\n")
+	}
+}
+
+// splitLines Function
+func splitLines(doc string) []string {
+	doc = strings.TrimSuffix(doc, "\n")
+	if doc == "" {
+		return []string{}
+	}
+	return strings.Split(doc, "\n")
+}
+
+// == //
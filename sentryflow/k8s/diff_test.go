@@ -0,0 +1,63 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package k8s
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// numberedLines Function that gives a document of lines from "1" to "count"
+func numberedLines(count int) []string {
+	lines := make([]string, 0, count)
+	for i := 1; i <= count; i++ {
+		lines = append(lines, fmt.Sprint(i))
+	}
+	return lines
+}
+
+// TestUnifiedDiff checks the hunks of unified diffs
+func TestUnifiedDiff(t *testing.T) {
+	lines := numberedLines(20)
+
+	changed := append([]string{}, lines...)
+	changed[1] = "two"
+	changed[16] = "seventeen"
+
+	nearby := append([]string{}, lines...)
+	nearby[1] = "two"
+	nearby[8] = "nine"
+
+	tests := []struct {
+		name          string
+		before, after string
+		expected      string
+	}{
+		{"same", "a\nb\n", "a\nb\n", ""},
+		{"new", "", "a\nb\n", "--- before\n+++ after\n@@ -0,0 +1,2 @@\n+a\n+b\n"},
+		{"deleted", "a\nb\n", "", "--- before\n+++ after\n@@ -1,2 +0,0 @@\n-a\n-b\n"},
+		{
+			"separate hunks",
+			strings.Join(lines, "\n"), strings.Join(changed, "\n"),
+			"--- before\n+++ after\n" +
+				"@@ -1,5 +1,5 @@\n 1\n-2\n+two\n 3\n 4\n 5\n" +
+				"@@ -14,7 +14,7 @@\n 14\n 15\n 16\n-17\n+seventeen\n 18\n 19\n 20\n",
+		},
+		{
+			"merged hunk",
+			strings.Join(lines, "\n"), strings.Join(nearby, "\n"),
+			"--- before\n+++ after\n" +
+				"@@ -1,12 +1,12 @@\n 1\n-2\n+two\n 3\n 4\n 5\n 6\n 7\n 8\n-9\n+nine\n 10\n 11\n 12\n",
+		},
+		{"appended", "a\nb\nc\nd\ne\n", "a\nb\nc\nd\ne\nf\n", "--- before\n+++ after\n@@ -3,3 +3,4 @@\n c\n d\n e\n+f\n"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if diff := UnifiedDiff(tc.before, tc.after, "before", "after"); diff != tc.expected {
+				t.Errorf("unexpected diff:\n%s\nexpected:\n%s", diff, tc.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,173 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package k8s
+
+import (
+	"context"
+	"log"
+	"sort"
+	"sync"
+
+	"github.com/5gsec/SentryFlow/types"
+
+	corev1 "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+)
+
+// == //
+
+// Ways pods are reachable from outside of the cluster
+const (
+	ExposureGateway = "gateway" // an ingress gateway (its callers are outside of the cluster)
+	ExposureService = "service" // behind a LoadBalancer or NodePort service
+	ExposureIngress = "ingress" // behind a backend of an Ingress resource
+)
+
+// Labels of ingress gateways
+const (
+	istioGatewayLabel = "istio"
+	istioGatewayValue = "ingressgateway" // Istio ingress gateways installed by istioctl or Helm
+)
+
+// Exposure Structure (a pod reachable from outside of the cluster)
+type Exposure struct {
+	Kind string // ExposureGateway, ExposureService or ExposureIngress
+	Via  string // the gateway, service or Ingress resource (namespace/name)
+	Pod  types.K8sResource
+}
+
+// ingressWarning reports failures of listing Ingress resources once (e.g., without the RBAC for them)
+var ingressWarning sync.Once
+
+// == //
+
+// isIngressGateway Function that checks if the labels are the ones of an ingress gateway (Istio or the Gateway API, other than waypoint proxies)
+func isIngressGateway(labels map[string]string) bool {
+	if labels[istioGatewayLabel] == istioGatewayValue {
+		return true
+	}
+	return labels[waypointGatewayLabel] != "" && !isWaypoint(labels)
+}
+
+// ExposedPods Function that gives the pods reachable from outside of the cluster, derived from gateways, services and Ingress resources
+func ExposedPods() []Exposure {
+	services := make(map[string]string) // namespace/service -> exposure kind
+	via := make(map[string]string)      // namespace/service -> LoadBalancer/NodePort service or Ingress resource
+
+	for key, ingress := range ingressBackends() {
+		services[key] = ExposureIngress
+		via[key] = ingress
+	}
+
+	return K8sH.index.exposedPods(services, via)
+}
+
+// ingressBackends Function that gives the services used as backends of Ingress resources in scope (namespace/service -> namespace/ingress)
+func ingressBackends() map[string]string {
+	backends := make(map[string]string)
+
+	if K8sH.clientSet == nil {
+		return backends
+	}
+
+	sc := K8sH.currentScope()
+
+	for _, namespace := range sc.watchNamespaces() {
+		ingresses, err := K8sH.clientSet.NetworkingV1().Ingresses(namespace).List(context.TODO(), v1.ListOptions{})
+		if err != nil {
+			ingressWarning.Do(func() {
+				log.Printf("[K8s] Unable to list Ingress resources, exposure is derived from gateways and services only: %v", err)
+			})
+			return backends
+		}
+
+		for _, ingress := range ingresses.Items {
+			if !sc.inScope(ingress.Namespace) {
+				continue
+			}
+
+			name := ingress.Namespace + "/" + ingress.Name
+
+			if backend := ingress.Spec.DefaultBackend; backend != nil && backend.Service != nil {
+				backends[ingress.Namespace+"/"+backend.Service.Name] = name
+			}
+			for _, rule := range ingress.Spec.Rules {
+				if rule.HTTP == nil {
+					continue
+				}
+				for _, path := range rule.HTTP.Paths {
+					if path.Backend.Service != nil {
+						backends[ingress.Namespace+"/"+path.Backend.Service.Name] = name
+					}
+				}
+			}
+		}
+	}
+
+	return backends
+}
+
+// exposedPods Function that gives ingress gateways and the pods behind exposed services
+func (ri *ResourceIndex) exposedPods(services, via map[string]string) []Exposure {
+	ri.indexLock.RLock()
+	defer ri.indexLock.RUnlock()
+
+	// Services reachable without an ingress
+	seen := make(map[k8stypes.UID]bool)
+	for _, service := range ri.services {
+		if seen[service.UID] {
+			continue
+		}
+		seen[service.UID] = true
+
+		if service.Spec.Type == corev1.ServiceTypeLoadBalancer || service.Spec.Type == corev1.ServiceTypeNodePort {
+			key := service.Namespace + "/" + service.Name
+			services[key] = ExposureService
+			via[key] = key + " (" + string(service.Spec.Type) + ")"
+		}
+	}
+
+	exposures := make([]Exposure, 0)
+	exposed := make(map[k8stypes.UID]bool)
+
+	for _, pod := range ri.pods {
+		if exposed[pod.UID] || !isIngressGateway(pod.Labels) {
+			continue
+		}
+		exposed[pod.UID] = true
+
+		resource := ri.resourceOf(pod, nil)
+		exposures = append(exposures, Exposure{Kind: ExposureGateway, Via: pod.Namespace + "/" + resource.WorkloadName, Pod: resource})
+	}
+
+	for ip, refs := range ri.endpoints {
+		pod, ok := ri.pods[ip]
+		if !ok || exposed[pod.UID] {
+			continue
+		}
+
+		// A service reachable without an ingress wins over Ingress resources
+		best := ""
+		for _, ref := range refs {
+			key := ref.namespace + "/" + ref.service
+			if kind, ok := services[key]; ok && (best == "" || kind == ExposureService) {
+				best = key
+			}
+		}
+		if best == "" {
+			continue
+		}
+
+		exposed[pod.UID] = true
+		exposures = append(exposures, Exposure{Kind: services[best], Via: via[best], Pod: ri.resourceOf(pod, nil)})
+	}
+
+	sort.Slice(exposures, func(i, j int) bool {
+		return exposures[i].Pod.Namespace+"/"+exposures[i].Pod.Name < exposures[j].Pod.Namespace+"/"+exposures[j].Pod.Name
+	})
+
+	return exposures
+}
+
+// == //
@@ -3,244 +3,605 @@
 package k8s
 
 import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
+	"fmt"
 	"log"
+	"net"
+	"reflect"
+	"strconv"
+	"strings"
 
-	"gopkg.in/yaml.v2"
-	"k8s.io/apimachinery/pkg/util/json"
+	"github.com/5gsec/SentryFlow/config"
+
+	"gopkg.in/yaml.v3"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// == //
+
+// Modes of enabling access logs in Istio
+const (
+	IstioPatchModeConfigMap = "configMap" // rewrite the mesh config (default providers and envoyAccessLogService)
+	IstioPatchModeTelemetry = "telemetry" // register an extension provider and create Telemetry resources
+)
+
+// Annotations of the Istio ConfigMap for restoring the mesh config (even after SentryFlow was killed)
+const (
+	meshSnapshotAnnotation = "sentryflow.io/mesh-snapshot" // mesh config before SentryFlow patched it
+	meshPatchedAnnotation  = "sentryflow.io/mesh-patched"  // checksum of the mesh config written by SentryFlow
+)
+
+// Attributes added to OpenTelemetry access logs for the identities (SPIFFE IDs) of clients and servers
+// In the ambient mode, waypoint proxies stand between them, so their addresses alone do not tell the real ends
+const (
+	SourcePrincipalAttribute      = "source_principal"
+	DestinationPrincipalAttribute = "destination_principal"
+)
+
+// Fields of the access logs sent to SentryFlow (in the order of AccessLogFormat)
+const (
+	AccessLogStartTime   = iota
+	AccessLogRequestLine // method, path and protocol
+	AccessLogResponseCode
+	AccessLogResponseFlags // e.g., UF,URX
+	AccessLogResponseDetails
+	AccessLogTerminationDetails
+	AccessLogTransportFailure
+	AccessLogBytesReceived
+	AccessLogBytesSent
+	AccessLogDuration // in milliseconds
+	AccessLogUpstreamServiceTime
+	AccessLogForwardedFor
+	AccessLogUserAgent
+	AccessLogRequestID
+	AccessLogAuthority
+	AccessLogUpstreamHost
+	AccessLogUpstreamCluster
+	AccessLogUpstreamLocalAddress
+	AccessLogLocalAddress  // downstream local address
+	AccessLogRemoteAddress // downstream remote address
+	AccessLogServerName    // SNI
+	AccessLogRouteName
+	numAccessLogFields
+)
+
+// AccessLogFormat gives the Envoy command operators of the fields (the same as the default format of Istio)
+// Fields are separated by spaces, and ones that may have spaces are quoted or bracketed
+var AccessLogFormat = [numAccessLogFields]string{
+	AccessLogStartTime:            "[%START_TIME%]",
+	AccessLogRequestLine:          `"%REQ(:METHOD)% %REQ(X-ENVOY-ORIGINAL-PATH?:PATH)% %PROTOCOL%"`,
+	AccessLogResponseCode:         "%RESPONSE_CODE%",
+	AccessLogResponseFlags:        "%RESPONSE_FLAGS%",
+	AccessLogResponseDetails:      "%RESPONSE_CODE_DETAILS%",
+	AccessLogTerminationDetails:   "%CONNECTION_TERMINATION_DETAILS%",
+	AccessLogTransportFailure:     `"%UPSTREAM_TRANSPORT_FAILURE_REASON%"`,
+	AccessLogBytesReceived:        "%BYTES_RECEIVED%",
+	AccessLogBytesSent:            "%BYTES_SENT%",
+	AccessLogDuration:             "%DURATION%",
+	AccessLogUpstreamServiceTime:  "%RESP(X-ENVOY-UPSTREAM-SERVICE-TIME)%",
+	AccessLogForwardedFor:         `"%REQ(X-FORWARDED-FOR)%"`,
+	AccessLogUserAgent:            `"%REQ(USER-AGENT)%"`,
+	AccessLogRequestID:            `"%REQ(X-REQUEST-ID)%"`,
+	AccessLogAuthority:            `"%REQ(:AUTHORITY)%"`,
+	AccessLogUpstreamHost:         `"%UPSTREAM_HOST%"`,
+	AccessLogUpstreamCluster:      "%UPSTREAM_CLUSTER%",
+	AccessLogUpstreamLocalAddress: "%UPSTREAM_LOCAL_ADDRESS%",
+	AccessLogLocalAddress:         "%DOWNSTREAM_LOCAL_ADDRESS%",
+	AccessLogRemoteAddress:        "%DOWNSTREAM_REMOTE_ADDRESS%",
+	AccessLogServerName:           "%REQUESTED_SERVER_NAME%",
+	AccessLogRouteName:            "%ROUTE_NAME%",
+}
+
+// accessLogText Function that gives the text of the access log format
+func accessLogText() string {
+	return strings.Join(AccessLogFormat[:], " ") + "\n"
+}
+
+// otelLogFormat structure
+type otelLogFormat struct {
+	Text   string            `yaml:"text,omitempty"`
+	Labels map[string]string `yaml:"labels,omitempty"`
+}
+
+// otelAlsProvider structure
+type otelAlsProvider struct {
+	Service   string         `yaml:"service"`
+	Port      int            `yaml:"port"`
+	LogFormat *otelLogFormat `yaml:"logFormat,omitempty"`
+}
+
+// extensionProvider structure
+type extensionProvider struct {
+	Name         string          `yaml:"name"`
+	EnvoyOtelAls otelAlsProvider `yaml:"envoyOtelAls"`
+}
+
+const (
+	istioConfigMap = "istio"
+	meshKey        = "mesh"
+
+	sentryFlowProvider = "sentryflow"
 )
 
-// meshConfig structure
-type meshConfig struct {
-	DefaultConfig struct {
-		DiscoveryAddress      string `yaml:"discoveryAddress"`
-		EnvoyAccessLogService struct {
-			Address string `yaml:"address"`
-		} `yaml:"envoyAccessLogService"`
-		EnvoyMetricsService struct {
-			Address string `yaml:"address"`
-		} `yaml:"envoyMetricsService"`
-	} `yaml:"defaultConfig"`
+// PatchIstio Function that makes Istio proxies send access logs to SentryFlow (in the configured mode)
+func PatchIstio() bool {
+	switch config.GlobalConfig.IstioPatchMode {
+	case IstioPatchModeConfigMap:
+		return PatchIstioConfigMap()
+	case IstioPatchModeTelemetry:
+		return PatchIstioTelemetry()
+	}
+
+	log.Printf("[PatchIstio] Unknown mode %q (expected %s or %s)", config.GlobalConfig.IstioPatchMode, IstioPatchModeConfigMap, IstioPatchModeTelemetry)
 
-	DefaultProviders struct {
-		AccessLogs []string `yaml:"accessLogs"`
-		Metrics    []string `yaml:"metrics"`
-	} `yaml:"defaultProviders"`
+	return false
+}
 
-	EnableEnvoyAccessLogService bool `yaml:"enableEnvoyAccessLogService"`
+// UnpatchIstio Function
+func UnpatchIstio() bool {
+	if config.GlobalConfig.IstioPatchDryRun || K8sH.clientSet == nil {
+		return true // nothing was patched (e.g., SentryFlow failed to connect to Kubernetes)
+	}
 
-	ExtensionProviders []struct {
-		EnvoyOtelAls struct {
-			Port    string `yaml:"port"`
-			Service string `yaml:"service"`
-		} `yaml:"envoyOtelAls"`
-		Name string `yaml:"name"`
-	} `yaml:"extensionProviders"`
+	if config.GlobalConfig.IstioPatchMode == IstioPatchModeTelemetry {
+		return UnpatchIstioTelemetry()
+	}
 
-	ExtraFields map[string]interface{} `yaml:",inline"` // all extra fields that SentryFlow will not touch
+	return UnpatchIstioConfigMap()
 }
 
+// RestoreIstio Function that removes the settings of SentryFlow from Istio (e.g., after SentryFlow was killed)
+// The mesh config is restored from its snapshot, and Telemetry resources created by SentryFlow are deleted
+// With dryRun, nothing is changed and the changes are only returned
+func RestoreIstio(dryRun bool) ([]string, error) {
+	changes := make([]string, 0)
+
+	diff, err := unpatchIstioMesh(dryRun)
+	if err != nil {
+		return changes, err
+	}
+	if diff != "" {
+		changes = append(changes, diff)
+	}
+
+	deleted, err := deleteTelemetry(func(namespace, name string) bool { return false }, dryRun)
+	if err != nil {
+		return changes, err
+	}
+	for _, name := range deleted {
+		changes = append(changes, fmt.Sprintf("delete Telemetry %s\n", name))
+	}
+
+	return changes, nil
+}
+
+// == //
+
 // PatchIstioConfigMap Function
 func PatchIstioConfigMap() bool {
 	log.Print("[PatchIstioConfigMap] Patching Istio ConfigMap")
 
-	meshCfg, err := parseIstioConfigMap()
-	if err != nil {
-		log.Printf("[PatchIstioConfigMap] Unable to parse Istio ConfigMap: %v", err)
+	if err := patchIstioMesh(true, config.GlobalConfig.IstioPatchDryRun); err != nil {
+		log.Printf("[PatchIstioConfigMap] Unable to patch Istio ConfigMap: %v", err)
 		return false
 	}
 
-	if isIstioAlreadyPatched(meshCfg) {
-		log.Print("[PatchIstioConfigMap] Istio ConfigMap was already patched before, skipping...")
-		return true
+	return true
+}
+
+// UnpatchIstioConfigMap Function
+func UnpatchIstioConfigMap() bool {
+	log.Print("[PatchIstioConfigMap] Unpatching Istio ConfigMap")
+
+	if _, err := unpatchIstioMesh(false); err != nil {
+		log.Printf("[PatchIstioConfigMap] Unable to unpatch Istio ConfigMap: %v", err)
+		return false
 	}
 
-	// set metrics and envoy access logging to Sentryflow
-	meshCfg.DefaultConfig.EnvoyAccessLogService.Address = "sentryflow.sentryflow.svc.cluster.local:4317"
-	meshCfg.DefaultConfig.EnvoyMetricsService.Address = "sentryflow.sentryflow.svc.cluster.local:4317"
-
-	// add Sentryflow as Otel AL collector
-	if patched, _ := isEnvoyOtelAlPatched(meshCfg); !patched {
-		sfOtelAl := struct {
-			EnvoyOtelAls struct {
-				Port    string `yaml:"port"`
-				Service string `yaml:"service"`
-			} `yaml:"envoyOtelAls"`
-			Name string `yaml:"name"`
-		}{
-			EnvoyOtelAls: struct {
-				Port    string `yaml:"port"`
-				Service string `yaml:"service"`
-			}{
-				Port:    "4317",
-				Service: "sentryflow.sentryflow.svc.cluster.local",
-			},
-			Name: "sentryflow",
+	return true
+}
+
+// patchIstioMesh Function that adds SentryFlow to the mesh config, keeping a snapshot of the original one
+// With defaults, SentryFlow also becomes a default access log provider (configMap mode)
+func patchIstioMesh(defaults bool, dryRun bool) error {
+	cm, err := K8sH.clientSet.CoreV1().ConfigMaps(istioRootNamespace).Get(context.TODO(), istioConfigMap, v1.GetOptions{})
+	if err != nil {
+		return err
+	}
+
+	current, ok := cm.Data[meshKey]
+	if !ok {
+		return errors.New("unable to find field \"mesh\" from Istio config")
+	}
+
+	doc, err := parseMesh(current)
+	if err != nil {
+		return err
+	}
+
+	if err := patchMesh(doc.Content[0], defaults); err != nil {
+		return err
+	}
+
+	patched, err := encodeMesh(doc)
+	if err != nil {
+		return err
+	}
+
+	if sameMesh(current, patched) {
+		log.Print("[PatchIstio] Istio mesh config was already patched before, skipping...")
+		return nil
+	}
+
+	if dryRun {
+		log.Printf("[PatchIstio] Dry run, Istio mesh config is not changed:\n%s", meshDiff(current, patched))
+		return nil
+	}
+
+	if cm.Annotations == nil {
+		cm.Annotations = make(map[string]string)
+	}
+
+	// Keep the first snapshot if the mesh config is still the one written by SentryFlow (e.g., after a crash)
+	// Otherwise, the current one may still have settings of SentryFlow (e.g., edited by someone else after a crash), so they are left out
+	if _, ok := cm.Annotations[meshSnapshotAnnotation]; !ok || cm.Annotations[meshPatchedAnnotation] != checksum(current) {
+		snapshot, err := unpatchedMesh(current)
+		if err != nil {
+			return err
 		}
-		meshCfg.ExtensionProviders = append(meshCfg.ExtensionProviders, sfOtelAl)
+		cm.Annotations[meshSnapshotAnnotation] = snapshot
 	}
+	cm.Annotations[meshPatchedAnnotation] = checksum(patched)
 
-	// add default access log provider
-	if patched, _ := isEnvoyALProviderPatched(meshCfg); !patched {
-		meshCfg.DefaultProviders.AccessLogs = append(meshCfg.DefaultProviders.AccessLogs, "sentryflow")
+	cm.Data[meshKey] = patched
+	if _, err := K8sH.clientSet.CoreV1().ConfigMaps(istioRootNamespace).Update(context.TODO(), cm, v1.UpdateOptions{}); err != nil {
+		return err
 	}
 
-	meshCfg.EnableEnvoyAccessLogService = true
+	log.Print("[PatchIstio] Successfully patched Istio mesh config")
 
-	yamlMeshCfg, err := yaml.Marshal(meshCfg)
+	return nil
+}
+
+// unpatchIstioMesh Function that restores the mesh config (and gives the diff of the change)
+// The snapshot is restored as is unless the mesh config was changed by someone else after SentryFlow patched it,
+// in which case only the settings of SentryFlow are removed
+func unpatchIstioMesh(dryRun bool) (string, error) {
+	cm, err := K8sH.clientSet.CoreV1().ConfigMaps(istioRootNamespace).Get(context.TODO(), istioConfigMap, v1.GetOptions{})
 	if err != nil {
-		log.Printf("[PatchIstioConfigMap] Unable to unmarshall Istio ConfigMap: %v", err)
-		return false
+		return "", err
+	}
+
+	current, ok := cm.Data[meshKey]
+	if !ok {
+		return "", errors.New("unable to find field \"mesh\" from Istio config")
 	}
 
-	strMeshCfg := string(yamlMeshCfg[:])
-	err = K8sH.updateConfigMap("istio-system", "istio", strMeshCfg)
+	snapshot, hasSnapshot := cm.Annotations[meshSnapshotAnnotation]
+
+	restored := snapshot
+	if !hasSnapshot || cm.Annotations[meshPatchedAnnotation] != checksum(current) {
+		if hasSnapshot {
+			log.Print("[PatchIstio] Istio mesh config was changed after SentryFlow patched it, removing SentryFlow settings only")
+		}
+
+		if restored, err = unpatchedMesh(current); err != nil {
+			return "", err
+		}
+	}
+
+	diff := ""
+	if !sameMesh(current, restored) {
+		diff = meshDiff(current, restored)
+	} else if !hasSnapshot {
+		return "", nil
+	}
+
+	if dryRun {
+		return diff, nil
+	}
+
+	delete(cm.Annotations, meshSnapshotAnnotation)
+	delete(cm.Annotations, meshPatchedAnnotation)
+
+	cm.Data[meshKey] = restored
+	if _, err := K8sH.clientSet.CoreV1().ConfigMaps(istioRootNamespace).Update(context.TODO(), cm, v1.UpdateOptions{}); err != nil {
+		return "", err
+	}
+
+	log.Print("[PatchIstio] Successfully restored Istio mesh config")
+
+	return diff, nil
+}
+
+// == //
+
+// collectorAddress Function that gives the host and the port of the collector for Istio proxies
+func collectorAddress() (string, int, error) {
+	host, port, err := net.SplitHostPort(config.GlobalConfig.CollectorService)
 	if err != nil {
-		log.Printf("[PatchIstioConfigMap] Unable to update Istio ConfigMap: %v", err)
-		return false
+		return "", 0, err
 	}
 
-	log.Print("[PatchIstioConfigMap] Successfully patched Istio ConfigMap")
+	portNum, err := strconv.Atoi(port)
+	if err != nil {
+		return "", 0, err
+	}
 
-	return true
+	return host, portNum, nil
 }
 
-// UnpatchIstioConfigMap Function
-func UnpatchIstioConfigMap() bool {
-	log.Print("[PatchIstioConfigMap] Unpatching Istio ConfigMap")
+// patchMesh Function that adds SentryFlow to the mesh config, leaving everything else (order, comments) as is
+func patchMesh(mesh *yaml.Node, defaults bool) error {
+	host, port, err := collectorAddress()
+	if err != nil {
+		return fmt.Errorf("invalid collector service %q: %v", config.GlobalConfig.CollectorService, err)
+	}
+
+	// add (or replace) Sentryflow as Otel AL collector
+	provider := &yaml.Node{}
+	logFormat := &otelLogFormat{Text: accessLogText(), Labels: map[string]string{
+		SourcePrincipalAttribute:      "%DOWNSTREAM_PEER_URI_SAN%",
+		DestinationPrincipalAttribute: "%UPSTREAM_PEER_URI_SAN%",
+	}}
+	if err := provider.Encode(extensionProvider{Name: sentryFlowProvider, EnvoyOtelAls: otelAlsProvider{Service: host, Port: port, LogFormat: logFormat}}); err != nil {
+		return err
+	}
+
+	providers := childNode(mesh, "extensionProviders", yaml.SequenceNode)
+	removeItems(providers, func(item *yaml.Node) bool { return scalarValue(item, "name") == sentryFlowProvider })
+	providers.Content = append(providers.Content, provider)
+
+	if !defaults {
+		return nil
+	}
+
+	// set metrics and envoy access logging to Sentryflow
+	defaultConfig := childNode(mesh, "defaultConfig", yaml.MappingNode)
+	for _, service := range []string{"envoyAccessLogService", "envoyMetricsService"} {
+		setScalar(childNode(defaultConfig, service, yaml.MappingNode), "address", "!!str", config.GlobalConfig.CollectorService)
+	}
 
-	meshCfg, err := parseIstioConfigMap()
+	// add default access log provider
+	accessLogs := childNode(childNode(mesh, "defaultProviders", yaml.MappingNode), "accessLogs", yaml.SequenceNode)
+	found := false
+	for _, item := range accessLogs.Content {
+		if item.Value == sentryFlowProvider {
+			found = true
+		}
+	}
+	if !found {
+		accessLogs.Content = append(accessLogs.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: sentryFlowProvider})
+	}
+
+	setScalar(mesh, "enableEnvoyAccessLogService", "!!bool", "true")
+
+	return nil
+}
+
+// unpatchedMesh Function that gives a mesh config without the settings of SentryFlow
+func unpatchedMesh(mesh string) (string, error) {
+	doc, err := parseMesh(mesh)
 	if err != nil {
-		log.Printf("[PatchIstioConfigMap] Unable to parse Istio ConfigMap: %v", err)
-		return false
+		return "", err
+	}
+
+	unpatchMesh(doc.Content[0])
+
+	unpatched, err := encodeMesh(doc)
+	if err != nil {
+		return "", err
+	}
+
+	// Keep the mesh config as it is written if nothing was removed
+	if sameMesh(mesh, unpatched) {
+		return mesh, nil
+	}
+
+	return unpatched, nil
+}
+
+// unpatchMesh Function that removes SentryFlow from the mesh config
+func unpatchMesh(mesh *yaml.Node) {
+	addresses := map[string]bool{config.GlobalConfig.CollectorService: true}
+
+	// remove EnvoyOtelAl (and remember its address, SentryFlow may have been running with another one)
+	if providers := lookupNode(mesh, "extensionProviders"); providers != nil && providers.Kind == yaml.SequenceNode {
+		removeItems(providers, func(item *yaml.Node) bool {
+			if scalarValue(item, "name") != sentryFlowProvider {
+				return false
+			}
+			if otelAls := lookupNode(item, "envoyOtelAls"); otelAls != nil {
+				addresses[net.JoinHostPort(scalarValue(otelAls, "service"), scalarValue(otelAls, "port"))] = true
+			}
+			return true
+		})
+		if len(providers.Content) == 0 {
+			removeKey(mesh, "extensionProviders")
+		}
 	}
 
 	// set metrics and envoy access logging back to empty value
-	meshCfg.DefaultConfig.EnvoyAccessLogService.Address = ""
-	meshCfg.DefaultConfig.EnvoyMetricsService.Address = ""
-
-	// remove EnvoyOtelAl
-	if patched, targetIdx := isEnvoyOtelAlPatched(meshCfg); patched {
-		tmp := make([]struct {
-			EnvoyOtelAls struct {
-				Port    string `yaml:"port"`
-				Service string `yaml:"service"`
-			} `yaml:"envoyOtelAls"`
-			Name string `yaml:"name"`
-		}, 0)
-		for idx, envoyOtelAl := range meshCfg.ExtensionProviders {
-			if idx != targetIdx {
-				tmp = append(tmp, envoyOtelAl)
+	if defaultConfig := lookupNode(mesh, "defaultConfig"); defaultConfig != nil {
+		for _, service := range []string{"envoyAccessLogService", "envoyMetricsService"} {
+			serviceNode := lookupNode(defaultConfig, service)
+			if serviceNode == nil || !addresses[scalarValue(serviceNode, "address")] {
+				continue
 			}
+
+			removeKey(serviceNode, "address")
+			if len(serviceNode.Content) == 0 {
+				removeKey(defaultConfig, service)
+			}
+		}
+		if len(defaultConfig.Content) == 0 {
+			removeKey(mesh, "defaultConfig")
 		}
-		meshCfg.ExtensionProviders = tmp
 	}
 
 	// remove default access log provider
-	if patched, targetIdx := isEnvoyALProviderPatched(meshCfg); patched {
-		tmp := make([]string, 0)
-		for idx, provider := range meshCfg.DefaultProviders.AccessLogs {
-			if idx != targetIdx {
-				tmp = append(tmp, provider)
+	if defaultProviders := lookupNode(mesh, "defaultProviders"); defaultProviders != nil {
+		if accessLogs := lookupNode(defaultProviders, "accessLogs"); accessLogs != nil && accessLogs.Kind == yaml.SequenceNode {
+			removeItems(accessLogs, func(item *yaml.Node) bool { return item.Value == sentryFlowProvider })
+			if len(accessLogs.Content) == 0 {
+				removeKey(defaultProviders, "accessLogs")
 			}
 		}
-		meshCfg.DefaultProviders.AccessLogs = tmp
+		if len(defaultProviders.Content) == 0 {
+			removeKey(mesh, "defaultProviders")
+		}
 	}
 
 	// @todo this might be incorrect, the user might have just set up envoy access log service manually before.
 	// @todo check if this shall actually be overwritten by SentryFlow
-	// meshCfg.EnableEnvoyAccessLogService = false
+	// enableEnvoyAccessLogService is left as is
+}
 
-	yamlMeshCfg, err := yaml.Marshal(meshCfg)
-	if err != nil {
-		log.Printf("[PatchIstioConfigMap] Unable to unmarshall Istio ConfigMap: %v", err)
-		return false
+// == //
+
+// parseMesh Function that parses the mesh config into a YAML document (with a mapping even if it is empty)
+func parseMesh(mesh string) (*yaml.Node, error) {
+	doc := &yaml.Node{}
+	if err := yaml.Unmarshal([]byte(mesh), doc); err != nil {
+		return nil, err
 	}
 
-	strMeshCfg := string(yamlMeshCfg[:])
-	err = K8sH.updateConfigMap("istio-system", "istio", strMeshCfg)
-	if err != nil {
-		log.Printf("[PatchIstioConfigMap] Unable to update Istio ConfigMap: %v", err)
-		return false
+	if doc.Kind == 0 {
+		doc = &yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{{Kind: yaml.MappingNode, Tag: "!!map"}}}
 	}
 
-	log.Print("[PatchIstioConfigMap] Successfully unpatched Istio ConfigMap")
+	if len(doc.Content) == 0 || doc.Content[0].Kind != yaml.MappingNode {
+		return nil, errors.New("mesh config is not a mapping")
+	}
 
-	return true
+	return doc, nil
 }
 
-// parseIstioConfigMap Function
-func parseIstioConfigMap() (meshConfig, error) {
-	var meshCfg meshConfig
+// encodeMesh Function
+func encodeMesh(doc *yaml.Node) (string, error) {
+	var buf bytes.Buffer
 
-	configMapData, err := K8sH.getConfigMap("istio-system", "istio")
-	if err != nil {
-		return meshCfg, err
-	}
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
 
-	// unmarshall JSON format of Istio config
-	var rawIstioCfg map[string]interface{}
-	err = json.Unmarshal([]byte(configMapData), &rawIstioCfg)
-	if err != nil {
-		return meshCfg, err
+	if err := encoder.Encode(doc); err != nil {
+		return "", err
+	}
+	if err := encoder.Close(); err != nil {
+		return "", err
 	}
 
-	// extract mesh field from configmap
-	meshData, ok := rawIstioCfg["mesh"].(string)
-	if !ok {
-		return meshCfg, errors.New("[PatchIstioConfigMap] Unable to find field \"mesh\" from Istio config")
+	return buf.String(), nil
+}
+
+// sameMesh Function that checks if two mesh configs have the same settings (regardless of formatting)
+func sameMesh(a, b string) bool {
+	var aValue, bValue map[string]interface{}
+	if yaml.Unmarshal([]byte(a), &aValue) != nil || yaml.Unmarshal([]byte(b), &bValue) != nil {
+		return a == b
 	}
 
-	// unmarshall YAML format of Istio config
-	err = yaml.Unmarshal([]byte(meshData), &meshCfg)
-	if err != nil {
-		return meshCfg, err
+	// an empty mesh config is the same as {}
+	if len(aValue) == 0 && len(bValue) == 0 {
+		return true
 	}
 
-	return meshCfg, nil
+	return reflect.DeepEqual(aValue, bValue)
+}
+
+// meshDiff Function
+func meshDiff(before, after string) string {
+	name := fmt.Sprintf("ConfigMap/%s/%s (%s)", istioRootNamespace, istioConfigMap, meshKey)
+	return UnifiedDiff(before, after, name, name)
 }
 
-// isEnvoyOtelAlPatched Function
-func isEnvoyOtelAlPatched(meshCfg meshConfig) (bool, int) {
-	for idx, envoyOtelAl := range meshCfg.ExtensionProviders {
-		if envoyOtelAl.Name == "sentryflow" &&
-			envoyOtelAl.EnvoyOtelAls.Port == "4317" &&
-			envoyOtelAl.EnvoyOtelAls.Service == "sentryflow.sentryflow.svc.cluster.local" {
-			return true, idx
+// checksum Function
+func checksum(data string) string {
+	sum := sha256.Sum256([]byte(data))
+	return hex.EncodeToString(sum[:])
+}
+
+// == //
+
+// lookupNode Function that gives the value of a key in a mapping (nil if it does not exist)
+func lookupNode(mapping *yaml.Node, key string) *yaml.Node {
+	if mapping.Kind != yaml.MappingNode {
+		return nil
+	}
+
+	for idx := 0; idx+1 < len(mapping.Content); idx += 2 {
+		if mapping.Content[idx].Value == key {
+			return mapping.Content[idx+1]
 		}
 	}
 
-	return false, -1
+	return nil
 }
 
-// isEnvoyALProviderPatched Function
-func isEnvoyALProviderPatched(meshCfg meshConfig) (bool, int) {
-	for idx, accessLogProvider := range meshCfg.DefaultProviders.AccessLogs {
-		if accessLogProvider == "sentryflow" {
-			return true, idx
+// childNode Function that gives the value of a key in a mapping (added if it does not exist or is null)
+func childNode(mapping *yaml.Node, key string, kind yaml.Kind) *yaml.Node {
+	tag := "!!map"
+	if kind == yaml.SequenceNode {
+		tag = "!!seq"
+	}
+
+	if child := lookupNode(mapping, key); child != nil {
+		if child.Kind != yaml.ScalarNode || child.Tag != "!!null" {
+			return child
 		}
+		*child = yaml.Node{Kind: kind, Tag: tag}
+		return child
 	}
-	return false, -1
+
+	child := &yaml.Node{Kind: kind, Tag: tag}
+	mapping.Content = append(mapping.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key}, child)
+
+	return child
 }
 
-// isIstioAlreadyPatched Function
-func isIstioAlreadyPatched(meshCfg meshConfig) bool {
-	if meshCfg.DefaultConfig.EnvoyAccessLogService.Address != "sentryflow.sentryflow.svc.cluster.local:4317" ||
-		meshCfg.DefaultConfig.EnvoyMetricsService.Address != "sentryflow.sentryflow.svc.cluster.local:4317" {
-		return false
+// setScalar Function
+func setScalar(mapping *yaml.Node, key, tag, value string) {
+	if child := lookupNode(mapping, key); child != nil {
+		*child = yaml.Node{Kind: yaml.ScalarNode, Tag: tag, Value: value, LineComment: child.LineComment}
+		return
 	}
 
-	if patched, _ := isEnvoyOtelAlPatched(meshCfg); !patched {
-		return false
-	}
+	mapping.Content = append(mapping.Content,
+		&yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key},
+		&yaml.Node{Kind: yaml.ScalarNode, Tag: tag, Value: value})
+}
 
-	if patched, _ := isEnvoyALProviderPatched(meshCfg); !patched {
-		return false
+// scalarValue Function that gives the value of a scalar in a mapping (empty if it does not exist)
+func scalarValue(mapping *yaml.Node, key string) string {
+	if child := lookupNode(mapping, key); child != nil && child.Kind == yaml.ScalarNode {
+		return child.Value
 	}
+	return ""
+}
 
-	if !meshCfg.EnableEnvoyAccessLogService {
-		return false
+// removeKey Function
+func removeKey(mapping *yaml.Node, key string) {
+	for idx := 0; idx+1 < len(mapping.Content); idx += 2 {
+		if mapping.Content[idx].Value == key {
+			mapping.Content = append(mapping.Content[:idx], mapping.Content[idx+2:]...)
+			return
+		}
 	}
+}
 
-	return true
+// removeItems Function that removes the items of a sequence for which the given function returns true
+func removeItems(sequence *yaml.Node, match func(item *yaml.Node) bool) {
+	kept := make([]*yaml.Node, 0, len(sequence.Content))
+	for _, item := range sequence.Content {
+		if !match(item) {
+			kept = append(kept, item)
+		}
+	}
+	sequence.Content = kept
 }
+
+// == //
@@ -0,0 +1,98 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package k8s
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/5gsec/SentryFlow/config"
+)
+
+// patchedMesh Function that gives a mesh config with the settings of SentryFlow (as patchIstioMesh writes it)
+func patchedMesh(t *testing.T, mesh string, defaults bool) string {
+	t.Helper()
+
+	doc, err := parseMesh(mesh)
+	if err != nil {
+		t.Fatalf("failed to parse the mesh config: %v", err)
+	}
+
+	if err := patchMesh(doc.Content[0], defaults); err != nil {
+		t.Fatalf("failed to patch the mesh config: %v", err)
+	}
+
+	patched, err := encodeMesh(doc)
+	if err != nil {
+		t.Fatalf("failed to encode the mesh config: %v", err)
+	}
+
+	return patched
+}
+
+// TestPatchUnpatchMesh checks that removing SentryFlow from a patched mesh config gives the original one back
+func TestPatchUnpatchMesh(t *testing.T) {
+	config.GlobalConfig.CollectorService = "sentryflow.sentryflow.svc.cluster.local:4317"
+
+	tests := []struct {
+		name     string
+		mesh     string
+		defaults bool
+	}{
+		{"empty (telemetry mode)", "", false},
+		{"other settings (telemetry mode)", "accessLogFile: /dev/stdout\ntrustDomain: cluster.local\n", false},
+		{
+			"other providers (telemetry mode)",
+			"extensionProviders:\n- name: otel\n  opentelemetry:\n    service: otel.observability.svc.cluster.local\n    port: 4317\n",
+			false,
+		},
+		{"already enabled (configMap mode)", "enableEnvoyAccessLogService: true\n", true},
+		{
+			"other defaults (configMap mode)",
+			"enableEnvoyAccessLogService: true\n" +
+				"defaultConfig:\n  discoveryAddress: istiod.istio-system.svc:15012\n" +
+				"defaultProviders:\n  accessLogs:\n  - envoy\n",
+			true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			patched := patchedMesh(t, tc.mesh, tc.defaults)
+			if sameMesh(tc.mesh, patched) {
+				t.Fatalf("mesh config was not patched:\n%s", patched)
+			}
+			if !strings.Contains(patched, "%DOWNSTREAM_REMOTE_ADDRESS%") {
+				t.Errorf("access log format is missing in the patched mesh config:\n%s", patched)
+			}
+
+			// patching again changes nothing
+			if again := patchedMesh(t, patched, tc.defaults); !sameMesh(patched, again) {
+				t.Errorf("mesh config changed when patched again:\n%s", meshDiff(patched, again))
+			}
+
+			unpatched, err := unpatchedMesh(patched)
+			if err != nil {
+				t.Fatalf("failed to unpatch the mesh config: %v", err)
+			}
+			if !sameMesh(tc.mesh, unpatched) {
+				t.Errorf("unpatched mesh config differs from the original one:\n%s", meshDiff(tc.mesh, unpatched))
+			}
+		})
+	}
+}
+
+// TestUnpatchMeshKeepsOthers checks that unpatching leaves a mesh config without SentryFlow as it is written
+func TestUnpatchMeshKeepsOthers(t *testing.T) {
+	config.GlobalConfig.CollectorService = "sentryflow.sentryflow.svc.cluster.local:4317"
+
+	mesh := "# comments and formatting are kept\ndefaultConfig: {discoveryAddress: \"istiod.istio-system.svc:15012\"}\n"
+
+	unpatched, err := unpatchedMesh(mesh)
+	if err != nil {
+		t.Fatalf("failed to unpatch the mesh config: %v", err)
+	}
+	if unpatched != mesh {
+		t.Errorf("mesh config without SentryFlow was rewritten:\n%s", unpatched)
+	}
+}
@@ -0,0 +1,357 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/5gsec/SentryFlow/config"
+
+	"gopkg.in/yaml.v3"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// == //
+
+// TelemetryGVR is the Istio resource configuring access logs of proxies
+var TelemetryGVR = schema.GroupVersionResource{Group: "telemetry.istio.io", Version: "v1alpha1", Resource: "telemetries"}
+
+// Scopes of Telemetry resources in the telemetry mode
+const (
+	TelemetryScopeMesh      = "mesh"      // a single Telemetry resource in the Istio root namespace
+	TelemetryScopeNamespace = "namespace" // a Telemetry resource in each namespace in scope
+)
+
+const (
+	istioRootNamespace    = "istio-system"
+	telemetryName         = "sentryflow"
+	waypointTelemetryName = "sentryflow-waypoint"
+
+	managedByLabel = "app.kubernetes.io/managed-by"
+	managedByValue = "sentryflow"
+
+	telemetryOwnerName = "sentryflow-telemetry-owner" // a ClusterRole without rules, installed with SentryFlow
+
+	serviceAccountNamespaceFile = "/var/run/secrets/kubernetes.io/serviceaccount/namespace"
+
+	envoyFileProvider = "envoy" // the built-in provider of Istio writing access logs to meshConfig.accessLogFile
+)
+
+// == //
+
+// PatchIstioTelemetry Function that registers SentryFlow as an extension provider and creates Telemetry resources using it
+func PatchIstioTelemetry() bool {
+	log.Print("[PatchIstioTelemetry] Creating Istio Telemetry resources")
+
+	// Unlike the configMap mode, the default providers are left alone, so nothing changes until Telemetry resources use it
+	if err := patchIstioMesh(false, config.GlobalConfig.IstioPatchDryRun); err != nil {
+		log.Printf("[PatchIstioTelemetry] Unable to register SentryFlow as an extension provider: %v", err)
+		return false
+	}
+
+	if err := syncTelemetry(config.GlobalConfig.IstioPatchDryRun); err != nil {
+		log.Printf("[PatchIstioTelemetry] Unable to create Telemetry resources: %v", err)
+		return false
+	}
+
+	log.Print("[PatchIstioTelemetry] Successfully created Istio Telemetry resources")
+
+	return true
+}
+
+// UnpatchIstioTelemetry Function that deletes the Telemetry resources of SentryFlow and removes it from the extension providers
+func UnpatchIstioTelemetry() bool {
+	log.Print("[PatchIstioTelemetry] Deleting Istio Telemetry resources")
+
+	deleted, err := deleteTelemetry(func(namespace, name string) bool { return false }, false)
+	for _, name := range deleted {
+		log.Printf("[PatchIstioTelemetry] Deleted Telemetry %s", name)
+	}
+	if err != nil {
+		log.Printf("[PatchIstioTelemetry] Unable to delete Telemetry resources: %v", err)
+		return false
+	}
+
+	if _, err := unpatchIstioMesh(false); err != nil {
+		log.Printf("[PatchIstioTelemetry] Unable to remove SentryFlow from the extension providers: %v", err)
+		return false
+	}
+
+	return true
+}
+
+// syncTelemetry Function that creates or updates the Telemetry resources of SentryFlow and removes the ones out of scope
+func syncTelemetry(dryRun bool) error {
+	owner, err := telemetryOwner()
+	if err != nil {
+		return err
+	}
+
+	namespaces, err := telemetryNamespaces()
+	if err != nil {
+		return err
+	}
+
+	providers, err := accessLogProviders()
+	if err != nil {
+		return err
+	}
+
+	telemetries := make([]*unstructured.Unstructured, 0, len(namespaces))
+	for _, namespace := range namespaces {
+		telemetries = append(telemetries, newTelemetry(namespace, providers, owner))
+
+		if config.GlobalConfig.TelemetryScope != TelemetryScopeNamespace {
+			continue
+		}
+
+		// Waypoint proxies of the ambient mode are selected by their Gateways (the ones in the root namespace apply mesh-wide)
+		gateways, err := waypointGateways(namespace)
+		if err != nil {
+			return fmt.Errorf("waypoints in %s: %v", namespace, err)
+		}
+		if len(gateways) > 0 {
+			telemetries = append(telemetries, newWaypointTelemetry(namespace, gateways, providers, owner))
+		}
+	}
+
+	wanted := make(map[string]bool)
+	for _, telemetry := range telemetries {
+		wanted[telemetry.GetNamespace()+"/"+telemetry.GetName()] = true
+
+		if dryRun {
+			log.Printf("[PatchIstioTelemetry] Dry run, Telemetry %s/%s is not applied", telemetry.GetNamespace(), telemetry.GetName())
+			continue
+		}
+
+		if err := applyTelemetry(telemetry); err != nil {
+			return fmt.Errorf("%s/%s: %v", telemetry.GetNamespace(), telemetry.GetName(), err)
+		}
+	}
+
+	deleted, err := deleteTelemetry(func(namespace, name string) bool { return wanted[namespace+"/"+name] }, dryRun)
+	for _, name := range deleted {
+		if dryRun {
+			log.Printf("[PatchIstioTelemetry] Dry run, Telemetry %s is not deleted", name)
+		} else {
+			log.Printf("[PatchIstioTelemetry] Deleted Telemetry %s", name)
+		}
+	}
+
+	return err
+}
+
+// telemetryNamespaces Function that gives the namespaces to create Telemetry resources in
+func telemetryNamespaces() ([]string, error) {
+	switch config.GlobalConfig.TelemetryScope {
+	case TelemetryScopeMesh:
+		return []string{istioRootNamespace}, nil
+
+	case TelemetryScopeNamespace:
+		sc := K8sH.currentScope()
+
+		if sc.namespaceScoped() {
+			namespaces := make([]string, 0)
+			for _, namespace := range sc.watchNamespaces() {
+				if sc.inScope(namespace) {
+					namespaces = append(namespaces, namespace)
+				}
+			}
+			return namespaces, nil
+		}
+
+		nsList, err := K8sH.clientSet.CoreV1().Namespaces().List(context.TODO(), v1.ListOptions{})
+		if err != nil {
+			return nil, err
+		}
+
+		namespaces := make([]string, 0, len(nsList.Items))
+		for _, ns := range nsList.Items {
+			if ns.Name != istioRootNamespace && sc.inScope(ns.Name) {
+				namespaces = append(namespaces, ns.Name)
+			}
+		}
+		return namespaces, nil
+	}
+
+	return nil, fmt.Errorf("unknown telemetry scope %q (expected %s or %s)", config.GlobalConfig.TelemetryScope, TelemetryScopeMesh, TelemetryScopeNamespace)
+}
+
+// accessLogProviders Function that gives the access log providers of Telemetry resources (SentryFlow and the default providers of the mesh)
+// Providers in a Telemetry resource replace the default ones, so access logs sent elsewhere (e.g., to files) would stop otherwise
+func accessLogProviders() ([]string, error) {
+	cm, err := K8sH.clientSet.CoreV1().ConfigMaps(istioRootNamespace).Get(context.TODO(), istioConfigMap, v1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	doc, err := parseMesh(cm.Data[meshKey])
+	if err != nil {
+		return nil, err
+	}
+	mesh := doc.Content[0]
+
+	providers := []string{sentryFlowProvider}
+
+	var accessLogs *yaml.Node
+	if defaultProviders := lookupNode(mesh, "defaultProviders"); defaultProviders != nil {
+		accessLogs = lookupNode(defaultProviders, "accessLogs")
+	}
+
+	if accessLogs != nil && accessLogs.Kind == yaml.SequenceNode && len(accessLogs.Content) > 0 {
+		for _, item := range accessLogs.Content {
+			if item.Value != "" && item.Value != sentryFlowProvider {
+				providers = append(providers, item.Value)
+			}
+		}
+	} else if accessLogFile := scalarValue(mesh, "accessLogFile"); accessLogFile != "" {
+		// Without default providers, proxies write access logs to the file set in the mesh config
+		providers = append(providers, envoyFileProvider)
+	}
+
+	return providers, nil
+}
+
+// telemetryOwner Function that gives the dedicated owner of Telemetry resources (installed and uninstalled with SentryFlow)
+// Owners of resources in other namespaces need to be cluster-scoped (a namespaced one, e.g., the Deployment of SentryFlow,
+// would make the garbage collector delete them at once), and Telemetry resources left behind (e.g., after a crash) go with it
+// The extension provider registered in the Istio mesh config is left in place then, until "sentryflow unpatch" removes it
+func telemetryOwner() (v1.OwnerReference, error) {
+	owner, err := K8sH.clientSet.RbacV1().ClusterRoles().Get(context.TODO(), telemetryOwnerName, v1.GetOptions{})
+	if err != nil {
+		return v1.OwnerReference{}, err
+	}
+
+	return v1.OwnerReference{
+		APIVersion: "rbac.authorization.k8s.io/v1",
+		Kind:       "ClusterRole",
+		Name:       owner.Name,
+		UID:        owner.UID,
+	}, nil
+}
+
+// ownNamespace Function that gives the namespace SentryFlow runs in
+func ownNamespace() string {
+	if data, err := os.ReadFile(serviceAccountNamespaceFile); err == nil {
+		return strings.TrimSpace(string(data))
+	}
+	return "sentryflow"
+}
+
+// newTelemetry Function that builds a Telemetry resource sending access logs to SentryFlow (and the other providers)
+func newTelemetry(namespace string, providers []string, owner v1.OwnerReference) *unstructured.Unstructured {
+	providerRefs := make([]interface{}, 0, len(providers))
+	for _, provider := range providers {
+		providerRefs = append(providerRefs, map[string]interface{}{"name": provider})
+	}
+
+	telemetry := &unstructured.Unstructured{Object: map[string]interface{}{
+		"spec": map[string]interface{}{
+			"accessLogging": []interface{}{
+				map[string]interface{}{
+					"providers": providerRefs,
+				},
+			},
+		},
+	}}
+
+	telemetry.SetAPIVersion(TelemetryGVR.GroupVersion().String())
+	telemetry.SetKind("Telemetry")
+	telemetry.SetNamespace(namespace)
+	telemetry.SetName(telemetryName)
+	telemetry.SetLabels(map[string]string{managedByLabel: managedByValue})
+	telemetry.SetOwnerReferences([]v1.OwnerReference{owner})
+
+	return telemetry
+}
+
+// newWaypointTelemetry Function that builds a Telemetry resource sending access logs of waypoint proxies to SentryFlow
+func newWaypointTelemetry(namespace string, gateways, providers []string, owner v1.OwnerReference) *unstructured.Unstructured {
+	telemetry := newTelemetry(namespace, providers, owner)
+	telemetry.SetName(waypointTelemetryName)
+
+	targetRefs := make([]interface{}, 0, len(gateways))
+	for _, gateway := range gateways {
+		targetRefs = append(targetRefs, map[string]interface{}{
+			"group": GatewayGVR.Group,
+			"kind":  "Gateway",
+			"name":  gateway,
+		})
+	}
+	telemetry.Object["spec"].(map[string]interface{})["targetRefs"] = targetRefs
+
+	return telemetry
+}
+
+// applyTelemetry Function that creates or updates a Telemetry resource of SentryFlow
+func applyTelemetry(telemetry *unstructured.Unstructured) error {
+	namespace, name := telemetry.GetNamespace(), telemetry.GetName()
+	client := K8sH.dynamicClient.Resource(TelemetryGVR).Namespace(namespace)
+
+	existing, err := client.Get(context.TODO(), name, v1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		_, err = client.Create(context.TODO(), telemetry, v1.CreateOptions{})
+		return err
+	} else if err != nil {
+		return err
+	}
+
+	// Leave alone a Telemetry resource with the same name created by someone else
+	if existing.GetLabels()[managedByLabel] != managedByValue {
+		log.Printf("[PatchIstioTelemetry] Skipping %s/%s (not managed by SentryFlow)", namespace, name)
+		return nil
+	}
+
+	telemetry.SetResourceVersion(existing.GetResourceVersion())
+	_, err = client.Update(context.TODO(), telemetry, v1.UpdateOptions{})
+	return err
+}
+
+// deleteTelemetry Function that deletes the Telemetry resources of SentryFlow except the ones to keep (and gives their names)
+func deleteTelemetry(keep func(namespace, name string) bool, dryRun bool) ([]string, error) {
+	deleted := make([]string, 0)
+
+	options := v1.ListOptions{LabelSelector: managedByLabel + "=" + managedByValue}
+
+	namespaces := []string{corev1.NamespaceAll}
+	if sc := K8sH.currentScope(); sc.namespaceScoped() {
+		namespaces = sc.watchNamespaces()
+	}
+
+	for _, namespace := range namespaces {
+		telemetries, err := K8sH.dynamicClient.Resource(TelemetryGVR).Namespace(namespace).List(context.TODO(), options)
+		if apierrors.IsNotFound(err) {
+			return deleted, nil // Istio is not installed with the Telemetry API
+		} else if err != nil {
+			return deleted, err
+		}
+
+		for _, telemetry := range telemetries.Items {
+			name := telemetry.GetName()
+			if (name != telemetryName && name != waypointTelemetryName) || keep(telemetry.GetNamespace(), name) {
+				continue
+			}
+
+			if !dryRun {
+				err := K8sH.dynamicClient.Resource(TelemetryGVR).Namespace(telemetry.GetNamespace()).Delete(context.TODO(), name, v1.DeleteOptions{})
+				if err != nil && !apierrors.IsNotFound(err) {
+					return deleted, err
+				}
+			}
+
+			deleted = append(deleted, telemetry.GetNamespace()+"/"+name)
+		}
+	}
+
+	return deleted, nil
+}
+
+// == //
@@ -5,20 +5,30 @@ package k8s
 import (
 	"context"
 	"errors"
+	"fmt"
 	"log"
+	"reflect"
+	"strings"
 	"sync"
 	"time"
 
-	"k8s.io/apimachinery/pkg/util/json"
-
+	"github.com/5gsec/SentryFlow/config"
 	"github.com/5gsec/SentryFlow/types"
 
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/clientcmd"
 )
 
 // == //
@@ -33,14 +43,19 @@ func init() {
 
 // KubernetesHandler Structure
 type KubernetesHandler struct {
-	config    *rest.Config
-	clientSet *kubernetes.Clientset
+	config        *rest.Config
+	clientSet     *kubernetes.Clientset
+	dynamicClient dynamic.Interface
 
 	watchers  map[string]*cache.ListWatch
 	informers map[string]cache.Controller
 
-	podMap     map[string]*corev1.Pod     // NOT thread safe
-	serviceMap map[string]*corev1.Service // NOT thread safe
+	informersStop chan struct{} // closed to stop the current informers
+	informersLock sync.Mutex
+
+	index     *ResourceIndex
+	scope     *Scope
+	scopeLock sync.RWMutex
 }
 
 // NewK8sHandler Function
@@ -49,8 +64,8 @@ func NewK8sHandler() *KubernetesHandler {
 		watchers:  make(map[string]*cache.ListWatch),
 		informers: make(map[string]cache.Controller),
 
-		podMap:     make(map[string]*corev1.Pod),
-		serviceMap: make(map[string]*corev1.Service),
+		index: NewResourceIndex(),
+		scope: NewScope(),
 	}
 
 	return kh
@@ -69,369 +84,625 @@ func InitK8sClient() bool {
 		return false
 	}
 
-	// Initialize Kubernetes clientSet
-	K8sH.clientSet, err = kubernetes.NewForConfig(K8sH.config)
+	if !K8sH.initClients() {
+		return false
+	}
+
+	// Load the namespaces and labels in scope
+	scope, err := loadScope(config.GlobalConfig.Scope)
 	if err != nil {
-		log.Print("[InitK8sClient] Failed to initialize Kubernetes client")
+		log.Printf("[InitK8sClient] Failed to load the scope: %v", err)
 		return false
 	}
+	K8sH.scope = scope
 
 	// Create a mapping table for existing pods and services to IPs
-	K8sH.initExistingResources()
-
-	watchTargets := []string{"pods", "services"}
+	K8sH.initExistingResources(scope)
 
-	//  Initialize watchers for pods and services
-	for _, target := range watchTargets {
-		watcher := cache.NewListWatchFromClient(
-			K8sH.clientSet.CoreV1().RESTClient(),
-			target,
-			corev1.NamespaceAll,
-			fields.Everything(),
-		)
-		K8sH.watchers[target] = watcher
-	}
+	// Initialize watchers
+	K8sH.initWatchers(scope)
 
 	// Initialize informers
-	K8sH.initInformers()
+	K8sH.initInformers(scope)
 
 	log.Print("[InitK8sClient] Initialized Kubernetes client")
 
 	return true
 }
 
-// initExistingResources Function that creates a mapping table for existing pods and services to IPs
-// This is required since informers are NOT going to see existing resources until they are updated, created or deleted
-// @todo: Refactor this function, this is kind of messy
-func (k8s *KubernetesHandler) initExistingResources() {
-	// List existing Pods
-	podList, err := k8s.clientSet.CoreV1().Pods(corev1.NamespaceAll).List(context.TODO(), v1.ListOptions{})
+// ConnectK8sClient Function that only initializes clients (for commands run in or outside the cluster)
+func ConnectK8sClient() bool {
+	var err error
+
+	// Initialize in cluster config, or the config of kubectl (KUBECONFIG or ~/.kube/config)
+	K8sH.config, err = rest.InClusterConfig()
+	if err != nil {
+		rules := clientcmd.NewDefaultClientConfigLoadingRules()
+		K8sH.config, err = clientcmd.NewNonInteractiveDeferredLoadingClientConfig(rules, &clientcmd.ConfigOverrides{}).ClientConfig()
+		if err != nil {
+			log.Printf("[InitK8sClient] Failed to load Kubernetes config: %v", err)
+			return false
+		}
+	}
+
+	// Load the namespaces and labels in scope
+	scope, err := loadScope(config.GlobalConfig.Scope)
 	if err != nil {
-		log.Printf("[K8s] Failed to get Pods: %v", err.Error())
+		log.Printf("[InitK8sClient] Failed to load the scope: %v", err)
+		return false
 	}
+	K8sH.scope = scope
+
+	return K8sH.initClients()
+}
+
+// initClients Function
+func (k8s *KubernetesHandler) initClients() bool {
+	var err error
 
-	// Add existing Pods to the podMap
-	for _, pod := range podList.Items {
-		currentPod := pod
-		k8s.podMap[pod.Status.PodIP] = &currentPod
-		log.Printf("[K8s] Add existing pod %s: %s/%s", pod.Status.PodIP, pod.Namespace, pod.Name)
+	// Initialize Kubernetes clientSet
+	k8s.clientSet, err = kubernetes.NewForConfig(k8s.config)
+	if err != nil {
+		log.Print("[InitK8sClient] Failed to initialize Kubernetes client")
+		return false
 	}
 
-	// List existing Services
-	serviceList, err := k8s.clientSet.CoreV1().Services(corev1.NamespaceAll).List(context.TODO(), v1.ListOptions{})
+	// Initialize Kubernetes dynamic client (for custom resources such as Istio policies)
+	k8s.dynamicClient, err = dynamic.NewForConfig(k8s.config)
 	if err != nil {
-		log.Printf("[K8s] Failed to get Services: %v", err.Error())
+		log.Print("[InitK8sClient] Failed to initialize Kubernetes dynamic client")
+		return false
 	}
 
-	// Add existing Services to the serviceMap
-	for _, service := range serviceList.Items {
-		currentService := service
+	return true
+}
 
-		// Check if the service has a LoadBalancer type
-		if service.Spec.Type == "LoadBalancer" {
-			for _, lbIngress := range service.Status.LoadBalancer.Ingress {
-				lbIP := lbIngress.IP
-				if lbIP != "" {
-					k8s.serviceMap[lbIP] = &currentService
-					log.Printf("[K8s] Add existing service (LoadBalancer) %s: %s/%s", lbIP, service.Namespace, service.Name)
-				}
+// watcherKey Function (e.g., pods or pods/default)
+func watcherKey(target, namespace string) string {
+	if namespace == corev1.NamespaceAll {
+		return target
+	}
+	return target + "/" + namespace
+}
+
+// initWatchers Function that initializes watchers for the resources in scope
+func (k8s *KubernetesHandler) initWatchers(sc *Scope) {
+	k8s.watchers = make(map[string]*cache.ListWatch)
+
+	watchTargets := map[string]cache.Getter{
+		"pods":           k8s.clientSet.CoreV1().RESTClient(),
+		"services":       k8s.clientSet.CoreV1().RESTClient(),
+		"replicasets":    k8s.clientSet.AppsV1().RESTClient(),
+		"jobs":           k8s.clientSet.BatchV1().RESTClient(),
+		"endpointslices": k8s.clientSet.DiscoveryV1().RESTClient(),
+	}
+
+	//  Initialize watchers for pods, services, endpoints and the controllers owning pods (in each watched namespace)
+	for target, client := range watchTargets {
+		for _, namespace := range sc.watchNamespaces() {
+			target := target
+			namespace := namespace
+
+			watcher := cache.NewFilteredListWatchFromClient(
+				client,
+				target,
+				namespace,
+				func(options *v1.ListOptions) { sc.applyListOptions(target, namespace, options) },
+			)
+			k8s.watchers[watcherKey(target, namespace)] = watcher
+		}
+	}
+
+	// Initialize watchers for cluster-scoped resources (not allowed with namespace-scoped RBAC)
+	if !sc.namespaceScoped() {
+		k8s.watchers["nodes"] = cache.NewListWatchFromClient(k8s.clientSet.CoreV1().RESTClient(), "nodes", corev1.NamespaceAll, fields.Everything())
+
+		if sc.namespaceSelector != nil {
+			k8s.watchers["namespaces"] = cache.NewListWatchFromClient(k8s.clientSet.CoreV1().RESTClient(), "namespaces", corev1.NamespaceAll, fields.Everything())
+		}
+	}
+}
+
+// initExistingResources Function that creates a mapping table for existing pods and services to IPs
+// This is required since informers are NOT going to see existing resources until they are updated, created or deleted
+func (k8s *KubernetesHandler) initExistingResources(sc *Scope) {
+	for _, namespace := range sc.watchNamespaces() {
+		// List existing Pods
+		podList, err := k8s.clientSet.CoreV1().Pods(namespace).List(context.TODO(), sc.listOptions("pods", namespace))
+		if err != nil {
+			log.Printf("[K8s] Failed to get Pods: %v", err.Error())
+		} else {
+			for idx := range podList.Items {
+				pod := &podList.Items[idx]
+				k8s.index.addPod(nil, pod)
+				log.Printf("[K8s] Add existing pod %v: %s/%s", podIPs(pod), pod.Namespace, pod.Name)
 			}
+		}
+
+		// List existing Services
+		serviceList, err := k8s.clientSet.CoreV1().Services(namespace).List(context.TODO(), sc.listOptions("services", namespace))
+		if err != nil {
+			log.Printf("[K8s] Failed to get Services: %v", err.Error())
 		} else {
-			k8s.serviceMap[service.Spec.ClusterIP] = &currentService
-			if len(service.Spec.ExternalIPs) != 0 {
-				for _, eIP := range service.Spec.ExternalIPs {
-					k8s.serviceMap[eIP] = &currentService
-					log.Printf("[K8s] Add existing service %s: %s/%s", eIP, service.Namespace, service.Name)
-				}
+			for idx := range serviceList.Items {
+				service := &serviceList.Items[idx]
+				k8s.index.addService(nil, service)
+				log.Printf("[K8s] Add existing service %v: %s/%s", serviceIPs(service), service.Namespace, service.Name)
 			}
 		}
 	}
 }
 
-// initInformers Function that initializes informers for services and pods in a cluster
-func (k8s *KubernetesHandler) initInformers() {
-	// Create Pod controller informer
-	_, pc := cache.NewInformer(
-		k8s.watchers["pods"],
-		&corev1.Pod{},
-		time.Second*0,
-		cache.ResourceEventHandlerFuncs{
+// ownerHandlers Function that gives the event handlers for the controllers owning pods (ReplicaSets and Jobs)
+func (k8s *KubernetesHandler) ownerHandlers(kind string) cache.ResourceEventHandlerFuncs {
+	return cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			if meta, ok := obj.(v1.Object); ok {
+				k8s.index.setOwner(kind, meta)
+			}
+		},
+		UpdateFunc: func(_, newObj interface{}) {
+			if meta, ok := newObj.(v1.Object); ok {
+				k8s.index.setOwner(kind, meta)
+			}
+		},
+		DeleteFunc: func(obj interface{}) {
+			if meta, ok := tombstone(obj).(v1.Object); ok {
+				k8s.index.deleteOwner(kind, meta)
+			}
+		},
+	}
+}
+
+// initInformers Function that initializes informers for every watcher (pods, services, nodes, ...)
+func (k8s *KubernetesHandler) initInformers(sc *Scope) {
+	handlers := map[string]struct {
+		object   runtime.Object
+		handlers cache.ResourceEventHandlerFuncs
+	}{
+		"pods": {&corev1.Pod{}, cache.ResourceEventHandlerFuncs{
 			AddFunc: func(obj interface{}) { // Add pod
-				pod := obj.(*corev1.Pod)
-				k8s.podMap[pod.Status.PodIP] = pod
+				k8s.index.addPod(nil, obj.(*corev1.Pod))
 			},
 			UpdateFunc: func(oldObj, newObj interface{}) { // Update pod
-				newPod := newObj.(*corev1.Pod)
-				k8s.podMap[newPod.Status.PodIP] = newPod
+				k8s.index.addPod(oldObj.(*corev1.Pod), newObj.(*corev1.Pod))
 			},
 			DeleteFunc: func(obj interface{}) { // Remove deleted pod
-				pod := obj.(*corev1.Pod)
-				delete(k8s.podMap, pod.Status.PodIP)
+				if pod, ok := tombstone(obj).(*corev1.Pod); ok {
+					k8s.index.deletePod(pod)
+				}
 			},
-		},
-	)
-	k8s.informers["pods"] = pc
-
-	// Create Service controller informer
-	_, sc := cache.NewInformer(
-		k8s.watchers["services"],
-		&corev1.Service{},
-		time.Second*0,
-		cache.ResourceEventHandlerFuncs{
+		}},
+		"services": {&corev1.Service{}, cache.ResourceEventHandlerFuncs{
 			AddFunc: func(obj interface{}) { // Add service
-				service := obj.(*corev1.Service)
-
-				if service.Spec.Type == "LoadBalancer" {
-					for _, lbIngress := range service.Status.LoadBalancer.Ingress {
-						lbIP := lbIngress.IP
-						if lbIP != "" {
-							k8s.serviceMap[lbIP] = service
-						}
-					}
-				} else {
-					k8s.serviceMap[service.Spec.ClusterIP] = service
-					if len(service.Spec.ExternalIPs) != 0 {
-						for _, eIP := range service.Spec.ExternalIPs {
-							k8s.serviceMap[eIP] = service
-						}
-					}
-				}
+				k8s.index.addService(nil, obj.(*corev1.Service))
 			},
 			UpdateFunc: func(oldObj, newObj interface{}) { // Update service
-				newService := newObj.(*corev1.Service)
-				if newService.Spec.Type == "LoadBalancer" {
-					for _, lbIngress := range newService.Status.LoadBalancer.Ingress {
-						lbIP := lbIngress.IP
-						if lbIP != "" {
-							k8s.serviceMap[lbIP] = newService
-						}
-					}
-				} else {
-					k8s.serviceMap[newService.Spec.ClusterIP] = newService
-					if len(newService.Spec.ExternalIPs) != 0 {
-						for _, eIP := range newService.Spec.ExternalIPs {
-							k8s.serviceMap[eIP] = newService
-						}
-					}
+				k8s.index.addService(oldObj.(*corev1.Service), newObj.(*corev1.Service))
+			},
+			DeleteFunc: func(obj interface{}) { // Remove deleted service
+				if service, ok := tombstone(obj).(*corev1.Service); ok {
+					k8s.index.deleteService(service)
 				}
 			},
-			DeleteFunc: func(obj interface{}) {
-				service := obj.(*corev1.Service)
-				if service.Spec.Type == "LoadBalancer" {
-					for _, lbIngress := range service.Status.LoadBalancer.Ingress {
-						lbIP := lbIngress.IP
-						if lbIP != "" {
-							delete(k8s.serviceMap, lbIP)
-						}
-					}
-				} else {
-					delete(k8s.serviceMap, service.Spec.ClusterIP) // Remove deleted service
-					if len(service.Spec.ExternalIPs) != 0 {
-						for _, eIP := range service.Spec.ExternalIPs {
-							delete(k8s.serviceMap, eIP)
-						}
-					}
+		}},
+		// Nodes (for pods on the host network)
+		"nodes": {&corev1.Node{}, cache.ResourceEventHandlerFuncs{
+			AddFunc: func(obj interface{}) { // Add node
+				k8s.index.addNode(nil, obj.(*corev1.Node))
+			},
+			UpdateFunc: func(oldObj, newObj interface{}) { // Update node
+				k8s.index.addNode(oldObj.(*corev1.Node), newObj.(*corev1.Node))
+			},
+			DeleteFunc: func(obj interface{}) { // Remove deleted node
+				if node, ok := tombstone(obj).(*corev1.Node); ok {
+					k8s.index.deleteNode(node)
 				}
 			},
-		},
-	)
-	k8s.informers["services"] = sc
+		}},
+		// EndpointSlices (for headless and selectorless services)
+		"endpointslices": {&discoveryv1.EndpointSlice{}, cache.ResourceEventHandlerFuncs{
+			AddFunc: func(obj interface{}) { // Add endpoint slice
+				k8s.index.addEndpointSlice(nil, obj.(*discoveryv1.EndpointSlice))
+			},
+			UpdateFunc: func(oldObj, newObj interface{}) { // Update endpoint slice
+				k8s.index.addEndpointSlice(oldObj.(*discoveryv1.EndpointSlice), newObj.(*discoveryv1.EndpointSlice))
+			},
+			DeleteFunc: func(obj interface{}) { // Remove deleted endpoint slice
+				if slice, ok := tombstone(obj).(*discoveryv1.EndpointSlice); ok {
+					k8s.index.deleteEndpointSlice(slice)
+				}
+			},
+		}},
+		// Namespaces (for the namespace selector)
+		"namespaces": {&corev1.Namespace{}, cache.ResourceEventHandlerFuncs{
+			AddFunc: func(obj interface{}) { // Add namespace
+				sc.setNamespace(obj.(*corev1.Namespace))
+			},
+			UpdateFunc: func(_, newObj interface{}) { // Update namespace
+				sc.setNamespace(newObj.(*corev1.Namespace))
+			},
+			DeleteFunc: func(obj interface{}) { // Remove deleted namespace
+				if namespace, ok := tombstone(obj).(*corev1.Namespace); ok {
+					sc.deleteNamespace(namespace)
+				}
+			},
+		}},
+		// ReplicaSets and Jobs (for resolving pods to Deployments and CronJobs)
+		"replicasets": {&appsv1.ReplicaSet{}, k8s.ownerHandlers(WorkloadKindReplicaSet)},
+		"jobs":        {&batchv1.Job{}, k8s.ownerHandlers(WorkloadKindJob)},
+	}
+
+	k8s.informers = make(map[string]cache.Controller)
+
+	for key, watcher := range k8s.watchers {
+		target, _, _ := strings.Cut(key, "/")
+
+		_, informer := cache.NewInformer(
+			watcher,
+			handlers[target].object,
+			time.Second*0,
+			handlers[target].handlers,
+		)
+		k8s.informers[key] = informer
+	}
 }
 
 // == //
 
 // RunInformers Function that starts running informers
 func RunInformers(stopChan chan struct{}, wg *sync.WaitGroup) {
+	K8sH.informersLock.Lock()
+	informersStop := K8sH.startInformers()
+	K8sH.informersLock.Unlock()
+
 	wg.Add(1)
+	go pruneIPHistory(stopChan, wg)
 
-	for name, informer := range K8sH.informers {
+	// Namespaces need to be known before patching them or processing API logs
+	K8sH.waitForNamespaces(informersStop)
+
+	// Stop the informers (the current ones, if the scope changed)
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+
+		<-stopChan
+
+		K8sH.informersLock.Lock()
+		close(K8sH.informersStop)
+		K8sH.informersStop = nil
+		K8sH.informersLock.Unlock()
+	}()
+
+	log.Print("[RunInformers] Started all Kubernetes informers")
+}
+
+// startInformers Function that runs the informers until the returned channel is closed (for callers holding the lock)
+func (k8s *KubernetesHandler) startInformers() chan struct{} {
+	informersStop := make(chan struct{})
+	k8s.informersStop = informersStop
+
+	for name, informer := range k8s.informers {
 		name := name
 		informer := informer
 		go func() {
 			log.Printf("[RunInformers] Starting an informer for %s", name)
-			informer.Run(stopChan)
-			defer wg.Done()
+			informer.Run(informersStop)
 		}()
 	}
 
-	log.Print("[RunInformers] Started all Kubernetes informers")
+	return informersStop
 }
 
-// getConfigMap Function
-func (k8s *KubernetesHandler) getConfigMap(namespace, name string) (string, error) {
-	cm, err := k8s.clientSet.CoreV1().ConfigMaps(namespace).Get(context.TODO(), name, v1.GetOptions{})
-	if err != nil {
-		log.Printf("[K8s] Failed to get ConfigMaps: %v", err)
-		return "", err
+// waitForNamespaces Function
+func (k8s *KubernetesHandler) waitForNamespaces(informersStop chan struct{}) {
+	if informer, ok := k8s.informers["namespaces"]; ok {
+		cache.WaitForCacheSync(informersStop, informer.HasSynced)
 	}
+}
 
-	// convert data to string
-	data, err := json.Marshal(cm.Data)
+// LoadScope Function that replaces the namespaces and labels in scope (restarting informers if they changed)
+func LoadScope(scopeCfg config.ScopeConfig) error {
+	sc, err := loadScope(scopeCfg)
 	if err != nil {
-		log.Printf("[K8s] Failed to marshal ConfigMap: %v", err)
-		return "", err
+		return err
 	}
 
-	return string(data), nil
-}
+	K8sH.informersLock.Lock()
+	defer K8sH.informersLock.Unlock()
 
-// updateConfigMap Function
-func (k8s *KubernetesHandler) updateConfigMap(namespace, name, data string) error {
-	cm, err := k8s.clientSet.CoreV1().ConfigMaps(namespace).Get(context.TODO(), name, v1.GetOptions{})
-	if err != nil {
-		log.Printf("[K8s] Failed to get ConfigMap: %v", err)
-		return err
+	if reflect.DeepEqual(K8sH.currentScope().cfg, sc.cfg) {
+		return nil
 	}
 
-	if _, ok := cm.Data["mesh"]; !ok {
-		return errors.New("[K8s] Unable to find field \"mesh\" from Istio config")
+	running := K8sH.informersStop != nil
+	if running {
+		close(K8sH.informersStop)
+		K8sH.informersStop = nil
 	}
 
-	cm.Data["mesh"] = data
-	if _, err := k8s.clientSet.CoreV1().ConfigMaps(namespace).Update(context.Background(), cm, v1.UpdateOptions{}); err != nil {
-		return err
+	K8sH.scopeLock.Lock()
+	K8sH.scope = sc
+	K8sH.scopeLock.Unlock()
+
+	// Forget the resources out of scope (by namespace, and pods by the pod selector), informers add the ones in scope again
+	K8sH.index.retainScope(sc)
+
+	if K8sH.clientSet == nil {
+		return nil
 	}
 
-	return nil
-}
+	K8sH.initWatchers(sc)
+	K8sH.initInformers(sc)
 
-// PatchNamespaces Function that patches namespaces for adding 'istio-injection'
-func PatchNamespaces() bool {
-	namespaces, err := K8sH.clientSet.CoreV1().Namespaces().List(context.Background(), v1.ListOptions{})
-	if err != nil {
-		log.Printf("[PatchNamespaces] Failed to get Namespaces: %v", err)
-		return false
+	if running {
+		K8sH.waitForNamespaces(K8sH.startInformers())
 	}
 
-	for _, ns := range namespaces.Items {
-		namespace := ns.DeepCopy()
+	log.Printf("[K8s] Watching namespaces %v with the new scope", sc.watchNamespaces())
 
-		// Skip the following namespaces
-		if namespace.Name == "sentryflow" {
-			continue
+	// Telemetry resources follow the namespaces in scope
+	if running && config.GlobalConfig.IstioPatchMode == IstioPatchModeTelemetry && config.GlobalConfig.TelemetryScope == TelemetryScopeNamespace {
+		if err := syncTelemetry(config.GlobalConfig.IstioPatchDryRun); err != nil {
+			log.Printf("[K8s] Failed to update Telemetry resources with the new scope: %v", err)
 		}
+	}
 
-		namespace.Labels["istio-injection"] = "enabled"
+	return nil
+}
 
-		// Patch the namespace
-		if _, err := K8sH.clientSet.CoreV1().Namespaces().Update(context.TODO(), namespace, v1.UpdateOptions{FieldManager: "patcher"}); err != nil {
-			log.Printf("[PatchNamespaces] Failed to update Namespace %s: %v", namespace.Name, err)
-			return false
+// currentScope Function
+func (k8s *KubernetesHandler) currentScope() *Scope {
+	k8s.scopeLock.RLock()
+	defer k8s.scopeLock.RUnlock()
+
+	return k8s.scope
+}
+
+// pruneIPHistory Function that forgets past assignments of IP addresses beyond the horizon
+func pruneIPHistory(stopChan chan struct{}, wg *sync.WaitGroup) {
+	defer wg.Done()
+
+	ticker := time.NewTicker(time.Minute)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			horizon := time.Duration(config.GlobalConfig.IPHistoryHorizon) * time.Second
+			K8sH.index.pruneHistory(time.Now().Add(-horizon))
+		case <-stopChan:
+			return
 		}
+	}
+}
 
-		log.Printf("[PatchNamespaces] Updated Namespace %s", namespace.Name)
+// GetConfigMapData Function that gives the data of a ConfigMap
+func GetConfigMapData(namespace, name string) (map[string]string, error) {
+	if K8sH.clientSet == nil {
+		return nil, errors.New("kubernetes client is not initialized")
 	}
 
-	log.Print("[PatchNamespaces] Updated all Namespaces")
+	cm, err := K8sH.clientSet.CoreV1().ConfigMaps(namespace).Get(context.TODO(), name, v1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
 
-	return true
+	return cm.Data, nil
 }
 
-// restartDeployment Function that performs a rolling restart for a deployment in the specified namespace
-// @todo: fix this, this DOES NOT restart deployments
-func (k8s *KubernetesHandler) restartDeployment(namespace string, deploymentName string) error {
-	deploymentClient := k8s.clientSet.AppsV1().Deployments(namespace)
+// ListAnnotatedPods Function that gives the pods having the given annotation
+func ListAnnotatedPods(annotation string) ([]types.K8sResource, error) {
+	if K8sH.clientSet == nil {
+		return nil, errors.New("kubernetes client is not initialized")
+	}
 
-	// Get the deployment to retrieve the current spec
-	deployment, err := deploymentClient.Get(context.Background(), deploymentName, v1.GetOptions{})
-	if err != nil {
-		return err
+	podItems := make([]corev1.Pod, 0)
+	for _, namespace := range K8sH.currentScope().watchNamespaces() {
+		podList, err := K8sH.clientSet.CoreV1().Pods(namespace).List(context.TODO(), K8sH.currentScope().listOptions("pods", namespace))
+		if err != nil {
+			return nil, err
+		}
+		podItems = append(podItems, podList.Items...)
 	}
 
-	// Trigger a rolling restart by updating the deployment's labels or annotations
-	deployment.Spec.Template.ObjectMeta.Labels["restartedAt"] = v1.Now().String()
+	pods := make([]types.K8sResource, 0)
 
-	// Update the deployment to trigger the rolling restart
-	_, err = deploymentClient.Update(context.TODO(), deployment, v1.UpdateOptions{})
-	if err != nil {
-		return err
+	K8sH.index.indexLock.RLock()
+	defer K8sH.index.indexLock.RUnlock()
+
+	for idx := range podItems {
+		pod := &podItems[idx]
+		if _, ok := pod.Annotations[annotation]; !ok || !K8sH.currentScope().inScope(pod.Namespace) {
+			continue
+		}
+
+		kind, name := K8sH.index.workloadOf(pod)
+
+		pods = append(pods, types.K8sResource{
+			Type:         types.K8sResourceTypePod,
+			Namespace:    pod.Namespace,
+			Name:         pod.Name,
+			Labels:       pod.Labels,
+			Annotations:  pod.Annotations,
+			Containers:   containersOf(pod),
+			WorkloadKind: kind,
+			WorkloadName: name,
+		})
 	}
 
-	return nil
+	return pods, nil
 }
 
-// RestartDeployments Function that restarts the deployments in the namespaces with "istio-injection=enabled"
-func RestartDeployments() bool {
-	deployments, err := K8sH.clientSet.AppsV1().Deployments("").List(context.Background(), v1.ListOptions{})
+// PatchNamespaces Function that patches namespaces for adding 'istio-injection'
+func PatchNamespaces() bool {
+	namespaces, err := scopeNamespaces(K8sH.currentScope())
 	if err != nil {
-		log.Printf("[PatchDeployments] Failed to get Deployments: %v", err)
+		log.Printf("[PatchNamespaces] Failed to get Namespaces: %v", err)
 		return false
 	}
 
-	for _, deployment := range deployments.Items {
+	for _, ns := range namespaces {
+		namespace := ns.DeepCopy()
+
 		// Skip the following namespaces
-		if deployment.Namespace == "sentryflow" {
+		if namespace.Name == "sentryflow" || !K8sH.currentScope().allowed(namespace.Name) {
+			continue
+		}
+
+		// Namespaces in the ambient mesh have no sidecars, and access logs come from their waypoint proxies
+		if IsAmbientNamespace(namespace.Labels) {
+			log.Printf("[PatchNamespaces] Skipped Namespace %s (ambient mode)", namespace.Name)
 			continue
 		}
 
-		// Restart the deployment
-		if err := K8sH.restartDeployment(deployment.Namespace, deployment.Name); err != nil {
-			log.Printf("[PatchDeployments] Failed to restart Deployment %s/%s: %v", deployment.Namespace, deployment.Name, err)
+		if namespace.Labels == nil {
+			namespace.Labels = make(map[string]string)
+		}
+		namespace.Labels["istio-injection"] = "enabled"
+
+		// Patch the namespace
+		if _, err := K8sH.clientSet.CoreV1().Namespaces().Update(context.TODO(), namespace, v1.UpdateOptions{FieldManager: "patcher"}); err != nil {
+			log.Printf("[PatchNamespaces] Failed to update Namespace %s: %v", namespace.Name, err)
 			return false
 		}
 
-		log.Printf("[PatchDeployments] Deployment %s/%s restarted", deployment.Namespace, deployment.Name)
+		log.Printf("[PatchNamespaces] Updated Namespace %s", namespace.Name)
 	}
 
-	log.Print("[PatchDeployments] Restarted all patched deployments")
+	log.Print("[PatchNamespaces] Updated all Namespaces")
 
 	return true
 }
 
-// == //
-
-// lookupIPAddress Function
-func lookupIPAddress(ipAddr string) interface{} {
-	// Look for pod map
-	pod, ok := K8sH.podMap[ipAddr]
-	if ok {
-		return pod
+// scopeNamespaces Function that gives the namespaces which may be in scope (matching the namespace selector if any)
+// With namespace-scoped RBAC, namespaces cannot be listed, so the included ones are taken one by one by their names
+func scopeNamespaces(sc *Scope) ([]corev1.Namespace, error) {
+	if !sc.namespaceScoped() {
+		nsList, err := K8sH.clientSet.CoreV1().Namespaces().List(context.TODO(), v1.ListOptions{LabelSelector: sc.rawNsSelector})
+		if err != nil {
+			return nil, err
+		}
+		return nsList.Items, nil
 	}
 
-	// Look for service map
-	service, ok := K8sH.serviceMap[ipAddr]
-	if ok {
-		return service
+	namespaces := make([]corev1.Namespace, 0)
+	for _, name := range sc.watchNamespaces() {
+		namespace, err := K8sH.clientSet.CoreV1().Namespaces().Get(context.TODO(), name, v1.GetOptions{})
+		if apierrors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return nil, fmt.Errorf("namespace %s: %v", name, err)
+		}
+		namespaces = append(namespaces, *namespace)
 	}
 
-	return nil
+	return namespaces, nil
 }
 
-// LookupK8sResource Function
+// == //
+
+// LookupK8sResource Function that gives the pod or the service having an IP address (with its top-level owner)
 func LookupK8sResource(srcIP string) types.K8sResource {
-	ret := types.K8sResource{
+	return LookupK8sResourceAt(srcIP, "", time.Time{})
+}
+
+// LookupK8sResourceAt Function that gives the pod, the service or the node which had an IP address at the given time
+// The port tells apart pods on the host network and the endpoints of headless services sharing an IP address
+func LookupK8sResourceAt(srcIP, port string, at time.Time) types.K8sResource {
+	if resource, ok := K8sH.index.lookup(srcIP, port, at); ok {
+		return resource
+	}
+
+	return types.K8sResource{
 		Namespace: "Unknown",
 		Name:      "Unknown",
 		Labels:    make(map[string]string),
 		Type:      types.K8sResourceTypeUnknown,
 	}
+}
+
+// == //
+
+// FindPod Function that gives the first pod in a namespace for which the given function returns true
+// The function is given the pod with the kind and the name of its top-level owner
+func FindPod(namespace string, match func(pod *corev1.Pod, kind, name string) bool) *corev1.Pod {
+	return K8sH.index.findPod(namespace, match)
+}
+
+// WorkloadSelector Function that gives the labels selecting the pods of the top-level owner of a pod (its matchLabels)
+// CronJobs select their pods through the labels of their pod template, as the selectors of their Jobs change with every run
+func WorkloadSelector(pod *corev1.Pod) (map[string]string, error) {
+	if K8sH.clientSet == nil {
+		return nil, errors.New("kubernetes client is not initialized")
+	}
+
+	K8sH.index.indexLock.RLock()
+	kind, name := K8sH.index.workloadOf(pod)
+	K8sH.index.indexLock.RUnlock()
 
-	// Find Kubernetes resource from source IP (service or a pod)
-	raw := lookupIPAddress(srcIP)
+	var selector *v1.LabelSelector
 
-	// Currently supports Service or Pod
-	switch raw.(type) {
-	case *corev1.Pod:
-		pod, ok := raw.(*corev1.Pod)
-		if ok {
-			ret.Namespace = pod.Namespace
-			ret.Name = pod.Name
-			ret.Labels = pod.Labels
-			ret.Type = types.K8sResourceTypePod
+	switch kind {
+	case WorkloadKindDeployment:
+		deployment, err := K8sH.clientSet.AppsV1().Deployments(pod.Namespace).Get(context.TODO(), name, v1.GetOptions{})
+		if err != nil {
+			return nil, err
 		}
-	case *corev1.Service:
-		svc, ok := raw.(*corev1.Service)
-		if ok {
-			ret.Namespace = svc.Namespace
-			ret.Name = svc.Name
-			ret.Labels = svc.Labels
-			ret.Type = types.K8sResourceTypeService
+		selector = deployment.Spec.Selector
+	case WorkloadKindStatefulSet:
+		statefulSet, err := K8sH.clientSet.AppsV1().StatefulSets(pod.Namespace).Get(context.TODO(), name, v1.GetOptions{})
+		if err != nil {
+			return nil, err
 		}
+		selector = statefulSet.Spec.Selector
+	case WorkloadKindDaemonSet:
+		daemonSet, err := K8sH.clientSet.AppsV1().DaemonSets(pod.Namespace).Get(context.TODO(), name, v1.GetOptions{})
+		if err != nil {
+			return nil, err
+		}
+		selector = daemonSet.Spec.Selector
+	case WorkloadKindReplicaSet:
+		replicaSet, err := K8sH.clientSet.AppsV1().ReplicaSets(pod.Namespace).Get(context.TODO(), name, v1.GetOptions{})
+		if err != nil {
+			return nil, err
+		}
+		selector = replicaSet.Spec.Selector
+	case WorkloadKindCronJob:
+		cronJob, err := K8sH.clientSet.BatchV1().CronJobs(pod.Namespace).Get(context.TODO(), name, v1.GetOptions{})
+		if err != nil {
+			return nil, err
+		}
+		selector = &v1.LabelSelector{MatchLabels: cronJob.Spec.JobTemplate.Spec.Template.Labels}
 	default:
-		ret.Type = types.K8sResourceTypeUnknown
+		return nil, fmt.Errorf("%s %s/%s has no selector", kind, pod.Namespace, name)
+	}
+
+	if selector == nil || len(selector.MatchLabels) == 0 {
+		return nil, fmt.Errorf("%s %s/%s has no matchLabels", kind, pod.Namespace, name)
+	}
+	if len(selector.MatchExpressions) > 0 {
+		return nil, fmt.Errorf("%s %s/%s selects its pods with matchExpressions", kind, pod.Namespace, name)
+	}
+
+	return selector.MatchLabels, nil
+}
+
+// GetResource Function that gives a resource as unstructured content (nil if it does not exist)
+func GetResource(gvr schema.GroupVersionResource, namespace, name string) (map[string]interface{}, error) {
+	if K8sH.dynamicClient == nil {
+		return nil, errors.New("kubernetes client is not initialized")
+	}
+
+	obj, err := K8sH.dynamicClient.Resource(gvr).Namespace(namespace).Get(context.TODO(), name, v1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
 	}
 
-	return ret
+	return obj.Object, nil
 }
 
 // == //
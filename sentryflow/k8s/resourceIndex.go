@@ -0,0 +1,843 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package k8s
+
+import (
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/5gsec/SentryFlow/types"
+
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/cache"
+)
+
+// == //
+
+// Kinds of workloads
+const (
+	WorkloadKindPod         = "Pod"
+	WorkloadKindService     = "Service"
+	WorkloadKindDeployment  = "Deployment"
+	WorkloadKindReplicaSet  = "ReplicaSet"
+	WorkloadKindStatefulSet = "StatefulSet"
+	WorkloadKindDaemonSet   = "DaemonSet"
+	WorkloadKindJob         = "Job"
+	WorkloadKindCronJob     = "CronJob"
+	WorkloadKindNode        = "Node"
+)
+
+// maxClockSkew is the time by which API logs may predate the assignments of their IP addresses
+const maxClockSkew = 30 * time.Second
+
+// ownerRef Structure (the controller of a ReplicaSet or a Job)
+type ownerRef struct {
+	kind string
+	name string
+}
+
+// ipAssignment Structure (a pod or a service holding an IP address during an interval)
+type ipAssignment struct {
+	pod     *corev1.Pod
+	service *corev1.Service
+
+	from  time.Time
+	until time.Time // zero while the IP address is still held
+}
+
+// endpointRef Structure (an endpoint of a service in an EndpointSlice)
+type endpointRef struct {
+	slice     string // namespace/name of the EndpointSlice
+	namespace string
+	service   string
+	ports     map[string]bool
+	pod       string // name of the target pod, if any
+}
+
+// ResourceIndex Structure (pods, services, nodes and endpoints by IP address, and the owners of intermediate controllers)
+type ResourceIndex struct {
+	pods      map[string]*corev1.Pod
+	hostPods  map[string][]*corev1.Pod // pods on the host network, by node IP address
+	services  map[string]*corev1.Service
+	nodes     map[string]*corev1.Node
+	endpoints map[string][]*endpointRef
+	owners    map[string]ownerRef // kind/namespace/name -> controller
+
+	serviceAccounts map[string]map[k8stypes.UID]*corev1.Pod // namespace/service account -> pods (other than waypoint proxies)
+
+	history map[string][]*ipAssignment // IP address -> assignments (oldest first)
+
+	indexLock sync.RWMutex
+}
+
+// NewResourceIndex Function
+func NewResourceIndex() *ResourceIndex {
+	ri := &ResourceIndex{
+		pods:      make(map[string]*corev1.Pod),
+		hostPods:  make(map[string][]*corev1.Pod),
+		services:  make(map[string]*corev1.Service),
+		nodes:     make(map[string]*corev1.Node),
+		endpoints: make(map[string][]*endpointRef),
+		owners:    make(map[string]ownerRef),
+
+		serviceAccounts: make(map[string]map[k8stypes.UID]*corev1.Pod),
+
+		history: make(map[string][]*ipAssignment),
+
+		indexLock: sync.RWMutex{},
+	}
+
+	return ri
+}
+
+// == //
+
+// tombstone Function that unwraps objects deleted while an informer was disconnected
+func tombstone(obj interface{}) interface{} {
+	if deleted, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+		return deleted.Obj
+	}
+	return obj
+}
+
+// serviceIPs Function that gives the IP addresses of a service (load balancer IPs, or the cluster IP and external IPs)
+func serviceIPs(service *corev1.Service) []string {
+	ips := make([]string, 0)
+
+	if service.Spec.Type == corev1.ServiceTypeLoadBalancer {
+		for _, lbIngress := range service.Status.LoadBalancer.Ingress {
+			if lbIngress.IP != "" {
+				ips = append(ips, lbIngress.IP)
+			}
+		}
+		return ips
+	}
+
+	if service.Spec.ClusterIP != "" && service.Spec.ClusterIP != corev1.ClusterIPNone {
+		ips = append(ips, service.Spec.ClusterIP)
+	}
+	ips = append(ips, service.Spec.ExternalIPs...)
+
+	return ips
+}
+
+// podIPs Function that gives the IP addresses of a pod (both families for dual-stack pods)
+func podIPs(pod *corev1.Pod) []string {
+	ips := make([]string, 0, len(pod.Status.PodIPs))
+	for _, podIP := range pod.Status.PodIPs {
+		if podIP.IP != "" {
+			ips = append(ips, podIP.IP)
+		}
+	}
+
+	if len(ips) == 0 && pod.Status.PodIP != "" {
+		ips = append(ips, pod.Status.PodIP)
+	}
+
+	return ips
+}
+
+// nodeIPs Function that gives the internal and external IP addresses of a node
+func nodeIPs(node *corev1.Node) []string {
+	ips := make([]string, 0)
+	for _, address := range node.Status.Addresses {
+		if address.Type == corev1.NodeInternalIP || address.Type == corev1.NodeExternalIP {
+			ips = append(ips, address.Address)
+		}
+	}
+	return ips
+}
+
+// keepSet Function
+func keepSet(ips []string) map[string]bool {
+	keep := make(map[string]bool, len(ips))
+	for _, ip := range ips {
+		keep[ip] = true
+	}
+	return keep
+}
+
+// terminated Function that checks if a pod has finished (its IP address may already be given to another pod)
+func terminated(pod *corev1.Pod) bool {
+	return pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed
+}
+
+// addPod Function (replacing the previous version of the pod)
+// Pods on the host network share the IP addresses of their nodes, so they are kept apart
+// Finished pods (e.g., of completed Jobs) keep their IP addresses in their status, so they are released instead
+func (ri *ResourceIndex) addPod(oldPod, pod *corev1.Pod) {
+	ri.indexLock.Lock()
+	defer ri.indexLock.Unlock()
+
+	ips := podIPs(pod)
+	if terminated(pod) {
+		ips = nil
+		if oldPod == nil {
+			oldPod = pod // released if it was indexed before (e.g., on a resync)
+		}
+	}
+
+	if oldPod != nil {
+		ri.removePod(oldPod, keepSet(ips))
+	}
+
+	if !terminated(pod) {
+		ri.addServiceAccount(pod)
+	}
+
+	for _, ip := range ips {
+		if pod.Spec.HostNetwork {
+			ri.hostPods[ip] = append(withoutPod(ri.hostPods[ip], pod), pod)
+			continue
+		}
+		if ri.assign(ip, pod.UID, &ipAssignment{pod: pod, from: pod.CreationTimestamp.Time}) {
+			ri.pods[ip] = pod
+		}
+	}
+}
+
+// deletePod Function
+func (ri *ResourceIndex) deletePod(pod *corev1.Pod) {
+	ri.indexLock.Lock()
+	defer ri.indexLock.Unlock()
+
+	ri.removePod(pod, nil)
+}
+
+// removePod Function (for callers holding the lock, keeping the IP addresses to keep and the ones other pods took)
+func (ri *ResourceIndex) removePod(pod *corev1.Pod, keep map[string]bool) {
+	ri.removeServiceAccount(pod)
+
+	for _, ip := range podIPs(pod) {
+		if keep[ip] {
+			continue
+		}
+
+		if pod.Spec.HostNetwork {
+			if remaining := withoutPod(ri.hostPods[ip], pod); len(remaining) > 0 {
+				ri.hostPods[ip] = remaining
+			} else {
+				delete(ri.hostPods, ip)
+			}
+			continue
+		}
+
+		if current, ok := ri.pods[ip]; ok && current.UID == pod.UID {
+			delete(ri.pods, ip)
+		}
+		ri.release(ip, pod.UID, time.Now())
+	}
+}
+
+// serviceAccountKey Function
+func serviceAccountKey(namespace, serviceAccount string) string {
+	return namespace + "/" + serviceAccount
+}
+
+// addServiceAccount Function (for callers holding the lock)
+// Pods on the host network and waypoint proxies are left out, as identities of access logs do not stand for them
+func (ri *ResourceIndex) addServiceAccount(pod *corev1.Pod) {
+	if pod.Spec.HostNetwork || isWaypoint(pod.Labels) {
+		return
+	}
+
+	key := serviceAccountKey(pod.Namespace, pod.Spec.ServiceAccountName)
+	if _, ok := ri.serviceAccounts[key]; !ok {
+		ri.serviceAccounts[key] = make(map[k8stypes.UID]*corev1.Pod)
+	}
+	ri.serviceAccounts[key][pod.UID] = pod
+}
+
+// removeServiceAccount Function (for callers holding the lock)
+func (ri *ResourceIndex) removeServiceAccount(pod *corev1.Pod) {
+	key := serviceAccountKey(pod.Namespace, pod.Spec.ServiceAccountName)
+	if pods, ok := ri.serviceAccounts[key]; ok {
+		delete(pods, pod.UID)
+		if len(pods) == 0 {
+			delete(ri.serviceAccounts, key)
+		}
+	}
+}
+
+// withoutPod Function
+func withoutPod(pods []*corev1.Pod, pod *corev1.Pod) []*corev1.Pod {
+	remaining := make([]*corev1.Pod, 0, len(pods))
+	for _, current := range pods {
+		if current.UID != pod.UID {
+			remaining = append(remaining, current)
+		}
+	}
+	return remaining
+}
+
+// addService Function (replacing the previous version of the service)
+func (ri *ResourceIndex) addService(oldService, service *corev1.Service) {
+	ri.indexLock.Lock()
+	defer ri.indexLock.Unlock()
+
+	ips := serviceIPs(service)
+
+	if oldService != nil {
+		ri.removeService(oldService, keepSet(ips))
+	}
+	for _, ip := range ips {
+		if ri.assign(ip, service.UID, &ipAssignment{service: service, from: service.CreationTimestamp.Time}) {
+			ri.services[ip] = service
+		}
+	}
+}
+
+// deleteService Function
+func (ri *ResourceIndex) deleteService(service *corev1.Service) {
+	ri.indexLock.Lock()
+	defer ri.indexLock.Unlock()
+
+	ri.removeService(service, nil)
+}
+
+// removeService Function (for callers holding the lock)
+func (ri *ResourceIndex) removeService(service *corev1.Service, keep map[string]bool) {
+	for _, ip := range serviceIPs(service) {
+		if keep[ip] {
+			continue
+		}
+		if current, ok := ri.services[ip]; ok && current.UID == service.UID {
+			delete(ri.services, ip)
+		}
+		ri.release(ip, service.UID, time.Now())
+	}
+}
+
+// addNode Function (replacing the previous version of the node)
+func (ri *ResourceIndex) addNode(oldNode, node *corev1.Node) {
+	ri.indexLock.Lock()
+	defer ri.indexLock.Unlock()
+
+	ips := nodeIPs(node)
+
+	if oldNode != nil {
+		ri.removeNode(oldNode, keepSet(ips))
+	}
+	for _, ip := range ips {
+		ri.nodes[ip] = node
+	}
+}
+
+// deleteNode Function
+func (ri *ResourceIndex) deleteNode(node *corev1.Node) {
+	ri.indexLock.Lock()
+	defer ri.indexLock.Unlock()
+
+	ri.removeNode(node, nil)
+}
+
+// removeNode Function (for callers holding the lock)
+func (ri *ResourceIndex) removeNode(node *corev1.Node, keep map[string]bool) {
+	for _, ip := range nodeIPs(node) {
+		if current, ok := ri.nodes[ip]; ok && !keep[ip] && current.UID == node.UID {
+			delete(ri.nodes, ip)
+		}
+	}
+}
+
+// addEndpointSlice Function (replacing the previous version of the slice)
+func (ri *ResourceIndex) addEndpointSlice(oldSlice, slice *discoveryv1.EndpointSlice) {
+	ri.indexLock.Lock()
+	defer ri.indexLock.Unlock()
+
+	if oldSlice != nil {
+		ri.removeEndpointSlice(oldSlice)
+	}
+
+	service := slice.Labels[discoveryv1.LabelServiceName]
+	if service == "" {
+		return
+	}
+
+	ports := make(map[string]bool, len(slice.Ports))
+	for _, port := range slice.Ports {
+		if port.Port != nil {
+			ports[strconv.Itoa(int(*port.Port))] = true
+		}
+	}
+
+	for _, endpoint := range slice.Endpoints {
+		ref := &endpointRef{
+			slice:     slice.Namespace + "/" + slice.Name,
+			namespace: slice.Namespace,
+			service:   service,
+			ports:     ports,
+		}
+		if endpoint.TargetRef != nil && endpoint.TargetRef.Kind == "Pod" {
+			ref.pod = endpoint.TargetRef.Name
+		}
+
+		for _, ip := range endpoint.Addresses {
+			ri.endpoints[ip] = append(ri.endpoints[ip], ref)
+		}
+	}
+}
+
+// deleteEndpointSlice Function
+func (ri *ResourceIndex) deleteEndpointSlice(slice *discoveryv1.EndpointSlice) {
+	ri.indexLock.Lock()
+	defer ri.indexLock.Unlock()
+
+	ri.removeEndpointSlice(slice)
+}
+
+// removeEndpointSlice Function (for callers holding the lock)
+func (ri *ResourceIndex) removeEndpointSlice(slice *discoveryv1.EndpointSlice) {
+	key := slice.Namespace + "/" + slice.Name
+
+	for _, endpoint := range slice.Endpoints {
+		for _, ip := range endpoint.Addresses {
+			remaining := make([]*endpointRef, 0, len(ri.endpoints[ip]))
+			for _, ref := range ri.endpoints[ip] {
+				if ref.slice != key {
+					remaining = append(remaining, ref)
+				}
+			}
+
+			if len(remaining) > 0 {
+				ri.endpoints[ip] = remaining
+			} else {
+				delete(ri.endpoints, ip)
+			}
+		}
+	}
+}
+
+// retainScope Function that forgets the resources out of a scope
+// Pods are checked against the pod selector as well, informers only deliver the ones matching it from now on
+func (ri *ResourceIndex) retainScope(sc *Scope) {
+	keep := sc.allowed
+
+	ri.indexLock.Lock()
+	defer ri.indexLock.Unlock()
+
+	for _, pod := range ri.pods {
+		if !sc.selectsPod(pod) {
+			ri.removePod(pod, nil)
+		}
+	}
+
+	for ip, pods := range ri.hostPods {
+		for _, pod := range pods {
+			if !sc.selectsPod(pod) {
+				ri.removePod(pod, nil)
+			}
+		}
+		if len(ri.hostPods[ip]) == 0 {
+			delete(ri.hostPods, ip)
+		}
+	}
+
+	for _, service := range ri.services {
+		if !keep(service.Namespace) {
+			ri.removeService(service, nil)
+		}
+	}
+
+	for ip, refs := range ri.endpoints {
+		remaining := make([]*endpointRef, 0, len(refs))
+		for _, ref := range refs {
+			if keep(ref.namespace) {
+				remaining = append(remaining, ref)
+			}
+		}
+
+		if len(remaining) > 0 {
+			ri.endpoints[ip] = remaining
+		} else {
+			delete(ri.endpoints, ip)
+		}
+	}
+}
+
+// == //
+
+// uidOf Function
+func (a *ipAssignment) uidOf() k8stypes.UID {
+	if a.pod != nil {
+		return a.pod.UID
+	}
+	return a.service.UID
+}
+
+// createdAt Function
+func (a *ipAssignment) createdAt() time.Time {
+	if a.pod != nil {
+		return a.pod.CreationTimestamp.Time
+	}
+	return a.service.CreationTimestamp.Time
+}
+
+// assign Function that records a pod or a service holding an IP address (for callers holding the lock)
+// An open assignment of the same resource is refreshed, and the ones of other resources are closed
+// A resource does not take back an IP address it released, or one held by a resource created after it
+// (stale events of an older resource must not hide the current holder), in which case false is returned
+func (ri *ResourceIndex) assign(ip string, uid k8stypes.UID, assignment *ipAssignment) bool {
+	now := time.Now()
+
+	for _, current := range ri.history[ip] {
+		if current.uidOf() != uid {
+			if current.until.IsZero() && assignment.createdAt().Before(current.createdAt()) {
+				return false
+			}
+			continue
+		}
+		if !current.until.IsZero() {
+			return false
+		}
+		current.pod, current.service = assignment.pod, assignment.service
+		return true
+	}
+
+	for _, current := range ri.history[ip] {
+		if current.until.IsZero() {
+			current.until = now
+		}
+	}
+
+	// the IP address is not given before the resource exists, but resources may predate the previous holder's release
+	if last := len(ri.history[ip]); last > 0 && assignment.from.Before(ri.history[ip][last-1].until) {
+		assignment.from = ri.history[ip][last-1].until
+	}
+	if assignment.from.IsZero() {
+		assignment.from = now
+	}
+
+	ri.history[ip] = append(ri.history[ip], assignment)
+
+	return true
+}
+
+// release Function that closes the assignment of an IP address to a resource (for callers holding the lock)
+func (ri *ResourceIndex) release(ip string, uid k8stypes.UID, at time.Time) {
+	for _, current := range ri.history[ip] {
+		if current.until.IsZero() && current.uidOf() == uid {
+			current.until = at
+		}
+	}
+}
+
+// pruneHistory Function that forgets the assignments released before the given time
+func (ri *ResourceIndex) pruneHistory(before time.Time) {
+	ri.indexLock.Lock()
+	defer ri.indexLock.Unlock()
+
+	for ip, assignments := range ri.history {
+		kept := assignments[:0]
+		for _, assignment := range assignments {
+			if assignment.until.IsZero() || assignment.until.After(before) {
+				kept = append(kept, assignment)
+			}
+		}
+
+		if len(kept) == 0 {
+			delete(ri.history, ip)
+		} else {
+			ri.history[ip] = kept
+		}
+	}
+}
+
+// ownerKey Function
+func ownerKey(kind, namespace, name string) string {
+	return kind + "/" + namespace + "/" + name
+}
+
+// controllerOf Function that gives the controller among owner references
+func controllerOf(refs []v1.OwnerReference) (ownerRef, bool) {
+	for _, ref := range refs {
+		if ref.Controller != nil && *ref.Controller {
+			return ownerRef{kind: ref.Kind, name: ref.Name}, true
+		}
+	}
+	return ownerRef{}, false
+}
+
+// setOwner Function that keeps the controller of a ReplicaSet or a Job
+func (ri *ResourceIndex) setOwner(kind string, meta v1.Object) {
+	ri.indexLock.Lock()
+	defer ri.indexLock.Unlock()
+
+	key := ownerKey(kind, meta.GetNamespace(), meta.GetName())
+	if owner, ok := controllerOf(meta.GetOwnerReferences()); ok {
+		ri.owners[key] = owner
+	} else {
+		delete(ri.owners, key)
+	}
+}
+
+// deleteOwner Function
+func (ri *ResourceIndex) deleteOwner(kind string, meta v1.Object) {
+	ri.indexLock.Lock()
+	defer ri.indexLock.Unlock()
+
+	delete(ri.owners, ownerKey(kind, meta.GetNamespace(), meta.GetName()))
+}
+
+// == //
+
+// workloadOf Function that resolves a pod to its top-level owner (for callers holding the lock)
+// ReplicaSets resolve to Deployments and Jobs to CronJobs, through the known controllers first
+func (ri *ResourceIndex) workloadOf(pod *corev1.Pod) (string, string) {
+	owner, ok := controllerOf(pod.OwnerReferences)
+	if !ok {
+		return WorkloadKindPod, pod.Name
+	}
+
+	switch owner.kind {
+	case WorkloadKindReplicaSet:
+		if parent, ok := ri.owners[ownerKey(WorkloadKindReplicaSet, pod.Namespace, owner.name)]; ok {
+			return parent.kind, parent.name
+		}
+
+		// ReplicaSets of Deployments are named <deployment>-<pod-template-hash>
+		if hash := pod.Labels["pod-template-hash"]; hash != "" && strings.HasSuffix(owner.name, "-"+hash) {
+			return WorkloadKindDeployment, strings.TrimSuffix(owner.name, "-"+hash)
+		}
+	case WorkloadKindJob:
+		if parent, ok := ri.owners[ownerKey(WorkloadKindJob, pod.Namespace, owner.name)]; ok {
+			return parent.kind, parent.name
+		}
+	}
+
+	return owner.kind, owner.name
+}
+
+// resourceOf Function that describes a pod or a service with its top-level owner (for callers holding the lock)
+func (ri *ResourceIndex) resourceOf(pod *corev1.Pod, service *corev1.Service) types.K8sResource {
+	if pod != nil {
+		kind, name := ri.workloadOf(pod)
+		return types.K8sResource{
+			Type:         types.K8sResourceTypePod,
+			Namespace:    pod.Namespace,
+			Name:         pod.Name,
+			Labels:       pod.Labels,
+			Annotations:  pod.Annotations,
+			Containers:   containersOf(pod),
+			WorkloadKind: kind,
+			WorkloadName: name,
+		}
+	}
+
+	return types.K8sResource{
+		Type:         types.K8sResourceTypeService,
+		Namespace:    service.Namespace,
+		Name:         service.Name,
+		Labels:       service.Labels,
+		WorkloadKind: WorkloadKindService,
+		WorkloadName: service.Name,
+	}
+}
+
+// lookup Function that gives the Kubernetes resource having an IP address at the given time (now if zero)
+// Pod IPs and service VIPs come first, then pods on the host network and service endpoints serving the port, then nodes
+func (ri *ResourceIndex) lookup(ip, port string, at time.Time) (types.K8sResource, bool) {
+	ri.indexLock.RLock()
+	defer ri.indexLock.RUnlock()
+
+	if resource, ok := ri.lookupAssigned(ip, at); ok {
+		return resource, true
+	}
+
+	// a node IP address, more specific matches use the port
+	hostPods := ri.hostPods[ip]
+	for _, pod := range hostPods {
+		if servesPort(pod, port) {
+			return ri.resourceOf(pod, nil), true
+		}
+	}
+
+	if ref := endpointOf(ri.endpoints[ip], port); ref != nil {
+		for _, pod := range hostPods {
+			if pod.Namespace == ref.namespace && pod.Name == ref.pod {
+				return ri.resourceOf(pod, nil), true
+			}
+		}
+		return types.K8sResource{
+			Type:         types.K8sResourceTypeService,
+			Namespace:    ref.namespace,
+			Name:         ref.service,
+			Labels:       make(map[string]string),
+			WorkloadKind: WorkloadKindService,
+			WorkloadName: ref.service,
+		}, true
+	}
+
+	if node, ok := ri.nodes[ip]; ok {
+		return types.K8sResource{
+			Type:         types.K8sResourceTypeNode,
+			Name:         node.Name,
+			Labels:       node.Labels,
+			WorkloadKind: WorkloadKindNode,
+			WorkloadName: node.Name,
+		}, true
+	}
+
+	if len(hostPods) == 1 {
+		return ri.resourceOf(hostPods[0], nil), true
+	}
+
+	return types.K8sResource{}, false
+}
+
+// lookupAssigned Function that gives the pod or the service holding an IP address at the given time (for callers holding the lock)
+// The latest assignment covering the time wins, and the current holder is used for slightly earlier times (clock skew)
+func (ri *ResourceIndex) lookupAssigned(ip string, at time.Time) (types.K8sResource, bool) {
+	if !at.IsZero() {
+		assignments := ri.history[ip]
+		for idx := len(assignments) - 1; idx >= 0; idx-- {
+			assignment := assignments[idx]
+			if at.Before(assignment.from) || (!assignment.until.IsZero() && at.After(assignment.until)) {
+				continue
+			}
+			return ri.resourceOf(assignment.pod, assignment.service), true
+		}
+
+		for idx := len(assignments) - 1; idx >= 0; idx-- {
+			assignment := assignments[idx]
+			if assignment.until.IsZero() && !at.Before(assignment.from.Add(-maxClockSkew)) {
+				return ri.resourceOf(assignment.pod, assignment.service), true
+			}
+		}
+
+		// older than the history (or the IP address was never assigned)
+		return types.K8sResource{}, false
+	}
+
+	if pod, ok := ri.pods[ip]; ok {
+		return ri.resourceOf(pod, nil), true
+	}
+
+	if service, ok := ri.services[ip]; ok {
+		return ri.resourceOf(nil, service), true
+	}
+
+	return types.K8sResource{}, false
+}
+
+// containersOf Function that gives the names of the containers of a pod (with init containers, e.g., native sidecars)
+func containersOf(pod *corev1.Pod) []string {
+	containers := make([]string, 0, len(pod.Spec.InitContainers)+len(pod.Spec.Containers))
+	for _, container := range pod.Spec.InitContainers {
+		containers = append(containers, container.Name)
+	}
+	for _, container := range pod.Spec.Containers {
+		containers = append(containers, container.Name)
+	}
+	return containers
+}
+
+// servesPort Function that checks if a container of a pod listens on a port
+func servesPort(pod *corev1.Pod, port string) bool {
+	if port == "" {
+		return false
+	}
+
+	for _, container := range pod.Spec.Containers {
+		for _, containerPort := range container.Ports {
+			if strconv.Itoa(int(containerPort.ContainerPort)) == port || strconv.Itoa(int(containerPort.HostPort)) == port {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// endpointOf Function that gives the endpoint serving a port (or the only endpoint of the IP address)
+func endpointOf(refs []*endpointRef, port string) *endpointRef {
+	for _, ref := range refs {
+		if ref.ports[port] {
+			return ref
+		}
+	}
+
+	if len(refs) == 1 {
+		return refs[0]
+	}
+
+	return nil
+}
+
+// podsOf Function that gives the pods of workloads for which the given function returns true
+// Pods on the host network are left out since Istio does not inject sidecars into them
+func (ri *ResourceIndex) podsOf(match func(namespace, kind, name string) bool) []types.K8sResource {
+	ri.indexLock.RLock()
+	defer ri.indexLock.RUnlock()
+
+	seen := make(map[k8stypes.UID]bool)
+	resources := make([]types.K8sResource, 0)
+
+	// dual-stack pods are indexed by both of their IP addresses
+	for _, pod := range ri.pods {
+		if seen[pod.UID] {
+			continue
+		}
+		seen[pod.UID] = true
+
+		if kind, name := ri.workloadOf(pod); match(pod.Namespace, kind, name) {
+			resources = append(resources, ri.resourceOf(pod, nil))
+		}
+	}
+
+	return resources
+}
+
+// lookupServiceAccount Function that gives the pods (other than waypoint proxies) running with a service account and the number of their workloads
+func (ri *ResourceIndex) lookupServiceAccount(namespace, serviceAccount string) (types.K8sResource, int) {
+	ri.indexLock.RLock()
+	defer ri.indexLock.RUnlock()
+
+	resource := types.K8sResource{}
+	workloads := make(map[string]bool)
+
+	for _, pod := range ri.serviceAccounts[serviceAccountKey(namespace, serviceAccount)] {
+		kind, name := ri.workloadOf(pod)
+		if !workloads[kind+"/"+name] {
+			workloads[kind+"/"+name] = true
+			resource = ri.resourceOf(pod, nil)
+		}
+	}
+
+	return resource, len(workloads)
+}
+
+// findPod Function that gives the first pod in a namespace for which the given function returns true
+func (ri *ResourceIndex) findPod(namespace string, match func(pod *corev1.Pod, kind, name string) bool) *corev1.Pod {
+	ri.indexLock.RLock()
+	defer ri.indexLock.RUnlock()
+
+	for _, pod := range ri.pods {
+		if pod.Namespace != namespace {
+			continue
+		}
+		if kind, name := ri.workloadOf(pod); match(pod, kind, name) {
+			return pod
+		}
+	}
+
+	for _, pods := range ri.hostPods {
+		for _, pod := range pods {
+			if pod.Namespace != namespace {
+				continue
+			}
+			if kind, name := ri.workloadOf(pod); match(pod, kind, name) {
+				return pod
+			}
+		}
+	}
+
+	return nil
+}
+
+// == //
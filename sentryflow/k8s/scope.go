@@ -0,0 +1,209 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package k8s
+
+import (
+	"errors"
+	"sort"
+	"sync"
+
+	"github.com/5gsec/SentryFlow/config"
+
+	corev1 "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// == //
+
+// Scope Structure (namespaces and labels of the resources to watch, patch and process)
+type Scope struct {
+	cfg config.ScopeConfig
+
+	include map[string]bool
+	exclude map[string]bool
+
+	namespaceSelector labels.Selector // nil if every namespace is selected
+	rawNsSelector     string
+	podSelector       string
+	podLabels         labels.Selector // nil if every pod is selected
+
+	selected  map[string]bool // namespaces matching the namespace selector
+	scopeLock sync.RWMutex
+}
+
+// NewScope Function (everything in scope)
+func NewScope() *Scope {
+	sc := &Scope{
+		include: make(map[string]bool),
+		exclude: make(map[string]bool),
+
+		selected:  make(map[string]bool),
+		scopeLock: sync.RWMutex{},
+	}
+
+	return sc
+}
+
+// loadScope Function that builds a scope from its config
+func loadScope(cfg config.ScopeConfig) (*Scope, error) {
+	sc := NewScope()
+	sc.cfg = cfg
+
+	for _, namespace := range cfg.Namespaces {
+		sc.include[namespace] = true
+	}
+	for _, namespace := range cfg.ExcludeNamespaces {
+		sc.exclude[namespace] = true
+	}
+
+	if cfg.NamespaceSelector != "" {
+		// Namespaces are only watched (to match them with the selector) when none is included
+		if len(cfg.Namespaces) > 0 {
+			return nil, errors.New("namespaceSelector cannot be combined with namespaces (namespaces are not watched when some are included)")
+		}
+
+		selector, err := labels.Parse(cfg.NamespaceSelector)
+		if err != nil {
+			return nil, err
+		}
+		sc.namespaceSelector = selector
+		sc.rawNsSelector = cfg.NamespaceSelector
+	}
+
+	if cfg.PodSelector != "" {
+		selector, err := labels.Parse(cfg.PodSelector)
+		if err != nil {
+			return nil, err
+		}
+		sc.podSelector = cfg.PodSelector
+		sc.podLabels = selector
+	}
+
+	return sc, nil
+}
+
+// == //
+
+// configured Function that checks if anything is out of scope
+func (sc *Scope) configured() bool {
+	return len(sc.include) > 0 || len(sc.exclude) > 0 || sc.namespaceSelector != nil || sc.podSelector != ""
+}
+
+// namespaceScoped Function that checks if only some namespaces are watched (with namespace-scoped RBAC)
+func (sc *Scope) namespaceScoped() bool {
+	return len(sc.include) > 0
+}
+
+// allowed Function that checks a namespace against the lists of namespaces
+func (sc *Scope) allowed(namespace string) bool {
+	if len(sc.include) > 0 && !sc.include[namespace] {
+		return false
+	}
+	return !sc.exclude[namespace]
+}
+
+// selectsPod Function that checks a pod against the namespaces and the pod selector
+func (sc *Scope) selectsPod(pod *corev1.Pod) bool {
+	if !sc.allowed(pod.Namespace) {
+		return false
+	}
+	return sc.podLabels == nil || sc.podLabels.Matches(labels.Set(pod.Labels))
+}
+
+// inScope Function that checks a namespace against the lists of namespaces and the namespace selector
+func (sc *Scope) inScope(namespace string) bool {
+	if !sc.allowed(namespace) {
+		return false
+	}
+
+	if sc.namespaceSelector == nil {
+		return true
+	}
+
+	sc.scopeLock.RLock()
+	defer sc.scopeLock.RUnlock()
+
+	return sc.selected[namespace]
+}
+
+// watchNamespaces Function that gives the namespaces to watch (all namespaces unless some are included)
+func (sc *Scope) watchNamespaces() []string {
+	if len(sc.include) == 0 {
+		return []string{corev1.NamespaceAll}
+	}
+
+	namespaces := make([]string, 0, len(sc.include))
+	for namespace := range sc.include {
+		if !sc.exclude[namespace] {
+			namespaces = append(namespaces, namespace)
+		}
+	}
+	sort.Strings(namespaces)
+
+	return namespaces
+}
+
+// applyListOptions Function that narrows down a list or a watch of namespaced resources
+// Excluded namespaces are left out by the API server, and so are pods not matching the pod selector
+func (sc *Scope) applyListOptions(target, namespace string, options *v1.ListOptions) {
+	if namespace == corev1.NamespaceAll && len(sc.exclude) > 0 {
+		excluded := make([]string, 0, len(sc.exclude))
+		for ns := range sc.exclude {
+			excluded = append(excluded, ns)
+		}
+		sort.Strings(excluded)
+
+		selectors := make([]fields.Selector, 0, len(excluded))
+		for _, ns := range excluded {
+			selectors = append(selectors, fields.OneTermNotEqualSelector("metadata.namespace", ns))
+		}
+		options.FieldSelector = fields.AndSelectors(selectors...).String()
+	}
+
+	if target == "pods" && sc.podSelector != "" {
+		options.LabelSelector = sc.podSelector
+	}
+}
+
+// listOptions Function
+func (sc *Scope) listOptions(target, namespace string) v1.ListOptions {
+	options := v1.ListOptions{}
+	sc.applyListOptions(target, namespace, &options)
+	return options
+}
+
+// setNamespace Function that keeps track of the namespaces matching the namespace selector
+func (sc *Scope) setNamespace(namespace *corev1.Namespace) {
+	sc.scopeLock.Lock()
+	defer sc.scopeLock.Unlock()
+
+	if sc.namespaceSelector.Matches(labels.Set(namespace.Labels)) {
+		sc.selected[namespace.Name] = true
+	} else {
+		delete(sc.selected, namespace.Name)
+	}
+}
+
+// deleteNamespace Function
+func (sc *Scope) deleteNamespace(namespace *corev1.Namespace) {
+	sc.scopeLock.Lock()
+	defer sc.scopeLock.Unlock()
+
+	delete(sc.selected, namespace.Name)
+}
+
+// == //
+
+// ScopeConfigured Function that checks if some namespaces or pods are out of scope
+func ScopeConfigured() bool {
+	return K8sH.currentScope().configured()
+}
+
+// InScope Function that checks if the resources in a namespace are watched, patched and processed
+func InScope(namespace string) bool {
+	return K8sH.currentScope().inScope(namespace)
+}
+
+// == //
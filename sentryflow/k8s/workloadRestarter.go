@@ -0,0 +1,466 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package k8s
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/5gsec/SentryFlow/config"
+	"github.com/5gsec/SentryFlow/types"
+
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+)
+
+// == //
+
+// SidecarContainer is the name of the container of the Istio proxy
+const SidecarContainer = "istio-proxy"
+
+// sidecarInjectKey is the label (or the legacy annotation) of pods opting in or out of sidecar injection
+const sidecarInjectKey = "sidecar.istio.io/inject"
+
+// restartedAtAnnotation is the annotation of pod templates changed for restarting workloads (as kubectl rollout restart)
+const restartedAtAnnotation = "kubectl.kubernetes.io/restartedAt"
+
+// rolloutPollPeriod is the period for checking if a rollout is ready
+const rolloutPollPeriod = 2 * time.Second
+
+// workloadRef Structure
+type workloadRef struct {
+	kind      string
+	namespace string
+	name      string
+}
+
+// String Function
+func (w workloadRef) String() string {
+	return fmt.Sprintf("%s %s/%s", w.kind, w.namespace, w.name)
+}
+
+// SidecarStatus Structure (pods of a workload with and without the Istio proxy)
+type SidecarStatus struct {
+	Namespace    string
+	WorkloadKind string
+	WorkloadName string
+
+	Pods        int
+	WithSidecar int
+	Ambient     int // pods without the sidecar in the ambient mesh (and waypoint proxies)
+	OptedOut    int // pods with sidecar.istio.io/inject=false
+}
+
+// == //
+
+// HasSidecar Function that checks if a pod has the Istio proxy
+func HasSidecar(pod types.K8sResource) bool {
+	for _, container := range pod.Containers {
+		if container == SidecarContainer {
+			return true
+		}
+	}
+	return false
+}
+
+// optedOut Function that checks if a pod opted out of sidecar injection
+func optedOut(pod types.K8sResource) bool {
+	return pod.Labels[sidecarInjectKey] == "false" || pod.Annotations[sidecarInjectKey] == "false"
+}
+
+// NamespaceInjection Function that gives the label enabling sidecar injection (or the ambient mode) for each namespace in scope (empty if not enabled)
+func NamespaceInjection() (map[string]string, error) {
+	if K8sH.clientSet == nil {
+		return nil, errors.New("kubernetes client is not initialized")
+	}
+
+	sc := K8sH.currentScope()
+
+	namespaces, err := scopeNamespaces(sc)
+	if err != nil {
+		return nil, err
+	}
+
+	injection := make(map[string]string)
+	for _, namespace := range namespaces {
+		if !sc.inScope(namespace.Name) {
+			continue
+		}
+
+		switch {
+		case IsAmbientNamespace(namespace.Labels):
+			injection[namespace.Name] = DataplaneModeLabel + "=" + DataplaneModeAmbient
+		case namespace.Labels["istio-injection"] == "enabled":
+			injection[namespace.Name] = "istio-injection=enabled"
+		case namespace.Labels["istio-injection"] == "disabled":
+			injection[namespace.Name] = ""
+		case namespace.Labels["istio.io/rev"] != "":
+			injection[namespace.Name] = "istio.io/rev=" + namespace.Labels["istio.io/rev"]
+		default:
+			injection[namespace.Name] = ""
+		}
+	}
+
+	return injection, nil
+}
+
+// SidecarCoverage Function that gives the pods with and without the Istio proxy for each workload in scope
+func SidecarCoverage() []SidecarStatus {
+	sc := K8sH.currentScope()
+
+	pods := K8sH.index.podsOf(func(namespace, kind, name string) bool {
+		return sc.inScope(namespace)
+	})
+
+	statuses := make(map[workloadRef]*SidecarStatus)
+	for _, pod := range pods {
+		ref := workloadRef{kind: pod.WorkloadKind, namespace: pod.Namespace, name: pod.WorkloadName}
+
+		status, ok := statuses[ref]
+		if !ok {
+			status = &SidecarStatus{Namespace: ref.namespace, WorkloadKind: ref.kind, WorkloadName: ref.name}
+			statuses[ref] = status
+		}
+
+		status.Pods++
+		if HasSidecar(pod) {
+			status.WithSidecar++
+		} else if InAmbientMesh(pod) {
+			status.Ambient++
+		} else if optedOut(pod) {
+			status.OptedOut++
+		}
+	}
+
+	coverage := make([]SidecarStatus, 0, len(statuses))
+	for _, status := range statuses {
+		coverage = append(coverage, *status)
+	}
+
+	sort.Slice(coverage, func(i, j int) bool {
+		if coverage[i].Namespace != coverage[j].Namespace {
+			return coverage[i].Namespace < coverage[j].Namespace
+		}
+		if coverage[i].WorkloadKind != coverage[j].WorkloadKind {
+			return coverage[i].WorkloadKind < coverage[j].WorkloadKind
+		}
+		return coverage[i].WorkloadName < coverage[j].WorkloadName
+	})
+
+	return coverage
+}
+
+// == //
+
+// RestartWorkloads Function that restarts the workloads in patched namespaces gradually (in the background)
+// Restarts stop at the first rollout not ready within the timeout, so that a bad rollout does not spread
+func RestartWorkloads(stopChan chan struct{}, wg *sync.WaitGroup) bool {
+	restartCfg := config.GlobalConfig.Restarts
+
+	for _, kind := range restartCfg.Kinds {
+		if kind != WorkloadKindDeployment && kind != WorkloadKindStatefulSet && kind != WorkloadKindDaemonSet {
+			log.Printf("[RestartWorkloads] Unknown kind of workloads %q", kind)
+			return false
+		}
+	}
+
+	if _, err := labels.Parse(restartCfg.Selector); err != nil {
+		log.Printf("[RestartWorkloads] Invalid selector %q: %v", restartCfg.Selector, err)
+		return false
+	}
+
+	if restartCfg.MaxConcurrent < 1 {
+		restartCfg.MaxConcurrent = 1
+	}
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		restartWorkloads(restartCfg, stopChan)
+	}()
+
+	return true
+}
+
+// restartWorkloads Function
+func restartWorkloads(restartCfg config.RestartConfig, stopChan chan struct{}) {
+	workloads, err := selectWorkloads(restartCfg)
+	if err != nil {
+		log.Printf("[RestartWorkloads] Failed to get workloads: %v", err)
+		return
+	}
+
+	log.Printf("[RestartWorkloads] Restarting %d workloads (%d at a time)", len(workloads), restartCfg.MaxConcurrent)
+
+	queue := make(chan workloadRef)
+	halted := make(chan struct{})
+	haltOnce := sync.Once{}
+
+	workers := sync.WaitGroup{}
+	for i := 0; i < restartCfg.MaxConcurrent; i++ {
+		workers.Add(1)
+		go func() {
+			defer workers.Done()
+
+			for workload := range queue {
+				if err := restartWorkload(workload, restartCfg.Timeout, stopChan); err != nil {
+					log.Printf("[RestartWorkloads] Failed to restart %s: %v", workload, err)
+					haltOnce.Do(func() { close(halted) })
+					continue
+				}
+				log.Printf("[RestartWorkloads] Restarted %s", workload)
+			}
+		}()
+	}
+
+	pending := len(workloads)
+
+enqueue:
+	for _, workload := range workloads {
+		select {
+		case queue <- workload:
+			pending--
+		case <-halted:
+			break enqueue
+		case <-stopChan:
+			break enqueue
+		}
+	}
+	close(queue)
+	workers.Wait()
+
+	if pending > 0 {
+		log.Printf("[RestartWorkloads] Stopped restarting workloads (%d not restarted)", pending)
+	} else {
+		log.Print("[RestartWorkloads] Restarted all selected workloads")
+	}
+
+	// Report the workloads that still lack the Istio proxy (e.g., with sidecar.istio.io/inject=false)
+	for _, status := range SidecarCoverage() {
+		if missing := status.Pods - status.WithSidecar - status.Ambient; missing > 0 {
+			log.Printf("[RestartWorkloads] %s %s/%s lacks a sidecar (%d of %d pods without %s)",
+				status.WorkloadKind, status.Namespace, status.WorkloadName, missing, status.Pods, SidecarContainer)
+		}
+	}
+}
+
+// restartNamespaces Function that gives the namespaces to restart workloads in
+// Only namespaces with sidecar injection enabled are taken, as restarting workloads elsewhere cannot add sidecars
+func restartNamespaces(restartCfg config.RestartConfig) ([]string, error) {
+	sc := K8sH.currentScope()
+
+	injection, err := NamespaceInjection()
+	if err != nil {
+		return nil, err
+	}
+
+	candidates := make([]string, 0, len(injection))
+	if len(restartCfg.Namespaces) > 0 {
+		candidates = restartCfg.Namespaces
+	} else {
+		for namespace := range injection {
+			candidates = append(candidates, namespace)
+		}
+	}
+
+	// Never restart the control plane, system workloads or SentryFlow itself
+	excluded := map[string]bool{istioRootNamespace: true, "kube-system": true, ownNamespace(): true}
+
+	namespaces := make([]string, 0, len(candidates))
+	for _, namespace := range candidates {
+		label := injection[namespace]
+		if excluded[namespace] || !sc.inScope(namespace) || label == "" || strings.HasPrefix(label, DataplaneModeLabel) {
+			continue
+		}
+		namespaces = append(namespaces, namespace)
+	}
+
+	sort.Strings(namespaces)
+
+	return namespaces, nil
+}
+
+// selectWorkloads Function that gives the workloads to restart by the namespaces, the selector and the kinds
+func selectWorkloads(restartCfg config.RestartConfig) ([]workloadRef, error) {
+	namespaces, err := restartNamespaces(restartCfg)
+	if err != nil {
+		return nil, err
+	}
+
+	kinds := make(map[string]bool)
+	for _, kind := range restartCfg.Kinds {
+		kinds[kind] = true
+	}
+
+	options := v1.ListOptions{LabelSelector: restartCfg.Selector}
+
+	workloads := make([]workloadRef, 0)
+	for _, namespace := range namespaces {
+		if kinds[WorkloadKindDeployment] {
+			deployments, err := K8sH.clientSet.AppsV1().Deployments(namespace).List(context.TODO(), options)
+			if err != nil {
+				return nil, err
+			}
+			for _, deployment := range deployments.Items {
+				workloads = append(workloads, workloadRef{WorkloadKindDeployment, deployment.Namespace, deployment.Name})
+			}
+		}
+
+		if kinds[WorkloadKindStatefulSet] {
+			statefulSets, err := K8sH.clientSet.AppsV1().StatefulSets(namespace).List(context.TODO(), options)
+			if err != nil {
+				return nil, err
+			}
+			for _, statefulSet := range statefulSets.Items {
+				if statefulSet.Spec.UpdateStrategy.Type == appsv1.OnDeleteStatefulSetStrategyType {
+					log.Printf("[RestartWorkloads] Skipping StatefulSet %s/%s (pods are only updated when deleted)", statefulSet.Namespace, statefulSet.Name)
+					continue
+				}
+				workloads = append(workloads, workloadRef{WorkloadKindStatefulSet, statefulSet.Namespace, statefulSet.Name})
+			}
+		}
+
+		if kinds[WorkloadKindDaemonSet] {
+			daemonSets, err := K8sH.clientSet.AppsV1().DaemonSets(namespace).List(context.TODO(), options)
+			if err != nil {
+				return nil, err
+			}
+			for _, daemonSet := range daemonSets.Items {
+				if daemonSet.Spec.UpdateStrategy.Type == appsv1.OnDeleteDaemonSetStrategyType {
+					log.Printf("[RestartWorkloads] Skipping DaemonSet %s/%s (pods are only updated when deleted)", daemonSet.Namespace, daemonSet.Name)
+					continue
+				}
+				workloads = append(workloads, workloadRef{WorkloadKindDaemonSet, daemonSet.Namespace, daemonSet.Name})
+			}
+		}
+	}
+
+	// Skip (optionally) the workloads already having sidecars (or in the ambient mesh)
+	missing := make(map[workloadRef]bool)
+	if restartCfg.OnlyMissingSidecar {
+		for _, status := range SidecarCoverage() {
+			if status.WithSidecar+status.Ambient < status.Pods {
+				missing[workloadRef{status.WorkloadKind, status.Namespace, status.WorkloadName}] = true
+			}
+		}
+	}
+
+	selected := make([]workloadRef, 0, len(workloads))
+	for _, workload := range workloads {
+		if restartCfg.OnlyMissingSidecar && !missing[workload] {
+			continue
+		}
+		selected = append(selected, workload)
+	}
+
+	return selected, nil
+}
+
+// == //
+
+// restartWorkload Function that restarts a workload and waits for its rollout to become ready
+func restartWorkload(workload workloadRef, timeout time.Duration, stopChan chan struct{}) error {
+	patch := fmt.Sprintf(`{"spec":{"template":{"metadata":{"annotations":{%q:%q}}}}}`, restartedAtAnnotation, time.Now().Format(time.RFC3339))
+
+	apps := K8sH.clientSet.AppsV1()
+
+	var err error
+	switch workload.kind {
+	case WorkloadKindDeployment:
+		_, err = apps.Deployments(workload.namespace).Patch(context.TODO(), workload.name, k8stypes.StrategicMergePatchType, []byte(patch), v1.PatchOptions{})
+	case WorkloadKindStatefulSet:
+		_, err = apps.StatefulSets(workload.namespace).Patch(context.TODO(), workload.name, k8stypes.StrategicMergePatchType, []byte(patch), v1.PatchOptions{})
+	case WorkloadKindDaemonSet:
+		_, err = apps.DaemonSets(workload.namespace).Patch(context.TODO(), workload.name, k8stypes.StrategicMergePatchType, []byte(patch), v1.PatchOptions{})
+	}
+	if err != nil {
+		return err
+	}
+
+	return waitForRollout(workload, timeout, stopChan)
+}
+
+// waitForRollout Function that waits until every pod of a workload is updated and available
+func waitForRollout(workload workloadRef, timeout time.Duration, stopChan chan struct{}) error {
+	deadline := time.NewTimer(timeout)
+	defer deadline.Stop()
+
+	ticker := time.NewTicker(rolloutPollPeriod)
+	defer ticker.Stop()
+
+	for {
+		ready, err := rolloutReady(workload)
+		if err != nil {
+			return err
+		} else if ready {
+			return nil
+		}
+
+		select {
+		case <-ticker.C:
+		case <-deadline.C:
+			return fmt.Errorf("rollout is not ready after %v", timeout)
+		case <-stopChan:
+			return fmt.Errorf("stopped waiting for the rollout")
+		}
+	}
+}
+
+// rolloutReady Function
+func rolloutReady(workload workloadRef) (bool, error) {
+	apps := K8sH.clientSet.AppsV1()
+
+	switch workload.kind {
+	case WorkloadKindDeployment:
+		deployment, err := apps.Deployments(workload.namespace).Get(context.TODO(), workload.name, v1.GetOptions{})
+		if err != nil {
+			return false, err
+		}
+
+		replicas := int32(1)
+		if deployment.Spec.Replicas != nil {
+			replicas = *deployment.Spec.Replicas
+		}
+
+		status := deployment.Status
+		return status.ObservedGeneration >= deployment.Generation &&
+			status.UpdatedReplicas == replicas && status.Replicas == replicas && status.AvailableReplicas == replicas, nil
+
+	case WorkloadKindStatefulSet:
+		statefulSet, err := apps.StatefulSets(workload.namespace).Get(context.TODO(), workload.name, v1.GetOptions{})
+		if err != nil {
+			return false, err
+		}
+
+		replicas := int32(1)
+		if statefulSet.Spec.Replicas != nil {
+			replicas = *statefulSet.Spec.Replicas
+		}
+
+		status := statefulSet.Status
+		return status.ObservedGeneration >= statefulSet.Generation &&
+			status.UpdatedReplicas == replicas && status.ReadyReplicas == replicas && status.CurrentRevision == status.UpdateRevision, nil
+
+	case WorkloadKindDaemonSet:
+		daemonSet, err := apps.DaemonSets(workload.namespace).Get(context.TODO(), workload.name, v1.GetOptions{})
+		if err != nil {
+			return false, err
+		}
+
+		status := daemonSet.Status
+		return status.ObservedGeneration >= daemonSet.Generation &&
+			status.UpdatedNumberScheduled == status.DesiredNumberScheduled && status.NumberAvailable == status.DesiredNumberScheduled, nil
+	}
+
+	return false, fmt.Errorf("unknown kind of workloads %q", workload.kind)
+}
+
+// == //
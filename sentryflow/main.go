@@ -3,6 +3,11 @@
 package main
 
 import (
+	"flag"
+	"os"
+
+	"github.com/5gsec/SentryFlow/cli"
+	"github.com/5gsec/SentryFlow/config"
 	"github.com/5gsec/SentryFlow/core"
 )
 
@@ -11,5 +16,14 @@ import (
 // ========== //
 
 func main() {
+	// Load the configuration from flags, environment variables and the config file
+	flag.Parse()
+	_ = config.LoadConfig()
+
+	// Run a subcommand if given (e.g., sentryflow openapi -all)
+	if flag.NArg() > 0 {
+		os.Exit(cli.Run(flag.Args()))
+	}
+
 	core.SentryFlow()
 }
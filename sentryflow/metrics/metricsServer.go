@@ -0,0 +1,71 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package metrics
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/5gsec/SentryFlow/config"
+
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// == //
+
+// metricsServer is the HTTP server for Prometheus metrics
+var metricsServer *http.Server
+
+// StartMetricsServer Function
+func StartMetricsServer() bool {
+	if config.GlobalConfig.MetricsPort == "" {
+		log.Print("[Metrics] Prometheus metrics are disabled")
+		return true
+	}
+
+	metricsAddr := fmt.Sprintf("%s:%s", config.GlobalConfig.MetricsAddr, config.GlobalConfig.MetricsPort)
+
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.Handler())
+
+	metricsServer = &http.Server{
+		Addr:              metricsAddr,
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	go func() {
+		if err := metricsServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Printf("[Metrics] Failed to serve Prometheus metrics at %s: %v", metricsAddr, err)
+		}
+	}()
+
+	log.Printf("[Metrics] Serving Prometheus metrics (%s/metrics)", metricsAddr)
+
+	return true
+}
+
+// StopMetricsServer Function
+func StopMetricsServer() bool {
+	if metricsServer == nil {
+		return true
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := metricsServer.Shutdown(ctx); err != nil {
+		log.Printf("[Metrics] Failed to stop serving Prometheus metrics: %v", err)
+		return false
+	}
+
+	log.Print("[Metrics] Stopped serving Prometheus metrics")
+
+	return true
+}
+
+// == //
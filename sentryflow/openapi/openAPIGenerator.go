@@ -0,0 +1,248 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package openapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/5gsec/SentryFlow/inventory"
+	"github.com/5gsec/SentryFlow/types"
+
+	"gopkg.in/yaml.v2"
+)
+
+// == //
+
+// Output formats
+const (
+	FormatYAML = "yaml"
+	FormatJSON = "json"
+)
+
+// openAPIVersion is the version of generated OpenAPI documents
+const openAPIVersion = "3.0.3"
+
+// == //
+
+// schemaFromType Function that converts an inferred value type into an OpenAPI schema
+func schemaFromType(valueType string) *Schema {
+	switch valueType {
+	case inventory.ValueTypeInteger:
+		return &Schema{Type: "integer", Format: "int64"}
+	case inventory.ValueTypeNumber:
+		return &Schema{Type: "number"}
+	case inventory.ValueTypeBoolean:
+		return &Schema{Type: "boolean"}
+	case inventory.ValueTypeUUID:
+		return &Schema{Type: "string", Format: "uuid"}
+	case inventory.ValueTypeDate:
+		return &Schema{Type: "string", Format: "date"}
+	case inventory.ValueTypeDateTime:
+		return &Schema{Type: "string", Format: "date-time"}
+	}
+	return &Schema{Type: "string"}
+}
+
+// exampleFromType Function that converts an observed value into an example of the given type
+func exampleFromType(valueType, value string) interface{} {
+	switch valueType {
+	case inventory.ValueTypeInteger:
+		if v, err := strconv.ParseInt(value, 10, 64); err == nil {
+			return v
+		}
+	case inventory.ValueTypeNumber:
+		if v, err := strconv.ParseFloat(value, 64); err == nil {
+			return v
+		}
+	case inventory.ValueTypeBoolean:
+		if v, err := strconv.ParseBool(value); err == nil {
+			return v
+		}
+	}
+	return value
+}
+
+// operationID Function that makes an operation ID from a method and a path template (e.g., getUsersUserId)
+func operationID(method, pathTemplate string) string {
+	var sb strings.Builder
+	sb.WriteString(strings.ToLower(method))
+
+	upper := true
+	for _, c := range pathTemplate {
+		if unicode.IsLetter(c) || unicode.IsDigit(c) {
+			if upper {
+				sb.WriteRune(unicode.ToUpper(c))
+			} else {
+				sb.WriteRune(c)
+			}
+			upper = false
+		} else {
+			upper = true
+		}
+	}
+
+	return sb.String()
+}
+
+// newParameter Function
+func newParameter(name, in string, required bool, param *inventory.ParamRecord) *Parameter {
+	valueType := param.DominantType()
+
+	return &Parameter{
+		Name:     name,
+		In:       in,
+		Required: required,
+		Schema:   schemaFromType(valueType),
+		Example:  exampleFromType(valueType, param.Example),
+	}
+}
+
+// newOperation Function that builds an OpenAPI operation from an API record of the inventory
+func newOperation(record *inventory.APIRecord) *Operation {
+	op := &Operation{
+		OperationID: operationID(record.Method, record.PathTemplate),
+		Parameters:  make([]*Parameter, 0),
+		Responses:   make(map[string]*Response),
+		Observed: &Observation{
+			CallCount: record.CallCount,
+			FirstSeen: record.FirstSeen,
+			LastSeen:  record.LastSeen,
+			Callers:   make([]string, 0, len(record.Callers)),
+		},
+	}
+
+	// path parameters in the order they appear in the template
+	for _, segment := range strings.Split(record.PathTemplate, "/") {
+		if !strings.HasPrefix(segment, "{") || !strings.HasSuffix(segment, "}") {
+			continue
+		}
+
+		name := strings.Trim(segment, "{}")
+		param, ok := record.PathParams[name]
+		if !ok {
+			param = &inventory.ParamRecord{Types: map[string]uint64{inventory.ValueTypeString: 1}}
+		}
+		op.Parameters = append(op.Parameters, newParameter(name, "path", true, param))
+	}
+
+	// query parameters seen in every call are considered required
+	queryNames := make([]string, 0, len(record.QueryParams))
+	for name := range record.QueryParams {
+		queryNames = append(queryNames, name)
+	}
+	sort.Strings(queryNames)
+
+	for _, name := range queryNames {
+		param := record.QueryParams[name]
+		op.Parameters = append(op.Parameters, newParameter(name, "query", param.Count >= record.CallCount, param))
+	}
+
+	// request bodies
+	if len(record.RequestContentTypes) > 0 {
+		op.RequestBody = &RequestBody{Content: make(map[string]*MediaType)}
+		for contentType := range record.RequestContentTypes {
+			op.RequestBody.Content[contentType] = &MediaType{}
+		}
+	}
+
+	// responses
+	for code := range record.StatusCodes {
+		description := http.StatusText(int(code))
+		if description == "" {
+			description = "Observed response"
+		}
+
+		response := &Response{Description: description}
+		if contentTypes, ok := record.ResponseContentTypes[code]; ok && len(contentTypes) > 0 {
+			response.Content = make(map[string]*MediaType)
+			for contentType := range contentTypes {
+				response.Content[contentType] = &MediaType{}
+			}
+		}
+
+		op.Responses[strconv.Itoa(int(code))] = response
+	}
+	if len(op.Responses) == 0 {
+		op.Responses["default"] = &Response{Description: "Observed response"}
+	}
+
+	for caller := range record.Callers {
+		op.Observed.Callers = append(op.Observed.Callers, caller)
+	}
+	sort.Strings(op.Observed.Callers)
+
+	return op
+}
+
+// GenerateSpec Function that infers an OpenAPI document for a workload from the API inventory
+func GenerateSpec(namespace, workload string) (*Document, bool) {
+	doc := &Document{
+		OpenAPI: openAPIVersion,
+		Info: Info{
+			Title:   fmt.Sprintf("%s.%s", workload, namespace),
+			Version: "observed",
+		},
+		Paths: make(map[string]*PathItem),
+	}
+
+	var firstSeen, lastSeen int64
+
+	inventory.VisitAPIs(namespace, workload, func(_ string, record *inventory.APIRecord) {
+		// gRPC methods are not HTTP APIs that OpenAPI can describe
+		if record.APIType == types.APITypeGRPC {
+			return
+		}
+
+		item, ok := doc.Paths[record.PathTemplate]
+		if !ok {
+			item = &PathItem{}
+			doc.Paths[record.PathTemplate] = item
+		}
+
+		// GraphQL operations share the same path, so the most called one describes it
+		if op := item.Operation(record.Method); op != nil && op.Observed != nil && op.Observed.CallCount >= record.CallCount {
+			return
+		}
+
+		if !item.SetOperation(record.Method, newOperation(record)) {
+			return
+		}
+
+		if firstSeen == 0 || record.FirstSeen < firstSeen {
+			firstSeen = record.FirstSeen
+		}
+		if record.LastSeen > lastSeen {
+			lastSeen = record.LastSeen
+		}
+	})
+
+	if len(doc.Paths) == 0 {
+		return nil, false
+	}
+
+	doc.Info.Description = fmt.Sprintf("Inferred by SentryFlow from the traffic observed between %s and %s",
+		time.Unix(firstSeen, 0).UTC().Format(time.RFC3339), time.Unix(lastSeen, 0).UTC().Format(time.RFC3339))
+
+	return doc, true
+}
+
+// MarshalSpec Function that converts an OpenAPI document into the given format
+func MarshalSpec(doc *Document, format string) ([]byte, error) {
+	switch strings.ToLower(format) {
+	case FormatJSON:
+		return json.MarshalIndent(doc, "", "  ")
+	case FormatYAML, "":
+		return yaml.Marshal(doc)
+	}
+
+	return nil, fmt.Errorf("unsupported format %q (yaml|json)", format)
+}
+
+// == //
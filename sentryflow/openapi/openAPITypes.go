@@ -0,0 +1,160 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package openapi
+
+import (
+	"strings"
+)
+
+// == //
+
+// Document Structure (OpenAPI 3 document, only the parts SentryFlow deals with)
+type Document struct {
+	OpenAPI string               `json:"openapi" yaml:"openapi"`
+	Info    Info                 `json:"info" yaml:"info"`
+	Servers []Server             `json:"servers,omitempty" yaml:"servers,omitempty"`
+	Paths   map[string]*PathItem `json:"paths" yaml:"paths"`
+}
+
+// Info Structure
+type Info struct {
+	Title       string `json:"title" yaml:"title"`
+	Description string `json:"description,omitempty" yaml:"description,omitempty"`
+	Version     string `json:"version" yaml:"version"`
+}
+
+// Server Structure
+type Server struct {
+	URL string `json:"url" yaml:"url"`
+}
+
+// PathItem Structure
+type PathItem struct {
+	Parameters []*Parameter `json:"parameters,omitempty" yaml:"parameters,omitempty"`
+
+	Get     *Operation `json:"get,omitempty" yaml:"get,omitempty"`
+	Put     *Operation `json:"put,omitempty" yaml:"put,omitempty"`
+	Post    *Operation `json:"post,omitempty" yaml:"post,omitempty"`
+	Delete  *Operation `json:"delete,omitempty" yaml:"delete,omitempty"`
+	Options *Operation `json:"options,omitempty" yaml:"options,omitempty"`
+	Head    *Operation `json:"head,omitempty" yaml:"head,omitempty"`
+	Patch   *Operation `json:"patch,omitempty" yaml:"patch,omitempty"`
+	Trace   *Operation `json:"trace,omitempty" yaml:"trace,omitempty"`
+}
+
+// Operation Structure
+type Operation struct {
+	OperationID string               `json:"operationId,omitempty" yaml:"operationId,omitempty"`
+	Summary     string               `json:"summary,omitempty" yaml:"summary,omitempty"`
+	Deprecated  bool                 `json:"deprecated,omitempty" yaml:"deprecated,omitempty"`
+	Parameters  []*Parameter         `json:"parameters,omitempty" yaml:"parameters,omitempty"`
+	RequestBody *RequestBody         `json:"requestBody,omitempty" yaml:"requestBody,omitempty"`
+	Responses   map[string]*Response `json:"responses" yaml:"responses"`
+
+	Observed *Observation `json:"x-sentryflow-observed,omitempty" yaml:"x-sentryflow-observed,omitempty"`
+}
+
+// Parameter Structure
+type Parameter struct {
+	Name     string      `json:"name" yaml:"name"`
+	In       string      `json:"in" yaml:"in"`
+	Required bool        `json:"required,omitempty" yaml:"required,omitempty"`
+	Schema   *Schema     `json:"schema,omitempty" yaml:"schema,omitempty"`
+	Example  interface{} `json:"example,omitempty" yaml:"example,omitempty"`
+}
+
+// Schema Structure
+type Schema struct {
+	Type   string `json:"type,omitempty" yaml:"type,omitempty"`
+	Format string `json:"format,omitempty" yaml:"format,omitempty"`
+}
+
+// RequestBody Structure
+type RequestBody struct {
+	Required bool                  `json:"required,omitempty" yaml:"required,omitempty"`
+	Content  map[string]*MediaType `json:"content" yaml:"content"`
+}
+
+// Response Structure
+type Response struct {
+	Description string                `json:"description" yaml:"description"`
+	Content     map[string]*MediaType `json:"content,omitempty" yaml:"content,omitempty"`
+}
+
+// MediaType Structure
+type MediaType struct {
+	Schema *Schema `json:"schema,omitempty" yaml:"schema,omitempty"`
+}
+
+// Observation Structure (x-sentryflow-observed extension)
+type Observation struct {
+	CallCount uint64   `json:"callCount" yaml:"callCount"`
+	FirstSeen int64    `json:"firstSeen" yaml:"firstSeen"`
+	LastSeen  int64    `json:"lastSeen" yaml:"lastSeen"`
+	Callers   []string `json:"callers,omitempty" yaml:"callers,omitempty"`
+}
+
+// == //
+
+// Operation Function that gives the operation for an HTTP method
+func (item *PathItem) Operation(method string) *Operation {
+	switch strings.ToUpper(method) {
+	case "GET":
+		return item.Get
+	case "PUT":
+		return item.Put
+	case "POST":
+		return item.Post
+	case "DELETE":
+		return item.Delete
+	case "OPTIONS":
+		return item.Options
+	case "HEAD":
+		return item.Head
+	case "PATCH":
+		return item.Patch
+	case "TRACE":
+		return item.Trace
+	}
+	return nil
+}
+
+// SetOperation Function that sets the operation for an HTTP method
+func (item *PathItem) SetOperation(method string, op *Operation) bool {
+	switch strings.ToUpper(method) {
+	case "GET":
+		item.Get = op
+	case "PUT":
+		item.Put = op
+	case "POST":
+		item.Post = op
+	case "DELETE":
+		item.Delete = op
+	case "OPTIONS":
+		item.Options = op
+	case "HEAD":
+		item.Head = op
+	case "PATCH":
+		item.Patch = op
+	case "TRACE":
+		item.Trace = op
+	default:
+		return false
+	}
+	return true
+}
+
+// Operations Function that gives all operations defined in a path item keyed by HTTP method
+func (item *PathItem) Operations() map[string]*Operation {
+	ops := make(map[string]*Operation)
+
+	for _, method := range []string{"GET", "PUT", "POST", "DELETE", "OPTIONS", "HEAD", "PATCH", "TRACE"} {
+		if op := item.Operation(method); op != nil {
+			ops[method] = op
+		}
+	}
+
+	return ops
+}
+
+// == //
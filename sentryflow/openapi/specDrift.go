@@ -0,0 +1,147 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package openapi
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/5gsec/SentryFlow/internal/detection"
+	"github.com/5gsec/SentryFlow/inventory"
+	"github.com/5gsec/SentryFlow/protobuf"
+	"github.com/5gsec/SentryFlow/types"
+)
+
+// == //
+
+// Types of spec drift findings
+const (
+	FindingCategorySpecDrift = "SpecDrift"
+
+	FindingTypeShadowAPI            = "ShadowAPI"
+	FindingTypeZombieAPI            = "ZombieAPI"
+	FindingTypeUndeclaredMethod     = "UndeclaredMethod"
+	FindingTypeUndeclaredStatusCode = "UndeclaredStatusCode"
+)
+
+// driftReportPeriod is the period for reporting the same finding again
+const driftReportPeriod = time.Hour
+
+// driftReports keeps when each finding was reported last
+var driftReports = detection.NewSuppressor(driftReportPeriod)
+
+// == //
+
+// shouldReport Function that suppresses the same finding within driftReportPeriod
+func shouldReport(key string) bool {
+	return driftReports.ShouldReport(key, time.Now())
+}
+
+// isStatusCodeDeclared Function that checks a status code with the responses of an operation (e.g., 404, 4XX, default)
+func isStatusCodeDeclared(op *Operation, code int32) bool {
+	if len(op.Responses) == 0 {
+		return true
+	}
+
+	codeStr := strconv.Itoa(int(code))
+
+	for declared := range op.Responses {
+		declared = strings.ToUpper(declared)
+
+		if declared == "DEFAULT" || declared == codeStr {
+			return true
+		}
+
+		if len(declared) == 3 && strings.HasSuffix(declared, "XX") && len(codeStr) == 3 && declared[0] == codeStr[0] {
+			return true
+		}
+	}
+
+	return false
+}
+
+// newDriftFinding Function
+func newDriftFinding(apiLog *protobuf.APILog, workload, findingType, severity, path, description string, spec *registeredSpec) *protobuf.APIFinding {
+	return &protobuf.APIFinding{
+		TimeStamp:   apiLog.TimeStamp,
+		Category:    FindingCategorySpecDrift,
+		Type:        findingType,
+		Severity:    severity,
+		Description: description,
+
+		Namespace: apiLog.DstNamespace,
+		Workload:  workload,
+		Method:    apiLog.Method,
+		Path:      path,
+
+		Evidence: map[string]string{
+			"spec":         spec.source,
+			"observedPath": apiLog.Path,
+			"responseCode": strconv.Itoa(int(apiLog.ResponseCode)),
+			"caller":       fmt.Sprintf("%s/%s", apiLog.SrcNamespace, inventory.SrcWorkload(apiLog)),
+		},
+		Samples: []*protobuf.APILog{apiLog},
+	}
+}
+
+// CheckAPILog Function that compares an API log with the API spec registered for its destination workload
+func CheckAPILog(apiLog *protobuf.APILog) []*protobuf.APIFinding {
+	// API specs are OpenAPI documents, which do not describe gRPC methods
+	if apiLog.ApiType == types.APITypeGRPC {
+		return nil
+	}
+
+	workload := inventory.DstWorkload(apiLog)
+
+	spec, ok := SpecReg.lookupSpec(apiLog.DstNamespace, workload)
+	if !ok {
+		return nil
+	}
+
+	findings := make([]*protobuf.APIFinding, 0)
+	path, _ := inventory.SplitPath(apiLog.Path)
+	prefix := fmt.Sprintf("%s/%s %s", apiLog.DstNamespace, workload, apiLog.Method)
+
+	// Shadow API: observed but not in the spec
+	sp, ok := spec.matchPath(path)
+	if !ok {
+		pathTemplate, _ := inventory.PathTemplate(path)
+		if shouldReport(fmt.Sprintf("%s %s %s", FindingTypeShadowAPI, prefix, pathTemplate)) {
+			findings = append(findings, newDriftFinding(apiLog, workload, FindingTypeShadowAPI, types.SeverityMedium, pathTemplate,
+				fmt.Sprintf("%s %s is called on %s/%s but not declared in its API spec", apiLog.Method, pathTemplate, apiLog.DstNamespace, workload), spec))
+		}
+		return findings
+	}
+
+	// Spec violation: the method is not declared for the path
+	op := sp.item.Operation(apiLog.Method)
+	if op == nil {
+		if shouldReport(fmt.Sprintf("%s %s %s", FindingTypeUndeclaredMethod, prefix, sp.template)) {
+			findings = append(findings, newDriftFinding(apiLog, workload, FindingTypeUndeclaredMethod, types.SeverityMedium, sp.template,
+				fmt.Sprintf("%s is not declared for %s on %s/%s", apiLog.Method, sp.template, apiLog.DstNamespace, workload), spec))
+		}
+		return findings
+	}
+
+	// Zombie API: deprecated but still called
+	if op.Deprecated || spec.deprecated {
+		if shouldReport(fmt.Sprintf("%s %s %s", FindingTypeZombieAPI, prefix, sp.template)) {
+			findings = append(findings, newDriftFinding(apiLog, workload, FindingTypeZombieAPI, types.SeverityMedium, sp.template,
+				fmt.Sprintf("%s %s on %s/%s is deprecated but still called", apiLog.Method, sp.template, apiLog.DstNamespace, workload), spec))
+		}
+	}
+
+	// Spec violation: the status code is not declared for the operation
+	if !isStatusCodeDeclared(op, apiLog.ResponseCode) {
+		if shouldReport(fmt.Sprintf("%s %s %s %d", FindingTypeUndeclaredStatusCode, prefix, sp.template, apiLog.ResponseCode)) {
+			findings = append(findings, newDriftFinding(apiLog, workload, FindingTypeUndeclaredStatusCode, types.SeverityLow, sp.template,
+				fmt.Sprintf("%s %s on %s/%s responded %d, which is not declared in its API spec", apiLog.Method, sp.template, apiLog.DstNamespace, workload, apiLog.ResponseCode), spec))
+		}
+	}
+
+	return findings
+}
+
+// == //
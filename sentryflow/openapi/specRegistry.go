@@ -0,0 +1,351 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package openapi
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/5gsec/SentryFlow/config"
+	"github.com/5gsec/SentryFlow/inventory"
+	"github.com/5gsec/SentryFlow/k8s"
+
+	"gopkg.in/yaml.v2"
+)
+
+// == //
+
+// SpecAnnotation is the annotation of a pod (template) pointing at a ConfigMap with its OpenAPI spec ("<configmap>" or "<configmap>/<key>")
+const SpecAnnotation = "sentryflow.io/openapi-configmap"
+
+// SpecReg global reference for API Spec Registry
+var SpecReg *SpecRegistry
+
+// init Function
+func init() {
+	SpecReg = NewSpecRegistry()
+}
+
+// specPath Structure
+type specPath struct {
+	template string
+	matcher  *regexp.Regexp
+	literals int
+	item     *PathItem
+}
+
+// registeredSpec Structure
+type registeredSpec struct {
+	source     string
+	deprecated bool
+	basePath   string
+	paths      []*specPath
+}
+
+// SpecRegistry Structure
+type SpecRegistry struct {
+	stopChan chan struct{}
+
+	specs     map[string]*registeredSpec
+	specsLock sync.RWMutex
+}
+
+// NewSpecRegistry Function
+func NewSpecRegistry() *SpecRegistry {
+	sr := &SpecRegistry{
+		stopChan: make(chan struct{}),
+
+		specs:     make(map[string]*registeredSpec),
+		specsLock: sync.RWMutex{},
+	}
+
+	return sr
+}
+
+// == //
+
+// StartSpecRegistry Function
+func StartSpecRegistry(wg *sync.WaitGroup) bool {
+	SpecReg.reloadSpecs()
+
+	// keep reloading API specs
+	go refreshSpecs(wg)
+
+	log.Print("[SpecRegistry] Started API Spec Registry")
+
+	return true
+}
+
+// StopSpecRegistry Function
+func StopSpecRegistry() bool {
+	close(SpecReg.stopChan)
+
+	log.Print("[SpecRegistry] Stopped API Spec Registry")
+
+	return true
+}
+
+// refreshSpecs Function
+func refreshSpecs(wg *sync.WaitGroup) {
+	wg.Add(1)
+
+	ticker := time.NewTicker(time.Duration(config.GlobalConfig.APISpecRefreshPeriod) * time.Second)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			SpecReg.reloadSpecs()
+		case <-SpecReg.stopChan:
+			wg.Done()
+			return
+		}
+	}
+}
+
+// == //
+
+// specKey Function
+func specKey(namespace, workload string) string {
+	return namespace + "/" + workload
+}
+
+// reloadSpecs Function that loads API specs from the config file and annotated pods
+func (sr *SpecRegistry) reloadSpecs() {
+	specs := make(map[string]*registeredSpec)
+
+	// API specs given in the config file
+	for _, specCfg := range config.GlobalConfig.APISpecs {
+		var spec *registeredSpec
+		var err error
+
+		if specCfg.File != "" {
+			spec, err = loadSpecFile(specCfg.File)
+		} else if specCfg.ConfigMap != "" {
+			spec, err = loadSpecConfigMap(specCfg.Namespace, specCfg.ConfigMap, specCfg.Key)
+		} else {
+			err = errors.New("either file or configMap is required")
+		}
+
+		if err != nil {
+			log.Printf("[SpecRegistry] Failed to load the API spec for %s/%s: %v", specCfg.Namespace, specCfg.Workload, err)
+			continue
+		}
+
+		spec.deprecated = specCfg.Deprecated
+		specs[specKey(specCfg.Namespace, specCfg.Workload)] = spec
+	}
+
+	// API specs given by pod annotations (the config file takes precedence)
+	pods, err := k8s.ListAnnotatedPods(SpecAnnotation)
+	if err != nil {
+		log.Printf("[SpecRegistry] Failed to list annotated pods: %v", err)
+	}
+
+	for _, pod := range pods {
+		key := specKey(pod.Namespace, inventory.WorkloadOf(pod.WorkloadKind, pod.WorkloadName, pod.Name, pod.Labels))
+		if _, ok := specs[key]; ok {
+			continue
+		}
+
+		name, dataKey, _ := strings.Cut(pod.Annotations[SpecAnnotation], "/")
+
+		spec, err := loadSpecConfigMap(pod.Namespace, name, dataKey)
+		if err != nil {
+			log.Printf("[SpecRegistry] Failed to load the API spec for %s (%s/%s): %v", key, pod.Namespace, pod.Name, err)
+			continue
+		}
+
+		specs[key] = spec
+	}
+
+	sr.specsLock.Lock()
+	sr.specs = specs
+	sr.specsLock.Unlock()
+
+	if config.GlobalConfig.Debug {
+		log.Printf("[SpecRegistry] Loaded %d API specs", len(specs))
+	}
+}
+
+// lookupSpec Function
+func (sr *SpecRegistry) lookupSpec(namespace, workload string) (*registeredSpec, bool) {
+	sr.specsLock.RLock()
+	defer sr.specsLock.RUnlock()
+
+	spec, ok := sr.specs[specKey(namespace, workload)]
+	return spec, ok
+}
+
+// IsDocumented Function that tells whether a workload has an API spec and whether the spec declares an endpoint
+func IsDocumented(namespace, workload, method, pathTemplate string) (bool, bool) {
+	spec, ok := SpecReg.lookupSpec(namespace, workload)
+	if !ok {
+		return false, false
+	}
+
+	sp, ok := spec.matchPath(pathTemplate)
+	if !ok {
+		return true, false
+	}
+
+	return true, sp.item.Operation(method) != nil
+}
+
+// == //
+
+// loadSpecFile Function
+func loadSpecFile(fileName string) (*registeredSpec, error) {
+	data, err := os.ReadFile(filepath.Clean(fileName))
+	if err != nil {
+		return nil, err
+	}
+
+	return parseSpec(fileName, data)
+}
+
+// loadSpecConfigMap Function
+func loadSpecConfigMap(namespace, name, key string) (*registeredSpec, error) {
+	data, err := k8s.GetConfigMapData(namespace, name)
+	if err != nil {
+		return nil, err
+	}
+
+	source := fmt.Sprintf("configmap/%s/%s", namespace, name)
+
+	if key != "" {
+		spec, ok := data[key]
+		if !ok {
+			return nil, fmt.Errorf("unable to find key %q in %s", key, source)
+		}
+		return parseSpec(source+"/"+key, []byte(spec))
+	}
+
+	// take the first key that looks like an OpenAPI spec
+	keys := make([]string, 0, len(data))
+	for k := range data {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		ext := strings.ToLower(filepath.Ext(k))
+		if ext == ".yaml" || ext == ".yml" || ext == ".json" || len(data) == 1 {
+			return parseSpec(source+"/"+k, []byte(data[k]))
+		}
+	}
+
+	return nil, fmt.Errorf("unable to find an OpenAPI spec in %s", source)
+}
+
+// parseSpec Function that parses an OpenAPI document (YAML or JSON) and prepares path matchers
+func parseSpec(source string, data []byte) (*registeredSpec, error) {
+	var doc Document
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+
+	if !strings.HasPrefix(doc.OpenAPI, "3.") {
+		return nil, fmt.Errorf("unsupported OpenAPI version %q", doc.OpenAPI)
+	}
+
+	spec := &registeredSpec{
+		source: source,
+		paths:  make([]*specPath, 0, len(doc.Paths)),
+	}
+
+	// take the base path from the first server (e.g., https://example.com/api/v1 -> /api/v1)
+	if len(doc.Servers) > 0 {
+		if serverURL, err := url.Parse(doc.Servers[0].URL); err == nil {
+			spec.basePath = strings.TrimSuffix(serverURL.Path, "/")
+		}
+	}
+
+	for template, item := range doc.Paths {
+		if item == nil {
+			continue
+		}
+
+		matcher, literals, err := compilePathTemplate(template)
+		if err != nil {
+			return nil, fmt.Errorf("invalid path %q: %v", template, err)
+		}
+
+		spec.paths = append(spec.paths, &specPath{
+			template: template,
+			matcher:  matcher,
+			literals: literals,
+			item:     item,
+		})
+	}
+
+	// concrete paths are matched before templated ones
+	sort.Slice(spec.paths, func(i, j int) bool {
+		if spec.paths[i].literals != spec.paths[j].literals {
+			return spec.paths[i].literals > spec.paths[j].literals
+		}
+		return spec.paths[i].template < spec.paths[j].template
+	})
+
+	return spec, nil
+}
+
+// templateParam is the regular expression for path parameters in OpenAPI path templates
+var templateParam = regexp.MustCompile(`\{[^/{}]+\}`)
+
+// compilePathTemplate Function that converts an OpenAPI path template into a regular expression
+func compilePathTemplate(template string) (*regexp.Regexp, int, error) {
+	var sb strings.Builder
+	sb.WriteString("^")
+
+	literals := 0
+	last := 0
+
+	for _, loc := range templateParam.FindAllStringIndex(template, -1) {
+		literal := template[last:loc[0]]
+		sb.WriteString(regexp.QuoteMeta(literal))
+		sb.WriteString("[^/]+")
+		literals += len(literal)
+		last = loc[1]
+	}
+
+	sb.WriteString(regexp.QuoteMeta(template[last:]))
+	literals += len(template) - last
+
+	sb.WriteString("/?$")
+
+	matcher, err := regexp.Compile(sb.String())
+	return matcher, literals, err
+}
+
+// matchPath Function that finds the path item of a spec for a concrete path
+func (spec *registeredSpec) matchPath(path string) (*specPath, bool) {
+	if spec.basePath != "" {
+		if !strings.HasPrefix(path, spec.basePath) {
+			return nil, false
+		}
+		path = strings.TrimPrefix(path, spec.basePath)
+		if path == "" {
+			path = "/"
+		}
+	}
+
+	for _, sp := range spec.paths {
+		if sp.matcher.MatchString(path) {
+			return sp, true
+		}
+	}
+
+	return nil, false
+}
+
+// == //
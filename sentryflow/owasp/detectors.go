@@ -0,0 +1,345 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package owasp
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/5gsec/SentryFlow/internal/detection"
+	"github.com/5gsec/SentryFlow/inventory"
+	"github.com/5gsec/SentryFlow/protobuf"
+	"github.com/5gsec/SentryFlow/types"
+)
+
+// == //
+
+const (
+	// enumerationWindow is the time window in which object IDs called by a caller are compared
+	enumerationWindow = 5 * time.Minute
+
+	// maxIDGap is the largest gap between two IDs that still counts as walking sequential IDs
+	maxIDGap = 2
+
+	// minSequentialRatio is the ratio of sequential IDs required to report enumeration
+	minSequentialRatio = 0.8
+
+	// minAuthSamples is the number of calls to an endpoint required to know if it usually needs credentials
+	minAuthSamples = 50
+
+	// maxAuthSamples is the number of calls after which older calls weigh less
+	maxAuthSamples = 10000
+
+	// exposureAlpha is the smoothing factor of the usual response size of an endpoint
+	exposureAlpha = 0.05
+
+	// minExposureSamples is the number of responses required to know the usual response size of an endpoint
+	minExposureSamples = 50
+
+	// minExposureBytes is the response size below which excessive data exposure is not reported
+	minExposureBytes = 64 * 1024
+
+	// burstWindow is the time window in which requests by a caller are counted
+	burstWindow = 10 * time.Second
+
+	// burstAlpha is the smoothing factor of the usual requests by a caller within a burst window
+	burstAlpha = 0.1
+
+	// minBurstSamples is the number of windows required before comparing bursts with the usual requests
+	minBurstSamples = 10
+)
+
+// == //
+
+// objectCall Structure
+type objectCall struct {
+	seen         time.Time
+	responseCode int32
+}
+
+// enumerationState Structure (object IDs called by a caller on an endpoint)
+type enumerationState struct {
+	ids      map[int64]objectCall
+	lastSeen time.Time
+}
+
+// observe Function
+func (es *enumerationState) observe(id int64, responseCode int32, now time.Time, maxIDs int) {
+	for knownID, call := range es.ids {
+		if now.Sub(call.seen) > enumerationWindow {
+			delete(es.ids, knownID)
+		}
+	}
+
+	if _, ok := es.ids[id]; ok || len(es.ids) < maxIDs {
+		es.ids[id] = objectCall{seen: now, responseCode: responseCode}
+	}
+	es.lastSeen = now
+}
+
+// analyze Function that gives the sorted IDs, the ratio of sequential IDs and the number of successful calls
+func (es *enumerationState) analyze() ([]int64, float64, int) {
+	ids := make([]int64, 0, len(es.ids))
+	succeeded := 0
+
+	for id, call := range es.ids {
+		ids = append(ids, id)
+		if call.responseCode > 0 && call.responseCode < 400 {
+			succeeded++
+		}
+	}
+
+	if len(ids) < 2 {
+		return ids, 0, succeeded
+	}
+
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	sequential := 0
+	for idx := 1; idx < len(ids); idx++ {
+		if ids[idx]-ids[idx-1] <= maxIDGap {
+			sequential++
+		}
+	}
+
+	return ids, float64(sequential) / float64(len(ids)-1), succeeded
+}
+
+// detectEnumeration Function that reports a caller walking sequential object IDs (BOLA)
+func (sd *SecurityDetectors) detectEnumeration(t target, apiLog *protobuf.APILog, now time.Time) *protobuf.APIFinding {
+	threshold := sd.settings.EnumerationThreshold
+
+	for name, value := range t.params {
+		id, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			continue
+		}
+
+		key := fmt.Sprintf("%s -> %s {%s}", t.caller, t.endpointKey(), name)
+
+		state, ok := sd.enumerations[key]
+		if !ok {
+			if len(sd.enumerations) >= maxTracked {
+				return nil
+			}
+			state = &enumerationState{ids: make(map[int64]objectCall)}
+			sd.enumerations[key] = state
+		}
+
+		state.observe(id, apiLog.ResponseCode, now, 4*threshold)
+
+		ids, ratio, succeeded := state.analyze()
+		if len(ids) < threshold || ratio < minSequentialRatio {
+			continue
+		}
+
+		if !sd.shouldReport(FindingTypeObjectIDEnumeration+" "+key, now) {
+			continue
+		}
+
+		// objects returned to the caller are worse than probing denied by authorization
+		severity := types.SeverityMedium
+		if succeeded*2 >= len(ids) {
+			severity = types.SeverityHigh
+		}
+
+		return newFinding(t, FindingTypeObjectIDEnumeration, severity,
+			fmt.Sprintf("%s called %s %s with %d sequential %s values within %s", t.caller, t.method, t.endpoint, len(ids), name, enumerationWindow),
+			map[string]string{
+				"parameter":       name,
+				"distinctIds":     strconv.Itoa(len(ids)),
+				"idRange":         fmt.Sprintf("%d-%d", ids[0], ids[len(ids)-1]),
+				"sequentialRatio": strconv.FormatFloat(ratio, 'f', 2, 64),
+				"succeeded":       strconv.Itoa(succeeded),
+				"window":          enumerationWindow.String(),
+			}, apiLog, now)
+	}
+
+	return nil
+}
+
+// == //
+
+// endpointState Structure (what is usual for an endpoint)
+type endpointState struct {
+	calls         float64
+	authenticated float64
+
+	responseBytes detection.EWMA
+
+	lastSeen time.Time
+}
+
+// endpointStateOf Function (nil if too many endpoints are tracked)
+func (sd *SecurityDetectors) endpointStateOf(t target, now time.Time) *endpointState {
+	key := t.endpointKey()
+
+	state, ok := sd.endpoints[key]
+	if !ok {
+		if len(sd.endpoints) >= maxTracked {
+			return nil
+		}
+		state = &endpointState{}
+		sd.endpoints[key] = state
+	}
+	state.lastSeen = now
+
+	return state
+}
+
+// detectMissingAuth Function that reports successful calls without credentials to endpoints that usually get them
+func (sd *SecurityDetectors) detectMissingAuth(t target, apiLog *protobuf.APILog, now time.Time) *protobuf.APIFinding {
+	state := sd.endpointStateOf(t, now)
+	if state == nil {
+		return nil
+	}
+
+	authenticated := inventory.HasCredentials(apiLog.RequestHeaders)
+
+	var finding *protobuf.APIFinding
+
+	if !authenticated && !inventory.IsError(apiLog) && state.calls >= minAuthSamples {
+		ratio := state.authenticated / state.calls
+		if ratio >= sd.settings.AuthRatio && sd.shouldReport(FindingTypeMissingAuthentication+" "+t.caller+" -> "+t.endpointKey(), now) {
+			finding = newFinding(t, FindingTypeMissingAuthentication, types.SeverityHigh,
+				fmt.Sprintf("%s called %s %s on %s/%s successfully without credentials, unlike %.0f%% of calls", t.caller, t.method, t.endpoint, t.namespace, t.workload, ratio*100),
+				map[string]string{
+					"authenticatedRatio": strconv.FormatFloat(ratio, 'f', 3, 64),
+					"calls":              strconv.FormatFloat(state.calls, 'f', 0, 64),
+					"responseCode":       strconv.Itoa(int(apiLog.ResponseCode)),
+				}, apiLog, now)
+		}
+	}
+
+	state.calls++
+	if authenticated {
+		state.authenticated++
+	}
+
+	// older calls weigh less so that an endpoint becoming public is learned
+	if state.calls >= maxAuthSamples {
+		state.calls /= 2
+		state.authenticated /= 2
+	}
+
+	return finding
+}
+
+// == //
+
+// detectExposure Function that reports responses much larger than usual for an endpoint
+func (sd *SecurityDetectors) detectExposure(t target, apiLog *protobuf.APILog, now time.Time) *protobuf.APIFinding {
+	if apiLog.ResponseBytes == 0 || inventory.IsError(apiLog) {
+		return nil
+	}
+
+	state := sd.endpointStateOf(t, now)
+	if state == nil {
+		return nil
+	}
+
+	size := float64(apiLog.ResponseBytes)
+	usual := state.responseBytes
+
+	state.responseBytes.Update(size, exposureAlpha)
+
+	if usual.Samples < minExposureSamples || size < minExposureBytes {
+		return nil
+	}
+
+	factor := sd.settings.ExposureFactor
+	if size < factor*usual.Mean || size < usual.Mean+4*usual.StdDev(0) {
+		return nil
+	}
+
+	if !sd.shouldReport(FindingTypeExcessiveDataExposure+" "+t.caller+" -> "+t.endpointKey(), now) {
+		return nil
+	}
+
+	severity := types.SeverityMedium
+	if size >= 10*factor*usual.Mean {
+		severity = types.SeverityHigh
+	}
+
+	return newFinding(t, FindingTypeExcessiveDataExposure, severity,
+		fmt.Sprintf("%s %s on %s/%s returned %d bytes to %s, %.1f times the usual size", t.method, t.endpoint, t.namespace, t.workload, apiLog.ResponseBytes, t.caller, size/usual.Mean),
+		map[string]string{
+			"responseBytes": strconv.FormatUint(apiLog.ResponseBytes, 10),
+			"usualBytes":    strconv.FormatFloat(usual.Mean, 'f', 0, 64),
+			"stdDev":        strconv.FormatFloat(usual.StdDev(0), 'f', 0, 64),
+		}, apiLog, now)
+}
+
+// == //
+
+// burstState Structure (requests by a caller to a workload)
+type burstState struct {
+	windowStart time.Time
+	requests    int
+	rateLimited int
+	reported    bool
+
+	usual detection.EWMA
+
+	lastSeen time.Time
+}
+
+// detectBurst Function that reports a caller sending far more requests to a workload than usual
+func (sd *SecurityDetectors) detectBurst(t target, apiLog *protobuf.APILog, now time.Time) *protobuf.APIFinding {
+	key := fmt.Sprintf("%s -> %s/%s", t.caller, t.namespace, t.workload)
+
+	state, ok := sd.bursts[key]
+	if !ok {
+		if len(sd.bursts) >= maxTracked {
+			return nil
+		}
+		state = &burstState{windowStart: now}
+		sd.bursts[key] = state
+	}
+	state.lastSeen = now
+
+	if now.Sub(state.windowStart) >= burstWindow {
+		state.usual.Update(float64(state.requests), burstAlpha)
+		*state = burstState{windowStart: now, usual: state.usual, lastSeen: now}
+	}
+
+	state.requests++
+	if apiLog.ResponseCode == 429 {
+		state.rateLimited++
+	}
+
+	if state.reported || state.requests < sd.settings.BurstRequests {
+		return nil
+	}
+	if state.usual.Samples >= minBurstSamples && float64(state.requests) < sd.settings.BurstFactor*state.usual.Mean {
+		return nil
+	}
+
+	state.reported = true
+
+	if !sd.shouldReport(FindingTypeResourceConsumption+" "+key, now) {
+		return nil
+	}
+
+	// rate limiting in place makes a burst less of a concern
+	severity := types.SeverityMedium
+	if state.requests >= 10*sd.settings.BurstRequests {
+		severity = types.SeverityHigh
+	}
+	if state.rateLimited*2 >= state.requests {
+		severity = types.SeverityLow
+	}
+
+	return newFinding(t, FindingTypeResourceConsumption, severity,
+		fmt.Sprintf("%s sent %d requests to %s/%s within %s", t.caller, state.requests, t.namespace, t.workload, burstWindow),
+		map[string]string{
+			"requests":      strconv.Itoa(state.requests),
+			"usualRequests": strconv.FormatFloat(state.usual.Mean, 'f', 1, 64),
+			"rateLimited":   strconv.Itoa(state.rateLimited),
+			"window":        burstWindow.String(),
+		}, apiLog, now)
+}
+
+// == //
@@ -0,0 +1,288 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package owasp
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/5gsec/SentryFlow/config"
+	"github.com/5gsec/SentryFlow/graph"
+	"github.com/5gsec/SentryFlow/internal/detection"
+	"github.com/5gsec/SentryFlow/inventory"
+	"github.com/5gsec/SentryFlow/protobuf"
+)
+
+// == //
+
+// Detectors
+const (
+	DetectorBOLA        = "bola"
+	DetectorAuth        = "auth"
+	DetectorExposure    = "exposure"
+	DetectorConsumption = "consumption"
+)
+
+// Types of security findings
+const (
+	FindingCategorySecurity = "APISecurity"
+
+	FindingTypeObjectIDEnumeration   = "ObjectIDEnumeration"
+	FindingTypeMissingAuthentication = "MissingAuthentication"
+	FindingTypeExcessiveDataExposure = "ExcessiveDataExposure"
+	FindingTypeResourceConsumption   = "ResourceConsumptionBurst"
+)
+
+// owaspRisks are the risks of the OWASP API Security Top 10 (2023) that findings relate to
+var owaspRisks = map[string]string{
+	FindingTypeObjectIDEnumeration:   "API1:2023 Broken Object Level Authorization",
+	FindingTypeMissingAuthentication: "API2:2023 Broken Authentication",
+	FindingTypeExcessiveDataExposure: "API3:2023 Broken Object Property Level Authorization",
+	FindingTypeResourceConsumption:   "API4:2023 Unrestricted Resource Consumption",
+}
+
+const (
+	// maxTracked is the maximum number of callers or endpoints tracked by each detector
+	maxTracked = 10000
+
+	// idleTimeout is the time after which the state of an idle caller or endpoint is forgotten
+	idleTimeout = time.Hour
+
+	// reportPeriod is the period for reporting the same finding again
+	reportPeriod = 15 * time.Minute
+)
+
+// SecD global reference for Security Detectors
+var SecD *SecurityDetectors
+
+// init Function
+func init() {
+	SecD = NewSecurityDetectors()
+}
+
+// SecurityDetectors Structure
+type SecurityDetectors struct {
+	stopChan chan struct{}
+
+	settings config.SecurityDetectorsConfig
+	enabled  map[string]bool
+
+	enumerations map[string]*enumerationState
+	endpoints    map[string]*endpointState
+	bursts       map[string]*burstState
+
+	reports *detection.Suppressor // findings reported within reportPeriod
+
+	detectorsLock sync.Mutex
+}
+
+// NewSecurityDetectors Function
+func NewSecurityDetectors() *SecurityDetectors {
+	sd := &SecurityDetectors{
+		stopChan: make(chan struct{}),
+
+		enabled: make(map[string]bool),
+
+		enumerations: make(map[string]*enumerationState),
+		endpoints:    make(map[string]*endpointState),
+		bursts:       make(map[string]*burstState),
+
+		reports: detection.NewSuppressor(reportPeriod),
+
+		detectorsLock: sync.Mutex{},
+	}
+
+	return sd
+}
+
+// == //
+
+// StartSecurityDetectors Function
+func StartSecurityDetectors(wg *sync.WaitGroup) bool {
+	if err := LoadDetectors(config.GlobalConfig.SecurityDetectors); err != nil {
+		log.Printf("[SecurityDetectors] Failed to load security detectors: %v", err)
+		return false
+	}
+
+	// forget idle callers and endpoints
+	go cleanUpDetectors(wg)
+
+	log.Print("[SecurityDetectors] Started Security Detectors")
+
+	return true
+}
+
+// StopSecurityDetectors Function
+func StopSecurityDetectors() bool {
+	close(SecD.stopChan)
+
+	log.Print("[SecurityDetectors] Stopped Security Detectors")
+
+	return true
+}
+
+// LoadDetectors Function that replaces the settings of the detectors (learned state is kept)
+func LoadDetectors(detectorsCfg config.SecurityDetectorsConfig) error {
+	enabled := map[string]bool{DetectorBOLA: true, DetectorAuth: true, DetectorExposure: true, DetectorConsumption: true}
+
+	for _, name := range detectorsCfg.Disabled {
+		if _, ok := enabled[name]; !ok {
+			return fmt.Errorf("unknown detector %q (bola|auth|exposure|consumption)", name)
+		}
+		enabled[name] = false
+	}
+
+	if detectorsCfg.EnumerationThreshold < 2 {
+		return fmt.Errorf("enumerationThreshold should be 2 or more")
+	}
+	if detectorsCfg.AuthRatio <= 0 || detectorsCfg.AuthRatio > 1 {
+		return fmt.Errorf("authRatio should be between 0 and 1")
+	}
+	if detectorsCfg.ExposureFactor <= 1 {
+		return fmt.Errorf("exposureFactor should be more than 1")
+	}
+	if detectorsCfg.BurstRequests <= 0 || detectorsCfg.BurstFactor <= 1 {
+		return fmt.Errorf("burstRequests should be positive and burstFactor more than 1")
+	}
+
+	SecD.detectorsLock.Lock()
+	defer SecD.detectorsLock.Unlock()
+
+	SecD.settings = detectorsCfg
+	SecD.enabled = enabled
+
+	return nil
+}
+
+// cleanUpDetectors Function
+func cleanUpDetectors(wg *sync.WaitGroup) {
+	wg.Add(1)
+
+	ticker := time.NewTicker(time.Minute)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			SecD.cleanUp(time.Now())
+		case <-SecD.stopChan:
+			wg.Done()
+			return
+		}
+	}
+}
+
+// cleanUp Function
+func (sd *SecurityDetectors) cleanUp(now time.Time) {
+	sd.detectorsLock.Lock()
+	defer sd.detectorsLock.Unlock()
+
+	for key, state := range sd.enumerations {
+		if now.Sub(state.lastSeen) > idleTimeout {
+			delete(sd.enumerations, key)
+		}
+	}
+	for key, state := range sd.endpoints {
+		if now.Sub(state.lastSeen) > idleTimeout {
+			delete(sd.endpoints, key)
+		}
+	}
+	for key, state := range sd.bursts {
+		if now.Sub(state.lastSeen) > idleTimeout {
+			delete(sd.bursts, key)
+		}
+	}
+}
+
+// == //
+
+// target Structure (the caller and the endpoint of an API log)
+type target struct {
+	caller    string
+	namespace string
+	workload  string
+	method    string
+	endpoint  string
+	params    map[string]string
+}
+
+// targetOf Function
+func targetOf(apiLog *protobuf.APILog) target {
+	endpoint, params := inventory.EndpointTemplate(apiLog)
+
+	return target{
+		caller:    graph.NodeID(apiLog.SrcNamespace, inventory.SrcWorkload(apiLog), apiLog.SrcIP, apiLog.SrcType),
+		namespace: apiLog.DstNamespace,
+		workload:  inventory.DstWorkload(apiLog),
+		method:    apiLog.Method,
+		endpoint:  endpoint,
+		params:    params,
+	}
+}
+
+// endpointKey Function
+func (t target) endpointKey() string {
+	return fmt.Sprintf("%s/%s %s %s", t.namespace, t.workload, t.method, t.endpoint)
+}
+
+// shouldReport Function
+func (sd *SecurityDetectors) shouldReport(key string, now time.Time) bool {
+	return sd.reports.ShouldReport(key, now)
+}
+
+// newFinding Function
+func newFinding(t target, findingType, severity, description string, evidence map[string]string, apiLog *protobuf.APILog, now time.Time) *protobuf.APIFinding {
+	evidence["caller"] = t.caller
+	evidence["owasp"] = owaspRisks[findingType]
+
+	return &protobuf.APIFinding{
+		TimeStamp:   strconv.FormatInt(now.Unix(), 10),
+		Category:    FindingCategorySecurity,
+		Type:        findingType,
+		Severity:    severity,
+		Description: description,
+
+		Namespace: t.namespace,
+		Workload:  t.workload,
+		Method:    t.method,
+		Path:      t.endpoint,
+
+		Evidence: evidence,
+		Samples:  []*protobuf.APILog{apiLog},
+	}
+}
+
+// InspectAPILog Function that runs the enabled detectors over an API log
+func InspectAPILog(apiLog *protobuf.APILog) []*protobuf.APIFinding {
+	t := targetOf(apiLog)
+	now := time.Now()
+
+	SecD.detectorsLock.Lock()
+	defer SecD.detectorsLock.Unlock()
+
+	findings := make([]*protobuf.APIFinding, 0)
+
+	for _, detector := range []struct {
+		name   string
+		detect func(t target, apiLog *protobuf.APILog, now time.Time) *protobuf.APIFinding
+	}{
+		{DetectorBOLA, SecD.detectEnumeration},
+		{DetectorAuth, SecD.detectMissingAuth},
+		{DetectorExposure, SecD.detectExposure},
+		{DetectorConsumption, SecD.detectBurst},
+	} {
+		if !SecD.enabled[detector.name] {
+			continue
+		}
+		if finding := detector.detect(t, apiLog, now); finding != nil {
+			findings = append(findings, finding)
+		}
+	}
+
+	return findings
+}
+
+// == //
@@ -0,0 +1,160 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package pipeline
+
+import (
+	"github.com/5gsec/SentryFlow/anomaly"
+	"github.com/5gsec/SentryFlow/chains"
+	"github.com/5gsec/SentryFlow/config"
+	"github.com/5gsec/SentryFlow/egress"
+	"github.com/5gsec/SentryFlow/filter"
+	"github.com/5gsec/SentryFlow/graph"
+	"github.com/5gsec/SentryFlow/inventory"
+	"github.com/5gsec/SentryFlow/openapi"
+	"github.com/5gsec/SentryFlow/owasp"
+	"github.com/5gsec/SentryFlow/protobuf"
+	"github.com/5gsec/SentryFlow/redaction"
+	"github.com/5gsec/SentryFlow/rules"
+)
+
+// == //
+
+// Built-in stages
+const (
+	StageRedaction  = "redaction"
+	StageInventory  = "inventory"
+	StageEgress     = "egress"
+	StageSvcGraph   = "serviceGraph"
+	StageReqChains  = "requestChains"
+	StageSpecDrift  = "specDrift"
+	StageAnomaly    = "anomaly"
+	StageSecurity   = "securityDetectors"
+	StageAlertRules = "alertRules"
+	StageFilter     = "filter"
+	StageLabelTags  = "labelTags"
+	StageHeaderTags = "headerTags"
+)
+
+// init Function
+func init() {
+	RegisterStage(StageRedaction, newFuncStage(StageRedaction, redactionStage))
+	RegisterStage(StageInventory, newFuncStage(StageInventory, inventoryStage))
+	RegisterStage(StageEgress, newFuncStage(StageEgress, egressStage))
+	RegisterStage(StageSvcGraph, newFuncStage(StageSvcGraph, serviceGraphStage))
+	RegisterStage(StageReqChains, newFuncStage(StageReqChains, requestChainsStage))
+	RegisterStage(StageSpecDrift, newFuncStage(StageSpecDrift, specDriftStage))
+	RegisterStage(StageAnomaly, newFuncStage(StageAnomaly, anomalyStage))
+	RegisterStage(StageSecurity, newFuncStage(StageSecurity, securityStage))
+	RegisterStage(StageAlertRules, newFuncStage(StageAlertRules, alertRulesStage))
+	RegisterStage(StageFilter, newFuncStage(StageFilter, filterStage))
+	RegisterStage(StageLabelTags, newLabelTagsStage)
+	RegisterStage(StageHeaderTags, newHeaderTagsStage)
+}
+
+// DefaultStages Function that gives the stages used when the config file has none
+// Sensitive data is redacted first, and filtering only affects what is exported
+func DefaultStages() []config.StageConfig {
+	return []config.StageConfig{
+		{Name: StageRedaction},
+		{Name: StageInventory},
+		{Name: StageEgress},
+		{Name: StageSvcGraph},
+		{Name: StageReqChains},
+		{Name: StageSpecDrift},
+		{Name: StageAnomaly},
+		{Name: StageSecurity},
+		{Name: StageAlertRules},
+		{Name: StageFilter},
+	}
+}
+
+// == //
+
+// funcStage Structure (a stage without settings)
+type funcStage struct {
+	name    string
+	process func(ctx *Context, apiLog *protobuf.APILog) *protobuf.APILog
+}
+
+func (fs *funcStage) Name() string { return fs.name }
+
+func (fs *funcStage) Process(ctx *Context, apiLog *protobuf.APILog) *protobuf.APILog {
+	return fs.process(ctx, apiLog)
+}
+
+// newFuncStage Function
+func newFuncStage(name string, process func(ctx *Context, apiLog *protobuf.APILog) *protobuf.APILog) StageFactory {
+	return func(_ map[string]interface{}) (Stage, error) {
+		return &funcStage{name: name, process: process}, nil
+	}
+}
+
+// == //
+
+// redactionStage Function that redacts sensitive data
+func redactionStage(ctx *Context, apiLog *protobuf.APILog) *protobuf.APILog {
+	ctx.APIFindings = append(ctx.APIFindings, redaction.RedactAPILog(apiLog)...)
+	return apiLog
+}
+
+// inventoryStage Function that keeps track of APIs and notifies newly discovered ones
+func inventoryStage(ctx *Context, apiLog *protobuf.APILog) *protobuf.APILog {
+	if apiEvent := inventory.UpdateAPI(apiLog); apiEvent != nil {
+		ctx.APIEvents = append(ctx.APIEvents, apiEvent)
+	}
+	return apiLog
+}
+
+// egressStage Function that keeps track of hosts outside of the cluster and notifies newly called ones
+func egressStage(ctx *Context, apiLog *protobuf.APILog) *protobuf.APILog {
+	if apiEvent := egress.ObserveAPILog(apiLog); apiEvent != nil {
+		ctx.APIEvents = append(ctx.APIEvents, apiEvent)
+	}
+	return apiLog
+}
+
+// serviceGraphStage Function that adds calls to the service graph
+func serviceGraphStage(_ *Context, apiLog *protobuf.APILog) *protobuf.APILog {
+	graph.ObserveAPILog(apiLog)
+	return apiLog
+}
+
+// requestChainsStage Function that groups API logs by request (chains are exported by the chain assembler)
+func requestChainsStage(_ *Context, apiLog *protobuf.APILog) *protobuf.APILog {
+	chains.ObserveAPILog(apiLog)
+	return apiLog
+}
+
+// specDriftStage Function that compares API logs with the API spec of their destination
+func specDriftStage(ctx *Context, apiLog *protobuf.APILog) *protobuf.APILog {
+	ctx.APIFindings = append(ctx.APIFindings, openapi.CheckAPILog(apiLog)...)
+	return apiLog
+}
+
+// anomalyStage Function that learns traffic baselines (anomalies are reported by the anomaly detector)
+func anomalyStage(_ *Context, apiLog *protobuf.APILog) *protobuf.APILog {
+	anomaly.ObserveAPILog(apiLog)
+	return apiLog
+}
+
+// securityStage Function that runs the heuristic detectors of the OWASP API Security Top 10
+func securityStage(ctx *Context, apiLog *protobuf.APILog) *protobuf.APILog {
+	ctx.APIFindings = append(ctx.APIFindings, owasp.InspectAPILog(apiLog)...)
+	return apiLog
+}
+
+// alertRulesStage Function that evaluates alert rules
+func alertRulesStage(ctx *Context, apiLog *protobuf.APILog) *protobuf.APILog {
+	ctx.Alerts = append(ctx.Alerts, rules.EvaluateAPILog(apiLog)...)
+	return apiLog
+}
+
+// filterStage Function that drops or samples API logs
+func filterStage(_ *Context, apiLog *protobuf.APILog) *protobuf.APILog {
+	if !filter.FilterAPILog(apiLog) {
+		return nil
+	}
+	return apiLog
+}
+
+// == //
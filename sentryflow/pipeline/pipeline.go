@@ -0,0 +1,188 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package pipeline
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/5gsec/SentryFlow/config"
+	"github.com/5gsec/SentryFlow/protobuf"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// == //
+
+// Stage Interface for processing API logs
+// Process returns the (possibly modified) API log, or nil to drop it
+type Stage interface {
+	Name() string
+	Process(ctx *Context, apiLog *protobuf.APILog) *protobuf.APILog
+}
+
+// StageFactory creates a stage with its settings in the config file
+type StageFactory func(stageCfg map[string]interface{}) (Stage, error)
+
+// Context Structure (what stages report while processing an API log)
+type Context struct {
+	context.Context
+
+	APIEvents   []*protobuf.APIEvent
+	APIFindings []*protobuf.APIFinding
+	Alerts      []*protobuf.Alert
+}
+
+// NewContext Function
+func NewContext(ctx context.Context) *Context {
+	return &Context{Context: ctx}
+}
+
+// == //
+
+var (
+	// stageDuration measures the time each stage takes per API log
+	stageDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "sentryflow_stage_duration_seconds",
+		Help:    "Time taken by each stage to process an API log",
+		Buckets: prometheus.ExponentialBuckets(0.00001, 4, 10),
+	}, []string{"stage"})
+
+	// stageDropped counts API logs dropped by each stage
+	stageDropped = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "sentryflow_stage_dropped_total",
+		Help: "Number of API logs dropped by each stage",
+	}, []string{"stage"})
+)
+
+// factories are the registered stages
+var factories = struct {
+	sync.RWMutex
+	byName map[string]StageFactory
+}{byName: make(map[string]StageFactory)}
+
+// RegisterStage Function that makes a stage available to the config file
+// Custom stages register themselves in the init function of a package imported by main
+func RegisterStage(name string, factory StageFactory) {
+	factories.Lock()
+	defer factories.Unlock()
+
+	if _, ok := factories.byName[name]; ok {
+		log.Printf("[Pipeline] Stage %s is registered more than once", name)
+	}
+	factories.byName[name] = factory
+}
+
+// RegisteredStages Function
+func RegisteredStages() []string {
+	factories.RLock()
+	defer factories.RUnlock()
+
+	return registeredNames()
+}
+
+// registeredNames Function (for callers holding the lock of factories)
+func registeredNames() []string {
+	names := make([]string, 0, len(factories.byName))
+	for name := range factories.byName {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// == //
+
+// Pipe global reference for Pipeline
+var Pipe *Pipeline
+
+// init Function
+func init() {
+	Pipe = &Pipeline{stages: make([]Stage, 0)}
+}
+
+// Pipeline Structure
+type Pipeline struct {
+	stages     []Stage
+	stagesLock sync.RWMutex
+}
+
+// StartPipeline Function
+func StartPipeline() bool {
+	stageCfgs := config.GlobalConfig.Stages
+	if len(stageCfgs) == 0 {
+		stageCfgs = DefaultStages()
+	}
+
+	if err := LoadStages(stageCfgs); err != nil {
+		log.Printf("[Pipeline] Failed to load stages: %v", err)
+		return false
+	}
+
+	names := make([]string, 0, len(stageCfgs))
+	for _, stageCfg := range stageCfgs {
+		names = append(names, stageCfg.Name)
+	}
+
+	log.Printf("[Pipeline] Started Pipeline (%s)", strings.Join(names, " -> "))
+
+	return true
+}
+
+// LoadStages Function that replaces the stages with the given ones (in order)
+func LoadStages(stageCfgs []config.StageConfig) error {
+	stages := make([]Stage, 0, len(stageCfgs))
+
+	factories.RLock()
+	defer factories.RUnlock()
+
+	for _, stageCfg := range stageCfgs {
+		factory, ok := factories.byName[stageCfg.Name]
+		if !ok {
+			return fmt.Errorf("unknown stage %q (registered: %s)", stageCfg.Name, strings.Join(registeredNames(), ", "))
+		}
+
+		stage, err := factory(stageCfg.Config)
+		if err != nil {
+			return fmt.Errorf("stage %q: %v", stageCfg.Name, err)
+		}
+
+		stages = append(stages, stage)
+	}
+
+	Pipe.stagesLock.Lock()
+	Pipe.stages = stages
+	Pipe.stagesLock.Unlock()
+
+	return nil
+}
+
+// RunStages Function that passes an API log through every stage in order
+// It returns nil if a stage drops the API log
+func RunStages(ctx *Context, apiLog *protobuf.APILog) *protobuf.APILog {
+	Pipe.stagesLock.RLock()
+	stages := Pipe.stages
+	Pipe.stagesLock.RUnlock()
+
+	for _, stage := range stages {
+		start := time.Now()
+		apiLog = stage.Process(ctx, apiLog)
+		stageDuration.WithLabelValues(stage.Name()).Observe(time.Since(start).Seconds())
+
+		if apiLog == nil {
+			stageDropped.WithLabelValues(stage.Name()).Inc()
+			return nil
+		}
+	}
+
+	return apiLog
+}
+
+// == //
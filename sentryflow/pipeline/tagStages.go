@@ -0,0 +1,132 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package pipeline
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/5gsec/SentryFlow/protobuf"
+)
+
+// == //
+
+// tagSource Structure (a tag and where its value comes from)
+type tagSource struct {
+	name string
+	from string
+}
+
+// parseTagSources Function that reads a list of {name: <tag>, <fromKey>: <source>} in stage settings
+// Lists are used instead of maps since keys in the config file are not case-sensitive
+func parseTagSources(stageCfg map[string]interface{}, fromKey string) ([]tagSource, error) {
+	items, ok := stageCfg["tags"].([]interface{})
+	if !ok || len(items) == 0 {
+		return nil, fmt.Errorf("tags are required")
+	}
+
+	sources := make([]tagSource, 0, len(items))
+
+	for _, item := range items {
+		fields, ok := item.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("each tag should have name and %s", fromKey)
+		}
+
+		name, _ := fields["name"].(string)
+		from, _ := fields[strings.ToLower(fromKey)].(string)
+		if name == "" || from == "" {
+			return nil, fmt.Errorf("each tag should have name and %s", fromKey)
+		}
+
+		sources = append(sources, tagSource{name: name, from: from})
+	}
+
+	return sources, nil
+}
+
+// setTag Function
+func setTag(apiLog *protobuf.APILog, name, value string) {
+	if apiLog.Tags == nil {
+		apiLog.Tags = make(map[string]string)
+	}
+	apiLog.Tags[name] = value
+}
+
+// == //
+
+// labelTagsStage Structure (tags from the labels of the source or the destination, e.g., team ownership)
+type labelTagsStage struct {
+	source string
+	tags   []tagSource
+}
+
+// newLabelTagsStage Function
+func newLabelTagsStage(stageCfg map[string]interface{}) (Stage, error) {
+	source, _ := stageCfg["source"].(string)
+	if source == "" {
+		source = "dst"
+	}
+	if source != "src" && source != "dst" {
+		return nil, fmt.Errorf("unsupported source %q (src|dst)", source)
+	}
+
+	tags, err := parseTagSources(stageCfg, "label")
+	if err != nil {
+		return nil, err
+	}
+
+	return &labelTagsStage{source: source, tags: tags}, nil
+}
+
+func (ls *labelTagsStage) Name() string { return StageLabelTags }
+
+func (ls *labelTagsStage) Process(_ *Context, apiLog *protobuf.APILog) *protobuf.APILog {
+	labels := apiLog.DstLabel
+	if ls.source == "src" {
+		labels = apiLog.SrcLabel
+	}
+
+	for _, tag := range ls.tags {
+		if value, ok := labels[tag.from]; ok {
+			setTag(apiLog, tag.name, value)
+		}
+	}
+
+	return apiLog
+}
+
+// == //
+
+// headerTagsStage Structure (tags from request headers, e.g., tenant IDs)
+type headerTagsStage struct {
+	tags []tagSource
+}
+
+// newHeaderTagsStage Function
+func newHeaderTagsStage(stageCfg map[string]interface{}) (Stage, error) {
+	tags, err := parseTagSources(stageCfg, "header")
+	if err != nil {
+		return nil, err
+	}
+
+	for idx := range tags {
+		tags[idx].from = strings.ToLower(tags[idx].from)
+	}
+
+	return &headerTagsStage{tags: tags}, nil
+}
+
+func (hs *headerTagsStage) Name() string { return StageHeaderTags }
+
+func (hs *headerTagsStage) Process(_ *Context, apiLog *protobuf.APILog) *protobuf.APILog {
+	for _, tag := range hs.tags {
+		if value, ok := apiLog.RequestHeaders[tag.from]; ok {
+			setTag(apiLog, tag.name, value)
+		}
+	}
+
+	return apiLog
+}
+
+// == //
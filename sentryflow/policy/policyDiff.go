@@ -0,0 +1,83 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package policy
+
+import (
+	"fmt"
+
+	"github.com/5gsec/SentryFlow/k8s"
+	"github.com/5gsec/SentryFlow/protobuf"
+
+	"gopkg.in/yaml.v2"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// == //
+
+// policyResources are the resources of policies in the cluster
+var policyResources = map[string]schema.GroupVersionResource{
+	KindAuthorizationPolicy: {Group: "security.istio.io", Version: "v1", Resource: "authorizationpolicies"},
+	KindNetworkPolicy:       {Group: "networking.k8s.io", Version: "v1", Resource: "networkpolicies"},
+}
+
+// normalize Function that keeps what generated policies set (no status or server-side metadata)
+func normalize(obj map[string]interface{}) map[string]interface{} {
+	metadata, _ := obj["metadata"].(map[string]interface{})
+
+	kept := map[string]interface{}{}
+	for _, key := range []string{"name", "namespace", "labels"} {
+		if value, ok := metadata[key]; ok {
+			kept[key] = value
+		}
+	}
+
+	return map[string]interface{}{
+		"apiVersion": obj["apiVersion"],
+		"kind":       obj["kind"],
+		"metadata":   kept,
+		"spec":       obj["spec"],
+	}
+}
+
+// diffPolicy Function that compares a generated policy with the one in the cluster (dry run, nothing is applied)
+func diffPolicy(generated *protobuf.GeneratedPolicy, obj map[string]interface{}) {
+	current, err := k8s.GetResource(policyResources[generated.Kind], generated.Namespace, generated.Name)
+	if err != nil {
+		generated.Status = StatusUnknown
+		generated.Notes = append(generated.Notes, fmt.Sprintf("failed to get the %s in the cluster: %v", generated.Kind, err))
+		return
+	}
+
+	after, err := yaml.Marshal(normalize(obj))
+	if err != nil {
+		generated.Status = StatusUnknown
+		return
+	}
+
+	if current == nil {
+		generated.Status = StatusNew
+		generated.Diff = k8s.UnifiedDiff("", string(after), "/dev/null", clusterName(generated))
+		return
+	}
+
+	before, err := yaml.Marshal(normalize(current))
+	if err != nil {
+		generated.Status = StatusUnknown
+		return
+	}
+
+	if string(before) == string(after) {
+		generated.Status = StatusUnchanged
+		return
+	}
+
+	generated.Status = StatusChanged
+	generated.Diff = k8s.UnifiedDiff(string(before), string(after), clusterName(generated), clusterName(generated))
+}
+
+// clusterName Function
+func clusterName(generated *protobuf.GeneratedPolicy) string {
+	return fmt.Sprintf("%s/%s/%s", generated.Kind, generated.Namespace, generated.Name)
+}
+
+// == //
@@ -0,0 +1,504 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package policy
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/5gsec/SentryFlow/inventory"
+	"github.com/5gsec/SentryFlow/k8s"
+	"github.com/5gsec/SentryFlow/protobuf"
+	"github.com/5gsec/SentryFlow/types"
+
+	"gopkg.in/yaml.v2"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// == //
+
+// Kinds of policies
+const (
+	KindAuthorizationPolicy = "AuthorizationPolicy"
+	KindNetworkPolicy       = "NetworkPolicy"
+)
+
+const (
+	// defaultTrustDomain is the trust domain of Istio service accounts unless configured otherwise
+	defaultTrustDomain = "cluster.local"
+
+	// namePrefix is the prefix of the names of generated policies
+	namePrefix = "sentryflow-"
+
+	// externalCaller is the caller of API calls from outside of the cluster in the API inventory
+	externalCaller = "Unknown/Unknown"
+)
+
+// selectorLabels are the labels that identify workloads without a selector of their own (in the order of inventory.WorkloadName)
+var selectorLabels = []string{"app.kubernetes.io/name", "app"}
+
+// Statuses of generated policies against the cluster
+const (
+	StatusNew       = "new"
+	StatusChanged   = "changed"
+	StatusUnchanged = "unchanged"
+	StatusUnknown   = "unknown"
+	StatusSkipped   = "skipped"
+)
+
+// == //
+
+// peer Structure (a workload calling another workload)
+type peer struct {
+	namespace string
+	workload  string
+
+	principal string
+	selector  map[string]string
+
+	operations map[string]map[string]bool // method -> paths
+	calls      uint64
+}
+
+// target Structure (a workload and what was observed calling it)
+type target struct {
+	namespace string
+	workload  string
+	selector  map[string]string
+
+	peers         map[string]*peer // keyed by caller (namespace/workload)
+	ports         map[string]bool
+	externalCalls uint64
+
+	notes []string
+}
+
+// workloadPod Function that gives a running pod of a workload
+func workloadPod(namespace, workload string) *corev1.Pod {
+	return k8s.FindPod(namespace, func(pod *corev1.Pod, kind, name string) bool {
+		return inventory.WorkloadOf(kind, name, pod.Name, pod.Labels) == workload
+	})
+}
+
+// selectorOf Function that gives the labels selecting the pods of a workload (the matchLabels of its top-level owner)
+// Workloads without a selector (e.g., bare pods) fall back to the label identifying them, with a note
+func selectorOf(pod *corev1.Pod) (map[string]string, string) {
+	selector, err := k8s.WorkloadSelector(pod)
+	if err == nil {
+		return selector, ""
+	}
+
+	for _, key := range selectorLabels {
+		if value := pod.Labels[key]; value != "" {
+			return map[string]string{key: value}, fmt.Sprintf("pods of %s/%s are selected by the %s label only (%v)", pod.Namespace, pod.Name, key, err)
+		}
+	}
+	return nil, ""
+}
+
+// principalOf Function that gives the Istio identity of the pods of a workload
+func principalOf(pod *corev1.Pod, trustDomain string) string {
+	serviceAccount := pod.Spec.ServiceAccountName
+	if serviceAccount == "" {
+		serviceAccount = "default"
+	}
+	return fmt.Sprintf("%s/ns/%s/sa/%s", trustDomain, pod.Namespace, serviceAccount)
+}
+
+// policyPath Function that converts a path template into an Istio path template (/users/{userId} -> /users/{*})
+func policyPath(pathTemplate string) string {
+	segments := strings.Split(pathTemplate, "/")
+	for idx, segment := range segments {
+		if strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}") {
+			segments[idx] = "{*}"
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+// == //
+
+// collectTargets Function that groups the observed calls by destination workload
+func collectTargets(query *protobuf.PolicyQuery, trustDomain string) []*target {
+	apis := inventory.ListAPIs(&protobuf.APIQuery{Namespace: query.Namespace, Workload: query.Workload})
+
+	targets := make(map[string]*target)
+	callerPods := make(map[string]*corev1.Pod)
+
+	// merge the path templates of each workload as literal segments taking many values (e.g., slugs) are not templated by the inventory
+	pathTemplates := make(map[string][]string)
+	for _, api := range apis {
+		if api.ApiType != types.APITypeGRPC {
+			key := api.Namespace + "/" + api.Workload
+			pathTemplates[key] = append(pathTemplates[key], api.PathTemplate)
+		}
+	}
+	mergedTemplates := make(map[string]map[string]string, len(pathTemplates))
+	for key, templates := range pathTemplates {
+		mergedTemplates[key] = inventory.MergeTemplates(templates)
+	}
+
+	for _, api := range apis {
+		key := api.Namespace + "/" + api.Workload
+
+		t, ok := targets[key]
+		if !ok {
+			t = &target{namespace: api.Namespace, workload: api.Workload, peers: make(map[string]*peer), ports: make(map[string]bool)}
+			targets[key] = t
+		}
+
+		for _, port := range api.Ports {
+			t.ports[port] = true
+		}
+
+		pathTemplate := api.PathTemplate
+		if merged, ok := mergedTemplates[key][pathTemplate]; ok {
+			pathTemplate = merged
+		}
+
+		for caller, calls := range api.Callers {
+			if calls < query.MinCalls {
+				continue
+			}
+
+			if caller == externalCaller {
+				t.externalCalls += calls
+				continue
+			}
+
+			p, ok := t.peers[caller]
+			if !ok {
+				p = &peer{operations: make(map[string]map[string]bool)}
+				p.namespace, p.workload, _ = strings.Cut(caller, "/")
+				t.peers[caller] = p
+			}
+
+			if _, ok := p.operations[api.Method]; !ok {
+				p.operations[api.Method] = make(map[string]bool)
+			}
+			p.operations[api.Method][policyPath(pathTemplate)] = true
+			p.calls += calls
+
+			if _, ok := callerPods[caller]; !ok {
+				callerPods[caller] = workloadPod(p.namespace, p.workload)
+			}
+		}
+	}
+
+	// identify workloads with their pods
+	result := make([]*target, 0, len(targets))
+
+	for _, t := range targets {
+		if pod := workloadPod(t.namespace, t.workload); pod != nil {
+			var note string
+			if t.selector, note = selectorOf(pod); note != "" {
+				t.notes = append(t.notes, note)
+			}
+		}
+
+		for caller, p := range t.peers {
+			pod := callerPods[caller]
+			if pod == nil {
+				t.notes = append(t.notes, fmt.Sprintf("calls from %s are not allowed (no running pod to identify it, %d calls)", caller, p.calls))
+				delete(t.peers, caller)
+				continue
+			}
+			p.principal = principalOf(pod, trustDomain)
+			p.selector, _ = selectorOf(pod)
+		}
+
+		if t.externalCalls > 0 {
+			t.notes = append(t.notes, fmt.Sprintf("calls from outside of the mesh are not allowed (no identity, %d calls)", t.externalCalls))
+		}
+		sort.Strings(t.notes)
+
+		result = append(result, t)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].namespace != result[j].namespace {
+			return result[i].namespace < result[j].namespace
+		}
+		return result[i].workload < result[j].workload
+	})
+
+	return result
+}
+
+// sortedPeers Function
+func (t *target) sortedPeers() []*peer {
+	peers := make([]*peer, 0, len(t.peers))
+	for _, p := range t.peers {
+		peers = append(peers, p)
+	}
+	sort.Slice(peers, func(i, j int) bool {
+		if peers[i].namespace != peers[j].namespace {
+			return peers[i].namespace < peers[j].namespace
+		}
+		return peers[i].workload < peers[j].workload
+	})
+	return peers
+}
+
+// metadataOf Function
+func metadataOf(t *target) map[string]interface{} {
+	return map[string]interface{}{
+		"name":      namePrefix + t.workload,
+		"namespace": t.namespace,
+		"labels": map[string]interface{}{
+			"app.kubernetes.io/managed-by": "sentryflow",
+		},
+	}
+}
+
+// toInterfaceMap Function
+func toInterfaceMap(labels map[string]string) map[string]interface{} {
+	m := make(map[string]interface{}, len(labels))
+	for key, value := range labels {
+		m[key] = value
+	}
+	return m
+}
+
+// toInterfaceSlice Function
+func toInterfaceSlice(values map[string]bool) []interface{} {
+	keys := make([]string, 0, len(values))
+	for value := range values {
+		keys = append(keys, value)
+	}
+	sort.Strings(keys)
+
+	s := make([]interface{}, 0, len(keys))
+	for _, key := range keys {
+		s = append(s, key)
+	}
+	return s
+}
+
+// authorizationPolicyOf Function that allows only the observed (principal, method, path) combinations
+// Callers sharing a service account share a rule
+func authorizationPolicyOf(t *target) map[string]interface{} {
+	operations := make(map[string]map[string]map[string]bool) // principal -> method -> paths
+
+	for _, p := range t.peers {
+		if _, ok := operations[p.principal]; !ok {
+			operations[p.principal] = make(map[string]map[string]bool)
+		}
+		for method, paths := range p.operations {
+			if _, ok := operations[p.principal][method]; !ok {
+				operations[p.principal][method] = make(map[string]bool)
+			}
+			for path := range paths {
+				operations[p.principal][method][path] = true
+			}
+		}
+	}
+
+	principals := make([]string, 0, len(operations))
+	for principal := range operations {
+		principals = append(principals, principal)
+	}
+	sort.Strings(principals)
+
+	rules := make([]interface{}, 0, len(principals))
+	for _, principal := range principals {
+		methods := make([]string, 0, len(operations[principal]))
+		for method := range operations[principal] {
+			methods = append(methods, method)
+		}
+		sort.Strings(methods)
+
+		to := make([]interface{}, 0, len(methods))
+		for _, method := range methods {
+			to = append(to, map[string]interface{}{
+				"operation": map[string]interface{}{
+					"methods": []interface{}{method},
+					"paths":   toInterfaceSlice(operations[principal][method]),
+				},
+			})
+		}
+
+		rules = append(rules, map[string]interface{}{
+			"from": []interface{}{
+				map[string]interface{}{"source": map[string]interface{}{"principals": []interface{}{principal}}},
+			},
+			"to": to,
+		})
+	}
+
+	return map[string]interface{}{
+		"apiVersion": "security.istio.io/v1",
+		"kind":       KindAuthorizationPolicy,
+		"metadata":   metadataOf(t),
+		"spec": map[string]interface{}{
+			"selector": map[string]interface{}{"matchLabels": toInterfaceMap(t.selector)},
+			"action":   "ALLOW",
+			"rules":    rules,
+		},
+	}
+}
+
+// networkPolicyOf Function that allows only the observed workload pairs on the observed ports
+func networkPolicyOf(t *target) (map[string]interface{}, []string) {
+	notes := make([]string, 0)
+
+	from := make([]interface{}, 0, len(t.peers))
+	for _, p := range t.sortedPeers() {
+		if p.selector == nil {
+			notes = append(notes, fmt.Sprintf("calls from %s/%s are not allowed by the NetworkPolicy (no selector, app.kubernetes.io/name or app label)", p.namespace, p.workload))
+			continue
+		}
+		from = append(from, map[string]interface{}{
+			"namespaceSelector": map[string]interface{}{
+				"matchLabels": map[string]interface{}{"kubernetes.io/metadata.name": p.namespace},
+			},
+			"podSelector": map[string]interface{}{"matchLabels": toInterfaceMap(p.selector)},
+		})
+	}
+
+	rule := map[string]interface{}{"from": from}
+
+	if len(t.ports) > 0 {
+		ports := make([]interface{}, 0, len(t.ports))
+		for _, port := range toInterfaceSlice(t.ports) {
+			number, err := strconv.Atoi(port.(string))
+			if err != nil {
+				continue
+			}
+			ports = append(ports, map[string]interface{}{"protocol": "TCP", "port": number})
+		}
+		rule["ports"] = ports
+	}
+
+	return map[string]interface{}{
+		"apiVersion": "networking.k8s.io/v1",
+		"kind":       KindNetworkPolicy,
+		"metadata":   metadataOf(t),
+		"spec": map[string]interface{}{
+			"podSelector": map[string]interface{}{"matchLabels": toInterfaceMap(t.selector)},
+			"policyTypes": []interface{}{"Ingress"},
+			"ingress":     []interface{}{rule},
+		},
+	}, notes
+}
+
+// == //
+
+// parseKinds Function
+func parseKinds(kinds []string) (map[string]bool, error) {
+	selected := map[string]bool{}
+
+	if len(kinds) == 0 {
+		kinds = []string{KindAuthorizationPolicy, KindNetworkPolicy}
+	}
+
+	for _, kind := range kinds {
+		switch strings.ToLower(kind) {
+		case strings.ToLower(KindAuthorizationPolicy):
+			selected[KindAuthorizationPolicy] = true
+		case strings.ToLower(KindNetworkPolicy):
+			selected[KindNetworkPolicy] = true
+		default:
+			return nil, fmt.Errorf("unknown kind %q (AuthorizationPolicy|NetworkPolicy)", kind)
+		}
+	}
+
+	return selected, nil
+}
+
+// renderPolicy Function that gives the YAML of a policy with its notes as comments
+func renderPolicy(obj map[string]interface{}, notes []string) (string, error) {
+	data, err := yaml.Marshal(obj)
+	if err != nil {
+		return "", err
+	}
+
+	var sb strings.Builder
+	for _, note := range notes {
+		sb.WriteString("# " + note + "\n")
+	}
+	sb.Write(data)
+
+	return sb.String(), nil
+}
+
+// GeneratePolicies Function that suggests least-privilege policies from the observed traffic (nothing is applied)
+func GeneratePolicies(query *protobuf.PolicyQuery) (*protobuf.PolicySet, error) {
+	kinds, err := parseKinds(query.Kinds)
+	if err != nil {
+		return nil, err
+	}
+
+	trustDomain := query.TrustDomain
+	if trustDomain == "" {
+		trustDomain = defaultTrustDomain
+	}
+
+	policySet := &protobuf.PolicySet{Policies: make([]*protobuf.GeneratedPolicy, 0)}
+	documents := make([]string, 0)
+
+	for _, t := range collectTargets(query, trustDomain) {
+		skipped := ""
+		if t.selector == nil {
+			skipped = "no running pod with a selector, an app.kubernetes.io/name or app label to select the workload"
+		} else if len(t.peers) == 0 {
+			skipped = "no identified callers (allowing nothing would cut off the workload)"
+		}
+
+		if skipped != "" {
+			policySet.Policies = append(policySet.Policies, &protobuf.GeneratedPolicy{
+				Namespace: t.namespace,
+				Workload:  t.workload,
+				Notes:     append(t.notes, skipped),
+				Status:    StatusSkipped,
+			})
+			continue
+		}
+
+		for _, kind := range []string{KindAuthorizationPolicy, KindNetworkPolicy} {
+			if !kinds[kind] {
+				continue
+			}
+
+			notes := append([]string(nil), t.notes...)
+
+			var obj map[string]interface{}
+			if kind == KindAuthorizationPolicy {
+				obj = authorizationPolicyOf(t)
+			} else {
+				var peerNotes []string
+				obj, peerNotes = networkPolicyOf(t)
+				notes = append(notes, peerNotes...)
+			}
+
+			doc, err := renderPolicy(obj, notes)
+			if err != nil {
+				return nil, err
+			}
+
+			generated := &protobuf.GeneratedPolicy{
+				Kind:      kind,
+				Namespace: t.namespace,
+				Name:      namePrefix + t.workload,
+				Workload:  t.workload,
+				Yaml:      doc,
+				Notes:     notes,
+			}
+
+			if query.Diff {
+				diffPolicy(generated, obj)
+			}
+
+			policySet.Policies = append(policySet.Policies, generated)
+			documents = append(documents, doc)
+		}
+	}
+
+	policySet.Yaml = strings.Join(documents, "---\n")
+
+	return policySet, nil
+}
+
+// == //
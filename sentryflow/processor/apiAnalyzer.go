@@ -31,6 +31,8 @@ type Analyzer struct {
 // NewAPIAnalyzer Function
 func NewAPIAnalyzer() *Analyzer {
 	ret := &Analyzer{
+		stopChan: make(chan struct{}),
+
 		apiLog:      make(chan string),
 		apiLogs:     []string{},
 		apiLogsLock: sync.Mutex{},
@@ -55,7 +57,7 @@ func AnalyzeAPI(api string) {
 
 // StopAPIAnalyzer Function
 func StopAPIAnalyzer() bool {
-	APIA.stopChan <- struct{}{}
+	close(APIA.stopChan)
 
 	log.Print("[APIAnalyzer] Stopped API Analyzer")
 
@@ -23,6 +23,9 @@ var APIC *APIClassifier
 type APIClassifier struct {
 	stopChan chan struct{}
 
+	streamCtx    context.Context // canceled on stop to unblock the stream
+	cancelStream context.CancelFunc
+
 	APIs chan []string
 
 	connected   bool
@@ -43,9 +46,14 @@ func init() {
 
 // NewAPIClassifier Function
 func NewAPIClassifier() *APIClassifier {
+	streamCtx, cancelStream := context.WithCancel(context.Background())
+
 	ah := &APIClassifier{
 		stopChan: make(chan struct{}),
 
+		streamCtx:    streamCtx,
+		cancelStream: cancelStream,
+
 		APIs: make(chan []string),
 
 		connected:   false,
@@ -71,7 +79,7 @@ func initAPIClassifier() bool {
 	client := protobuf.NewAPIClassifierClient(conn)
 
 	// Start serving gRPC server
-	stream, err := client.ClassifyAPIs(context.Background())
+	stream, err := client.ClassifyAPIs(APIC.streamCtx)
 	if err != nil {
 		log.Printf("[APIClassifier] Failed to make a stream: %v", err)
 		return false
@@ -106,37 +114,37 @@ func ClassifyAPIs(APIs []string) {
 
 // StopAPIClassifier Function
 func StopAPIClassifier() bool {
-	// one for connRoutine
-	APIC.stopChan <- struct{}{}
-
-	// one for sendAPIRoutine
-	APIC.stopChan <- struct{}{}
-
-	// one for recvAPIRoutine
-	APIC.stopChan <- struct{}{}
+	// for connRoutine, sendAPIRoutine and recvAPIRoutine (blocked in the stream, if connected)
+	close(APIC.stopChan)
+	APIC.cancelStream()
 
 	log.Print("[APIClassifier] Stopped API Classifier")
 
 	return true
 }
 
+// waitForRetry Function that waits for the next trial (false if the API classifier is stopped meanwhile)
+func waitForRetry() bool {
+	select {
+	case <-APIC.stopChan:
+		return false
+	case <-time.After(APIC.reConnTrial):
+		return true
+	}
+}
+
 // connRoutine Function
 func connRoutine(wg *sync.WaitGroup) {
 	wg.Add(1)
 
 	for {
-		select {
-		case <-APIC.stopChan:
+		if !APIC.connected && initAPIClassifier() {
+			APIC.connected = true
+		}
+
+		if !waitForRetry() {
 			wg.Done()
 			return
-		default:
-			if !APIC.connected {
-				if initAPIClassifier() {
-					APIC.connected = true
-				} else {
-					time.Sleep(APIC.reConnTrial)
-				}
-			}
 		}
 	}
 }
@@ -147,7 +155,10 @@ func sendAPIRoutine(wg *sync.WaitGroup) {
 
 	for {
 		if !APIC.connected {
-			time.Sleep(APIC.reConnTrial)
+			if !waitForRetry() {
+				wg.Done()
+				return
+			}
 			continue
 		}
 
@@ -181,7 +192,10 @@ func recvAPIRoutine(wg *sync.WaitGroup) {
 
 	for {
 		if !APIC.connected {
-			time.Sleep(APIC.reConnTrial)
+			if !waitForRetry() {
+				wg.Done()
+				return
+			}
 			continue
 		}
 
@@ -190,7 +204,9 @@ func recvAPIRoutine(wg *sync.WaitGroup) {
 			APIMetrics := make(map[string]uint64)
 
 			event, err := APIC.AIStream.AIStream.Recv()
-			if err == io.EOF {
+			if APIC.streamCtx.Err() != nil {
+				continue // stopped while receiving
+			} else if err == io.EOF {
 				continue
 			} else if err != nil {
 				log.Printf("[APIClassifier] Failed to receive an event from AI Engine: %v", err)
@@ -0,0 +1,184 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package processor
+
+import (
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/5gsec/SentryFlow/inventory"
+	"github.com/5gsec/SentryFlow/protobuf"
+	"github.com/5gsec/SentryFlow/types"
+)
+
+// == //
+
+var (
+	// grpcPath matches /package.Service/Method (the package is required unless the content type tells gRPC)
+	grpcPath = regexp.MustCompile(`^/([A-Za-z_][\w]*(?:\.[A-Za-z_][\w]*)+)/([A-Za-z_][\w]*)$`)
+
+	// graphqlOperation matches the head of a GraphQL document (e.g., mutation CreateUser($input: ...) {)
+	graphqlOperation = regexp.MustCompile(`^\s*(query|mutation|subscription)\b\s*([A-Za-z_][\w]*)?`)
+)
+
+// grpcStatusByFlag maps Envoy response flags to gRPC status codes
+var grpcStatusByFlag = map[string]string{
+	"DC":   "1",  // CANCELLED (downstream connection termination)
+	"UT":   "4",  // DEADLINE_EXCEEDED (upstream request timeout)
+	"SI":   "4",  // DEADLINE_EXCEEDED (stream idle timeout)
+	"UAEX": "7",  // PERMISSION_DENIED (unauthorized by an external service)
+	"RL":   "8",  // RESOURCE_EXHAUSTED (rate limited)
+	"NR":   "12", // UNIMPLEMENTED (no route)
+	"UH":   "14", // UNAVAILABLE (no healthy upstream)
+	"UF":   "14", // UNAVAILABLE (upstream connection failure)
+	"UO":   "14", // UNAVAILABLE (upstream overflow)
+	"URX":  "14", // UNAVAILABLE (upstream retry limit exceeded)
+	"UC":   "14", // UNAVAILABLE (upstream connection termination)
+	"UR":   "14", // UNAVAILABLE (upstream remote reset)
+	"LH":   "14", // UNAVAILABLE (failed local health check)
+}
+
+// grpcStatusByHTTPCode maps HTTP status codes to gRPC status codes (as gRPC clients do)
+var grpcStatusByHTTPCode = map[int32]string{
+	400: "13", // INTERNAL
+	401: "16", // UNAUTHENTICATED
+	403: "7",  // PERMISSION_DENIED
+	404: "12", // UNIMPLEMENTED
+	429: "14", // UNAVAILABLE
+	502: "14", // UNAVAILABLE
+	503: "14", // UNAVAILABLE
+	504: "14", // UNAVAILABLE
+}
+
+// == //
+
+// IdentifyAPI Function that sets the API type and the logical operation of an API log
+func IdentifyAPI(apiLog *protobuf.APILog) {
+	switch {
+	case isGRPC(apiLog):
+		identifyGRPC(apiLog)
+	case isGraphQL(apiLog):
+		identifyGraphQL(apiLog)
+	default:
+		apiLog.ApiType = types.APITypeREST
+	}
+}
+
+// apiName Function that gives the name of an API for API metrics (logical operations for gRPC and GraphQL)
+func apiName(apiLog *protobuf.APILog) string {
+	if apiLog.ApiType == types.APITypeREST {
+		return apiLog.Path
+	}
+
+	endpoint, _ := inventory.EndpointTemplate(apiLog)
+	return endpoint
+}
+
+// == //
+
+// isGRPC Function
+func isGRPC(apiLog *protobuf.APILog) bool {
+	if strings.HasPrefix(apiLog.RequestHeaders["content-type"], "application/grpc") {
+		return true
+	}
+
+	// without the content type, gRPC calls are POSTs over HTTP/2 to /package.Service/Method
+	return apiLog.Method == "POST" && strings.Contains(apiLog.Protocol, "2") && grpcPath.MatchString(apiLog.Path)
+}
+
+// identifyGRPC Function
+func identifyGRPC(apiLog *protobuf.APILog) {
+	apiLog.ApiType = types.APITypeGRPC
+
+	path, _ := inventory.SplitPath(apiLog.Path)
+	if idx := strings.LastIndex(path, "/"); idx > 0 {
+		apiLog.Service = strings.TrimPrefix(path[:idx], "/")
+		apiLog.Operation = path[idx+1:]
+	}
+
+	// Access logs do not tell how many messages were exchanged, so unary calls and streams are not told apart
+	apiLog.OperationType = "grpc"
+
+	apiLog.GrpcStatus = grpcStatus(apiLog)
+}
+
+// grpcStatus Function that takes the gRPC status from trailers, response flags or the HTTP status code in order
+func grpcStatus(apiLog *protobuf.APILog) string {
+	if status := apiLog.ResponseHeaders["grpc-status"]; status != "" {
+		return status
+	}
+
+	for _, flag := range strings.Split(apiLog.ResponseFlags, ",") {
+		if status, ok := grpcStatusByFlag[flag]; ok {
+			return status
+		}
+	}
+
+	if apiLog.ResponseCode == 0 || apiLog.ResponseCode == 200 {
+		return "" // trailers not logged
+	}
+
+	if status, ok := grpcStatusByHTTPCode[apiLog.ResponseCode]; ok {
+		return status
+	}
+
+	return "2" // UNKNOWN
+}
+
+// == //
+
+// isGraphQL Function
+func isGraphQL(apiLog *protobuf.APILog) bool {
+	path, _ := inventory.SplitPath(apiLog.Path)
+	if strings.HasSuffix(path, "/graphql") {
+		return true
+	}
+
+	if strings.HasPrefix(apiLog.RequestHeaders["content-type"], "application/graphql") {
+		return true
+	}
+
+	return apiLog.RequestHeaders["x-graphql-operation-name"] != "" || apiLog.RequestHeaders["x-apollo-operation-name"] != ""
+}
+
+// identifyGraphQL Function that takes the operation from headers or the query string (GraphQL over GET)
+func identifyGraphQL(apiLog *protobuf.APILog) {
+	apiLog.ApiType = types.APITypeGraphQL
+
+	_, rawQuery := inventory.SplitPath(apiLog.Path)
+	query, _ := url.ParseQuery(rawQuery)
+
+	for _, name := range []string{
+		apiLog.RequestHeaders["x-graphql-operation-name"],
+		apiLog.RequestHeaders["x-apollo-operation-name"],
+		query.Get("operationName"),
+	} {
+		if name != "" {
+			apiLog.Operation = name
+			break
+		}
+	}
+
+	apiLog.OperationType = strings.ToLower(apiLog.RequestHeaders["x-graphql-operation-type"])
+
+	if document := query.Get("query"); document != "" {
+		if match := graphqlOperation.FindStringSubmatch(document); match != nil {
+			if apiLog.OperationType == "" {
+				apiLog.OperationType = match[1]
+			}
+			if apiLog.Operation == "" {
+				apiLog.Operation = match[2]
+			}
+		} else if apiLog.OperationType == "" && strings.HasPrefix(strings.TrimSpace(document), "{") {
+			apiLog.OperationType = "query" // shorthand
+		}
+	}
+
+	// only queries are allowed over GET
+	if apiLog.OperationType == "" && apiLog.Method == "GET" {
+		apiLog.OperationType = "query"
+	}
+}
+
+// == //
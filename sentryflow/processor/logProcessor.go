@@ -3,11 +3,18 @@
 package processor
 
 import (
+	"context"
 	"log"
 	"sync"
 
+	"github.com/5gsec/SentryFlow/coverage"
+	"github.com/5gsec/SentryFlow/egress"
 	"github.com/5gsec/SentryFlow/exporter"
+	"github.com/5gsec/SentryFlow/k8s"
+	"github.com/5gsec/SentryFlow/pipeline"
 	"github.com/5gsec/SentryFlow/protobuf"
+	"github.com/5gsec/SentryFlow/risk"
+	"github.com/5gsec/SentryFlow/types"
 )
 
 // == //
@@ -57,11 +64,8 @@ func StartLogProcessor(wg *sync.WaitGroup) bool {
 
 // StopLogProcessor Function
 func StopLogProcessor() bool {
-	// One for ProcessAPILogs
-	LogH.stopChan <- struct{}{}
-
-	// One for ProcessMetrics
-	LogH.stopChan <- struct{}{}
+	// For ProcessAPILogs and ProcessMetrics
+	close(LogH.stopChan)
 
 	log.Print("[LogProcessor] Stopped Log Processors")
 
@@ -81,8 +85,40 @@ func ProcessAPILogs(wg *sync.WaitGroup) {
 				log.Print("[LogProcessor] Failed to process an API log")
 			}
 
-			go AnalyzeAPI(logType.(*protobuf.APILog).Path)
-			go exporter.InsertAPILog(logType.(*protobuf.APILog))
+			// Skip API logs between resources out of scope (e.g., system namespaces)
+			apiLog := logType.(*protobuf.APILog)
+			if !inScope(apiLog) {
+				continue
+			}
+
+			// Record the workloads seen in access logs (for the sidecar coverage)
+			coverage.ObserveAPILog(apiLog)
+
+			// Identify the API type (REST, gRPC, GraphQL), the logical operation and the network of the destination first
+			IdentifyAPI(apiLog)
+			egress.ClassifyAPILog(apiLog)
+
+			// Pass the API log through the stages (redaction, inventory, ..., filter by default)
+			ctx := pipeline.NewContext(context.Background())
+			apiLog = pipeline.RunStages(ctx, apiLog)
+
+			for _, apiEvent := range ctx.APIEvents {
+				go exporter.InsertAPIEvent(apiEvent)
+			}
+			for _, apiFinding := range ctx.APIFindings {
+				go ReportAPIFinding(apiFinding)
+			}
+			for _, alert := range ctx.Alerts {
+				go exporter.InsertAlert(alert)
+			}
+
+			// Dropped by a stage (e.g., filter)
+			if apiLog == nil {
+				continue
+			}
+
+			go AnalyzeAPI(apiName(apiLog))
+			go exporter.InsertAPILog(apiLog)
 
 		case <-LogH.stopChan:
 			wg.Done()
@@ -91,6 +127,24 @@ func ProcessAPILogs(wg *sync.WaitGroup) {
 	}
 }
 
+// inScope Function that checks if either end of an API log is a Kubernetes resource in scope
+func inScope(apiLog *protobuf.APILog) bool {
+	if !k8s.ScopeConfigured() {
+		return true
+	}
+
+	unknown := types.K8sResourceTypeToString(types.K8sResourceTypeUnknown)
+
+	return (apiLog.SrcType != unknown && k8s.InScope(apiLog.SrcNamespace)) ||
+		(apiLog.DstType != unknown && k8s.InScope(apiLog.DstNamespace))
+}
+
+// ReportAPIFinding Function that counts a finding toward risk scores and exports it
+func ReportAPIFinding(apiFinding *protobuf.APIFinding) {
+	risk.ObserveAPIFinding(apiFinding)
+	exporter.InsertAPIFinding(apiFinding)
+}
+
 // InsertAPILog Function
 func InsertAPILog(data interface{}) {
 	LogH.apiLogChan <- data
@@ -0,0 +1,371 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package redaction
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"net/url"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/5gsec/SentryFlow/config"
+	"github.com/5gsec/SentryFlow/internal/detection"
+	"github.com/5gsec/SentryFlow/inventory"
+	"github.com/5gsec/SentryFlow/protobuf"
+	"github.com/5gsec/SentryFlow/types"
+)
+
+// == //
+
+// Redaction modes
+const (
+	ModeMask = "mask"
+	ModeHash = "hash"
+	ModeDrop = "drop"
+)
+
+// Types of sensitive data findings
+const (
+	FindingCategorySensitiveData = "SensitiveData"
+
+	FindingTypeSensitiveDataExposure = "SensitiveDataExposure"
+)
+
+// exposureReportPeriod is the period for reporting the same exposure again
+const exposureReportPeriod = time.Hour
+
+// Redactor global reference for Data Redactor
+var Redactor *DataRedactor
+
+// init Function
+func init() {
+	Redactor = NewDataRedactor()
+}
+
+// DataRedactor Structure
+type DataRedactor struct {
+	enabled  bool
+	hashSalt string
+
+	detectors   []*detector
+	secretParam *detector
+	extraParams map[string]bool
+
+	lock sync.RWMutex
+
+	reports *detection.Suppressor // exposures reported within exposureReportPeriod
+}
+
+// NewDataRedactor Function
+func NewDataRedactor() *DataRedactor {
+	dr := &DataRedactor{
+		detectors:   make([]*detector, 0),
+		extraParams: make(map[string]bool),
+
+		lock: sync.RWMutex{},
+
+		reports: detection.NewSuppressor(exposureReportPeriod),
+	}
+
+	return dr
+}
+
+// == //
+
+// StartDataRedactor Function
+func StartDataRedactor() bool {
+	if err := LoadRedaction(config.GlobalConfig.Redaction); err != nil {
+		log.Printf("[DataRedactor] Failed to load the redaction config: %v", err)
+		return false
+	}
+
+	if config.GlobalConfig.Redaction.Enabled {
+		log.Print("[DataRedactor] Started Data Redactor")
+	} else {
+		log.Print("[DataRedactor] Data Redactor is disabled")
+	}
+
+	return true
+}
+
+// validMode Function
+func validMode(mode string) bool {
+	return mode == ModeMask || mode == ModeHash || mode == ModeDrop
+}
+
+// LoadRedaction Function that replaces the detectors with the given config
+func LoadRedaction(redactionCfg config.RedactionConfig) error {
+	mode := redactionCfg.Mode
+	if mode == "" {
+		mode = ModeMask
+	}
+	if !validMode(mode) {
+		return fmt.Errorf("unsupported mode %q (mask|hash|drop)", mode)
+	}
+
+	// keys in the config file are case-insensitive
+	modes := make(map[string]string, len(redactionCfg.Modes))
+	for name, m := range redactionCfg.Modes {
+		modes[strings.ToLower(name)] = m
+	}
+
+	modeOf := func(name, fallback string) (string, error) {
+		if m, ok := modes[strings.ToLower(name)]; ok {
+			if !validMode(m) {
+				return "", fmt.Errorf("unsupported mode %q for %s (mask|hash|drop)", m, name)
+			}
+			return m, nil
+		}
+		if fallback != "" {
+			return fallback, nil
+		}
+		return mode, nil
+	}
+
+	enabled := make(map[string]bool)
+	for _, name := range redactionCfg.Detectors {
+		enabled[name] = true
+	}
+
+	detectors := make([]*detector, 0)
+
+	// built-in detectors
+	for _, builtin := range builtinDetectors {
+		if len(enabled) > 0 && !enabled[builtin.name] {
+			continue
+		}
+
+		det := *builtin
+
+		m, err := modeOf(det.name, "")
+		if err != nil {
+			return err
+		}
+		det.mode = m
+
+		detectors = append(detectors, &det)
+	}
+
+	// custom detectors
+	for _, patternCfg := range redactionCfg.Patterns {
+		if patternCfg.Name == "" {
+			return fmt.Errorf("name is required for custom patterns")
+		}
+
+		pattern, err := regexp.Compile(patternCfg.Regex)
+		if err != nil {
+			return fmt.Errorf("invalid pattern %s: %v", patternCfg.Name, err)
+		}
+
+		m, err := modeOf(patternCfg.Name, patternCfg.Mode)
+		if err != nil {
+			return err
+		}
+		if !validMode(m) {
+			return fmt.Errorf("unsupported mode %q for %s (mask|hash|drop)", m, patternCfg.Name)
+		}
+
+		severity := patternCfg.Severity
+		if severity == "" {
+			severity = types.SeverityMedium
+		}
+
+		detectors = append(detectors, &detector{
+			name:     patternCfg.Name,
+			severity: severity,
+			mode:     m,
+			pattern:  pattern,
+		})
+	}
+
+	// values of secret query parameters
+	secretMode, err := modeOf(DetectorSecretParam, "")
+	if err != nil {
+		return err
+	}
+
+	extraParams := make(map[string]bool)
+	for _, name := range redactionCfg.QueryParams {
+		extraParams[strings.ToLower(name)] = true
+	}
+
+	Redactor.lock.Lock()
+	defer Redactor.lock.Unlock()
+
+	Redactor.enabled = redactionCfg.Enabled
+	Redactor.hashSalt = redactionCfg.HashSalt
+	Redactor.detectors = detectors
+	Redactor.secretParam = &detector{name: DetectorSecretParam, severity: types.SeverityHigh, mode: secretMode}
+	Redactor.extraParams = extraParams
+
+	return nil
+}
+
+// == //
+
+// hit Structure
+type hit struct {
+	detector *detector
+	location string
+}
+
+// redactValue Function that replaces a sensitive value according to the mode of its detector
+func (dr *DataRedactor) redactValue(det *detector, value string) string {
+	switch det.mode {
+	case ModeHash:
+		sum := sha256.Sum256([]byte(dr.hashSalt + value))
+		return fmt.Sprintf("[%s:%s]", det.name, hex.EncodeToString(sum[:6]))
+	case ModeDrop:
+		return ""
+	}
+
+	return fmt.Sprintf("[REDACTED:%s]", det.name)
+}
+
+// redactText Function that applies every detector to a text
+func (dr *DataRedactor) redactText(text, location string, hits []hit) (string, []hit) {
+	for _, det := range dr.detectors {
+		matches := det.pattern.FindAllStringSubmatchIndex(text, -1)
+		if len(matches) == 0 {
+			continue
+		}
+
+		var sb strings.Builder
+		found := false
+		last := 0
+
+		for _, loc := range matches {
+			// only the first group is sensitive if the pattern has groups (e.g., the value of ;jsessionid=...)
+			start, end := loc[0], loc[1]
+			if len(loc) >= 4 && loc[2] >= 0 {
+				start, end = loc[2], loc[3]
+			}
+
+			value := text[start:end]
+			if det.validate != nil && !det.validate(value) {
+				continue
+			}
+
+			sb.WriteString(text[last:start])
+			sb.WriteString(dr.redactValue(det, value))
+			last = end
+			found = true
+		}
+
+		if found {
+			sb.WriteString(text[last:])
+			text = sb.String()
+			hits = append(hits, hit{detector: det, location: location})
+		}
+	}
+
+	return text, hits
+}
+
+// isSecretParam Function
+func (dr *DataRedactor) isSecretParam(name string) bool {
+	if decoded, err := url.QueryUnescape(name); err == nil {
+		name = decoded
+	}
+	return secretParams.MatchString(name) || dr.extraParams[strings.ToLower(name)]
+}
+
+// redactURL Function that redacts the path and the query string of a URL
+func (dr *DataRedactor) redactURL(rawURL, location string, hits []hit) (string, []hit) {
+	path, query, hasQuery := strings.Cut(rawURL, "?")
+
+	path, hits = dr.redactText(path, location+" path", hits)
+	if !hasQuery {
+		return path, hits
+	}
+
+	params := strings.Split(query, "&")
+	for idx, param := range params {
+		name, value, ok := strings.Cut(param, "=")
+		if !ok || value == "" {
+			continue
+		}
+
+		paramLocation := fmt.Sprintf("%s query parameter '%s'", location, name)
+
+		if dr.isSecretParam(name) {
+			value = dr.redactValue(dr.secretParam, value)
+			hits = append(hits, hit{detector: dr.secretParam, location: paramLocation})
+		} else {
+			value, hits = dr.redactText(value, paramLocation, hits)
+		}
+
+		params[idx] = name + "=" + value
+	}
+
+	return path + "?" + strings.Join(params, "&"), hits
+}
+
+// shouldReport Function that suppresses the same exposure within exposureReportPeriod
+func (dr *DataRedactor) shouldReport(key string) bool {
+	return dr.reports.ShouldReport(key, time.Now())
+}
+
+// RedactAPILog Function that redacts sensitive data in an API log in place and reports what was found
+func RedactAPILog(apiLog *protobuf.APILog) []*protobuf.APIFinding {
+	Redactor.lock.RLock()
+	defer Redactor.lock.RUnlock()
+
+	if !Redactor.enabled {
+		return nil
+	}
+
+	hits := make([]hit, 0)
+
+	apiLog.Path, hits = Redactor.redactURL(apiLog.Path, "request", hits)
+
+	if referer, ok := apiLog.RequestHeaders["referer"]; ok {
+		apiLog.RequestHeaders["referer"], hits = Redactor.redactURL(referer, "referer", hits)
+	}
+
+	if len(hits) == 0 {
+		return nil
+	}
+
+	workload := inventory.DstWorkload(apiLog)
+	endpoint, _ := inventory.EndpointTemplate(apiLog)
+
+	findings := make([]*protobuf.APIFinding, 0)
+
+	for _, h := range hits {
+		key := fmt.Sprintf("%s/%s %s %s %s %s", apiLog.DstNamespace, workload, apiLog.Method, endpoint, h.detector.name, h.location)
+		if !Redactor.shouldReport(key) {
+			continue
+		}
+
+		findings = append(findings, &protobuf.APIFinding{
+			TimeStamp:   apiLog.TimeStamp,
+			Category:    FindingCategorySensitiveData,
+			Type:        FindingTypeSensitiveDataExposure,
+			Severity:    h.detector.severity,
+			Description: fmt.Sprintf("%s found in the %s of %s %s on %s/%s", h.detector.name, h.location, apiLog.Method, endpoint, apiLog.DstNamespace, workload),
+
+			Namespace: apiLog.DstNamespace,
+			Workload:  workload,
+			Method:    apiLog.Method,
+			Path:      endpoint,
+
+			Evidence: map[string]string{
+				"detector": h.detector.name,
+				"location": h.location,
+				"mode":     h.detector.mode,
+				"caller":   fmt.Sprintf("%s/%s", apiLog.SrcNamespace, inventory.SrcWorkload(apiLog)),
+			},
+			Samples: []*protobuf.APILog{apiLog},
+		})
+	}
+
+	return findings
+}
+
+// == //
@@ -0,0 +1,102 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package redaction
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/5gsec/SentryFlow/types"
+)
+
+// == //
+
+// Built-in detectors
+const (
+	DetectorEmail       = "email"
+	DetectorCreditCard  = "creditCard"
+	DetectorJWT         = "jwt"
+	DetectorAPIKey      = "apiKey"
+	DetectorSessionID   = "sessionId"
+	DetectorSecretParam = "secretParam"
+)
+
+// detector Structure (only the first group is redacted if the pattern has groups)
+type detector struct {
+	name     string
+	severity string
+	mode     string
+
+	pattern  *regexp.Regexp
+	validate func(value string) bool
+}
+
+// builtinDetectors are the detectors applied to paths and query values
+var builtinDetectors = []*detector{
+	{
+		name:     DetectorJWT,
+		severity: types.SeverityHigh,
+		pattern:  regexp.MustCompile(`eyJ[A-Za-z0-9_-]{5,}\.eyJ[A-Za-z0-9_-]{5,}\.[A-Za-z0-9_-]*`),
+	},
+	{
+		// AWS, GitHub, Slack, Stripe and Google API keys
+		name:     DetectorAPIKey,
+		severity: types.SeverityHigh,
+		pattern:  regexp.MustCompile(`\b(?:AKIA[0-9A-Z]{16}|gh[pousr]_[A-Za-z0-9]{36}|xox[abprs]-[A-Za-z0-9-]{10,}|[sr]k_live_[0-9A-Za-z]{24,}|AIza[0-9A-Za-z_-]{35})\b`),
+	},
+	{
+		name:     DetectorEmail,
+		severity: types.SeverityMedium,
+		pattern:  regexp.MustCompile(`[A-Za-z0-9._+-]+(?:@|%40)[A-Za-z0-9-]+(?:\.[A-Za-z0-9-]+)*\.[A-Za-z]{2,}`),
+	},
+	{
+		// Visa, Mastercard, American Express and Discover numbers (with optional separators)
+		name:     DetectorCreditCard,
+		severity: types.SeverityHigh,
+		pattern:  regexp.MustCompile(`\b(?:4\d{3}|5[1-5]\d{2}|2[2-7]\d{2}|3[47]\d{2}|6(?:011|5\d{2}))(?:[ -]?\d){9,15}\b`),
+		validate: isCardNumber,
+	},
+	{
+		// session IDs carried in path parameters (e.g., /cart;jsessionid=...)
+		name:     DetectorSessionID,
+		severity: types.SeverityMedium,
+		pattern:  regexp.MustCompile(`(?i);(?:jsessionid|phpsessid|sessionid|sid)=([^/?;&#]+)`),
+	},
+}
+
+// secretParams are the query parameters whose values are always redacted
+var secretParams = regexp.MustCompile(`(?i)^(?:access_?token|refresh_?token|id_?token|token|api_?key|apikey|key|secret|client_?secret|password|passwd|pwd|auth|authorization|signature|sig|x-amz-signature|x-amz-credential|session|session_?id|sessionid|sid|jsessionid|phpsessid|code)$`)
+
+// == //
+
+// isCardNumber Function that validates a card number with its length and Luhn checksum
+func isCardNumber(value string) bool {
+	digits := strings.NewReplacer(" ", "", "-", "").Replace(value)
+	if len(digits) < 13 || len(digits) > 19 {
+		return false
+	}
+
+	sum := 0
+	double := false
+
+	for i := len(digits) - 1; i >= 0; i-- {
+		d := int(digits[i] - '0')
+		if d < 0 || d > 9 {
+			return false
+		}
+
+		if double {
+			d *= 2
+			if d > 9 {
+				d -= 9
+			}
+		}
+
+		sum += d
+		double = !double
+	}
+
+	return sum%10 == 0
+}
+
+// == //
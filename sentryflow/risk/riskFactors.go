@@ -0,0 +1,252 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package risk
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/5gsec/SentryFlow/inventory"
+	"github.com/5gsec/SentryFlow/k8s"
+	"github.com/5gsec/SentryFlow/openapi"
+	"github.com/5gsec/SentryFlow/protobuf"
+	"github.com/5gsec/SentryFlow/types"
+)
+
+// == //
+
+// Risk factors
+const (
+	FactorExposure        = "exposure"
+	FactorSensitiveData   = "sensitiveData"
+	FactorUnauthenticated = "unauthenticated"
+	FactorErrors          = "errors"
+	FactorAnomalies       = "anomalies"
+	FactorUndocumented    = "undocumented"
+)
+
+// factorWeights are the maximum contributions of factors to a risk score (100 in total)
+var factorWeights = map[string]float64{
+	FactorExposure:        30,
+	FactorSensitiveData:   20,
+	FactorUnauthenticated: 20,
+	FactorErrors:          10,
+	FactorAnomalies:       10,
+	FactorUndocumented:    10,
+}
+
+// factorOrder is the order of factors in a breakdown
+var factorOrder = []string{FactorExposure, FactorSensitiveData, FactorUnauthenticated, FactorErrors, FactorAnomalies, FactorUndocumented}
+
+// severityValues are the values of the sensitive data factor by the severity of findings
+var severityValues = map[string]float64{
+	types.SeverityInfo:     0.1,
+	types.SeverityLow:      0.25,
+	types.SeverityMedium:   0.5,
+	types.SeverityHigh:     0.8,
+	types.SeverityCritical: 1,
+}
+
+const (
+	// errorRatioScale is the ratio of server errors that makes the errors factor reach its weight
+	errorRatioScale = 0.2
+
+	// anomalyScale is the number of anomalies that makes the anomalies factor reach its weight
+	anomalyScale = 5
+)
+
+// exposureIndex Structure (workloads reachable from outside of the cluster, by namespace/workload as in the API inventory)
+type exposureIndex struct {
+	gateways map[string]k8s.Exposure // ingress gateways (as callers)
+	backends map[string]k8s.Exposure // workloads behind LoadBalancer or NodePort services, or Ingress resources
+}
+
+// loadExposure Function that takes the exposure of workloads from gateways, services and Ingress resources
+func loadExposure() exposureIndex {
+	ex := exposureIndex{
+		gateways: make(map[string]k8s.Exposure),
+		backends: make(map[string]k8s.Exposure),
+	}
+
+	for _, exposure := range k8s.ExposedPods() {
+		pod := exposure.Pod
+		key := pod.Namespace + "/" + inventory.WorkloadOf(pod.WorkloadKind, pod.WorkloadName, pod.Name, pod.Labels)
+
+		if exposure.Kind == k8s.ExposureGateway {
+			ex.gateways[key] = exposure
+		} else if current, ok := ex.backends[key]; !ok || current.Kind != k8s.ExposureService {
+			ex.backends[key] = exposure
+		}
+	}
+
+	return ex
+}
+
+// == //
+
+// scoreEndpoint Function that gives the risk score of an endpoint with its breakdown
+func scoreEndpoint(api *protobuf.APIEndpoint, history endpointHistory, ex exposureIndex) *protobuf.RiskScore {
+	values := map[string]float64{}
+	details := map[string]string{}
+
+	values[FactorExposure], details[FactorExposure] = exposureOf(api, ex)
+	values[FactorSensitiveData], details[FactorSensitiveData] = sensitiveDataOf(history)
+	values[FactorUnauthenticated], details[FactorUnauthenticated] = unauthenticatedOf(api)
+	values[FactorErrors], details[FactorErrors] = errorsOf(api)
+	values[FactorAnomalies], details[FactorAnomalies] = anomaliesOf(history)
+	values[FactorUndocumented], details[FactorUndocumented] = undocumentedOf(api)
+
+	score := &protobuf.RiskScore{
+		Id:           api.Id,
+		Namespace:    api.Namespace,
+		Workload:     api.Workload,
+		Method:       api.Method,
+		PathTemplate: api.PathTemplate,
+		Factors:      make([]*protobuf.RiskFactor, 0, len(factorOrder)),
+	}
+
+	for _, name := range factorOrder {
+		weight := factorWeights[name]
+		contribution := round(weight * values[name])
+
+		score.Factors = append(score.Factors, &protobuf.RiskFactor{
+			Name:         name,
+			Weight:       weight,
+			Value:        round(values[name]),
+			Contribution: contribution,
+			Detail:       details[name],
+		})
+		score.Score += contribution
+	}
+	score.Score = round(score.Score)
+
+	return score
+}
+
+// round Function (to 2 decimal places)
+func round(value float64) float64 {
+	return math.Round(value*100) / 100
+}
+
+// == //
+
+// exposureOf Function that tells whether an endpoint is reachable from outside of the cluster
+// Workloads behind LoadBalancer or NodePort services are exposed directly, and the ones behind Ingress resources or called by ingress gateways through them
+func exposureOf(api *protobuf.APIEndpoint, ex exposureIndex) (float64, string) {
+	workload := api.Namespace + "/" + api.Workload
+
+	if backend, ok := ex.backends[workload]; ok && backend.Kind == k8s.ExposureService {
+		return 1, "served through the service " + backend.Via
+	}
+
+	if gateway, ok := ex.gateways[workload]; ok {
+		return 1, "served by the ingress gateway " + gateway.Via
+	}
+
+	gateways := make([]string, 0)
+	for caller, count := range api.Callers {
+		if _, ok := ex.gateways[caller]; ok {
+			gateways = append(gateways, fmt.Sprintf("%s (%d calls)", caller, count))
+		}
+	}
+	if len(gateways) > 0 {
+		sort.Strings(gateways)
+		return 0.8, "called through the ingress gateways " + strings.Join(gateways, ", ")
+	}
+
+	if backend, ok := ex.backends[workload]; ok {
+		return 0.8, "a backend of the Ingress " + backend.Via
+	}
+
+	return 0, "not behind gateways, Ingress resources, LoadBalancer or NodePort services"
+}
+
+// sensitiveDataOf Function that takes the most severe sensitive data finding
+func sensitiveDataOf(history endpointHistory) (float64, string) {
+	if len(history.sensitive) == 0 {
+		return 0, "no sensitive data found"
+	}
+
+	value := 0.0
+	detectors := map[string]bool{}
+
+	for _, record := range history.sensitive {
+		value = math.Max(value, severityValues[record.severity])
+		if record.detail != "" {
+			detectors[record.detail] = true
+		}
+	}
+
+	names := make([]string, 0, len(detectors))
+	for name := range detectors {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return value, fmt.Sprintf("%d findings (%s)", len(history.sensitive), strings.Join(names, ", "))
+}
+
+// unauthenticatedOf Function that gives the ratio of calls without credentials
+func unauthenticatedOf(api *protobuf.APIEndpoint) (float64, string) {
+	if api.CallCount == 0 {
+		return 0, "no calls"
+	}
+
+	unauthenticated := api.CallCount - min(api.AuthenticatedCalls, api.CallCount)
+
+	return float64(unauthenticated) / float64(api.CallCount), fmt.Sprintf("%d of %d calls without credentials", unauthenticated, api.CallCount)
+}
+
+// errorsOf Function that gives the ratio of server errors (20% of calls failing makes the most)
+func errorsOf(api *protobuf.APIEndpoint) (float64, string) {
+	if api.CallCount == 0 {
+		return 0, "no calls"
+	}
+
+	serverErrors := inventory.ServerErrorCalls(api)
+	ratio := float64(serverErrors) / float64(api.CallCount)
+
+	return math.Min(1, ratio/errorRatioScale), fmt.Sprintf("%d of %d calls failed with server errors", serverErrors, api.CallCount)
+}
+
+// anomaliesOf Function that counts anomalies and security findings
+func anomaliesOf(history endpointHistory) (float64, string) {
+	if len(history.anomalies) == 0 {
+		return 0, "no anomalies"
+	}
+
+	counts := map[string]int{}
+	for _, record := range history.anomalies {
+		counts[record.detail]++
+	}
+
+	parts := make([]string, 0, len(counts))
+	for name, count := range counts {
+		parts = append(parts, fmt.Sprintf("%s x%d", name, count))
+	}
+	sort.Strings(parts)
+
+	return math.Min(1, float64(len(history.anomalies))/anomalyScale), strings.Join(parts, ", ")
+}
+
+// undocumentedOf Function that checks whether an endpoint is declared in the API spec of its workload
+func undocumentedOf(api *protobuf.APIEndpoint) (float64, string) {
+	if api.ApiType == types.APITypeGRPC {
+		return 0, "gRPC method (described by protobuf definitions)"
+	}
+
+	hasSpec, documented := openapi.IsDocumented(api.Namespace, api.Workload, api.Method, api.PathTemplate)
+
+	switch {
+	case documented:
+		return 0, "declared in the API spec"
+	case hasSpec:
+		return 1, "missing from the API spec"
+	default:
+		return 0.5, "no API spec for the workload"
+	}
+}
+
+// == //
@@ -0,0 +1,349 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package risk
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/5gsec/SentryFlow/anomaly"
+	"github.com/5gsec/SentryFlow/inventory"
+	"github.com/5gsec/SentryFlow/owasp"
+	"github.com/5gsec/SentryFlow/protobuf"
+	"github.com/5gsec/SentryFlow/redaction"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// == //
+
+// Levels of risk scores
+const (
+	LevelEndpoint = "endpoint"
+	LevelWorkload = "workload"
+)
+
+const (
+	// historyPeriod is the period for which findings count toward risk scores
+	historyPeriod = 7 * 24 * time.Hour
+
+	// maxFindingsPerEndpoint is the maximum number of findings remembered per endpoint
+	maxFindingsPerEndpoint = 100
+
+	// highRiskScore is the score from which an endpoint is considered high risk
+	highRiskScore = 50.0
+
+	// refreshPeriod is the period for updating the risk scores in Prometheus metrics
+	refreshPeriod = time.Minute
+
+	// maxEndpointSeries is the maximum number of endpoints (the riskiest ones) with their own series in Prometheus metrics
+	// Paths are unbounded, so the rest are only counted per workload
+	maxEndpointSeries = 100
+)
+
+var (
+	endpointRiskScore = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "sentryflow_endpoint_risk_score",
+		Help: "Risk score (0-100) of the riskiest API endpoints",
+	}, []string{"namespace", "workload", "method", "path"})
+
+	workloadHighRiskEndpoints = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "sentryflow_workload_high_risk_endpoints",
+		Help: "High risk API endpoints of each workload",
+	}, []string{"namespace", "workload"})
+
+	workloadRiskScore = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "sentryflow_workload_risk_score",
+		Help: "Risk score (0-100) of each workload (the score of its riskiest endpoint)",
+	}, []string{"namespace", "workload"})
+
+	workloadRiskFactor = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "sentryflow_workload_risk_factor",
+		Help: "Contribution of each factor to the risk score of each workload",
+	}, []string{"namespace", "workload", "factor"})
+)
+
+// RiskS global reference for Risk Scorer
+var RiskS *RiskScorer
+
+// init Function
+func init() {
+	RiskS = NewRiskScorer()
+}
+
+// findingRecord Structure
+type findingRecord struct {
+	findingType string
+	severity    string
+	detail      string
+	seen        time.Time
+}
+
+// endpointHistory Structure (findings reported for an endpoint)
+type endpointHistory struct {
+	sensitive []findingRecord
+	anomalies []findingRecord
+}
+
+// RiskScorer Structure
+type RiskScorer struct {
+	stopChan chan struct{}
+
+	history     map[string]*endpointHistory
+	historyLock sync.Mutex
+}
+
+// NewRiskScorer Function
+func NewRiskScorer() *RiskScorer {
+	rs := &RiskScorer{
+		stopChan: make(chan struct{}),
+
+		history:     make(map[string]*endpointHistory),
+		historyLock: sync.Mutex{},
+	}
+
+	return rs
+}
+
+// == //
+
+// StartRiskScorer Function
+func StartRiskScorer(wg *sync.WaitGroup) bool {
+	// update risk scores in Prometheus metrics
+	go refreshRiskScores(wg)
+
+	log.Print("[RiskScorer] Started Risk Scorer")
+
+	return true
+}
+
+// StopRiskScorer Function
+func StopRiskScorer() bool {
+	close(RiskS.stopChan)
+
+	log.Print("[RiskScorer] Stopped Risk Scorer")
+
+	return true
+}
+
+// refreshRiskScores Function
+func refreshRiskScores(wg *sync.WaitGroup) {
+	wg.Add(1)
+
+	ticker := time.NewTicker(refreshPeriod)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			RiskS.forget(time.Now())
+			updateMetrics()
+		case <-RiskS.stopChan:
+			wg.Done()
+			return
+		}
+	}
+}
+
+// updateMetrics Function
+func updateMetrics() {
+	endpointRiskScore.Reset()
+	workloadRiskScore.Reset()
+	workloadRiskFactor.Reset()
+	workloadHighRiskEndpoints.Reset()
+
+	scores := ScoreEndpoints("", "")
+	if len(scores) > maxEndpointSeries {
+		scores = scores[:maxEndpointSeries]
+	}
+	for _, score := range scores {
+		endpointRiskScore.WithLabelValues(score.Namespace, score.Workload, score.Method, score.PathTemplate).Set(score.Score)
+	}
+
+	for _, score := range ScoreWorkloads("", "") {
+		workloadRiskScore.WithLabelValues(score.Namespace, score.Workload).Set(score.Score)
+		workloadHighRiskEndpoints.WithLabelValues(score.Namespace, score.Workload).Set(float64(score.HighRiskEndpoints))
+		for _, factor := range score.Factors {
+			workloadRiskFactor.WithLabelValues(score.Namespace, score.Workload, factor.Name).Set(factor.Contribution)
+		}
+	}
+}
+
+// == //
+
+// findingKey Function (the same as the ID of the endpoint in the API inventory)
+func findingKey(namespace, workload, method, endpoint string) string {
+	return fmt.Sprintf("%s/%s %s %s", namespace, workload, method, endpoint)
+}
+
+// ObserveAPIFinding Function that remembers findings toward the risk score of their endpoint
+func ObserveAPIFinding(finding *protobuf.APIFinding) {
+	record := findingRecord{findingType: finding.Type, severity: finding.Severity, seen: time.Now()}
+
+	var category string
+
+	switch finding.Category {
+	case redaction.FindingCategorySensitiveData:
+		category = redaction.FindingCategorySensitiveData
+		record.detail = finding.Evidence["detector"]
+	case anomaly.FindingCategoryAnomaly, owasp.FindingCategorySecurity:
+		category = anomaly.FindingCategoryAnomaly
+		record.detail = finding.Type
+	default:
+		return
+	}
+
+	key := findingKey(finding.Namespace, finding.Workload, finding.Method, finding.Path)
+
+	RiskS.historyLock.Lock()
+	defer RiskS.historyLock.Unlock()
+
+	history, ok := RiskS.history[key]
+	if !ok {
+		history = &endpointHistory{}
+		RiskS.history[key] = history
+	}
+
+	if category == redaction.FindingCategorySensitiveData {
+		history.sensitive = appendFinding(history.sensitive, record)
+	} else {
+		history.anomalies = appendFinding(history.anomalies, record)
+	}
+}
+
+// appendFinding Function (dropping the oldest finding once full)
+func appendFinding(records []findingRecord, record findingRecord) []findingRecord {
+	if len(records) >= maxFindingsPerEndpoint {
+		records = records[1:]
+	}
+	return append(records, record)
+}
+
+// forget Function that drops findings older than the history period
+func (rs *RiskScorer) forget(now time.Time) {
+	rs.historyLock.Lock()
+	defer rs.historyLock.Unlock()
+
+	for key, history := range rs.history {
+		history.sensitive = recentFindings(history.sensitive, now)
+		history.anomalies = recentFindings(history.anomalies, now)
+
+		if len(history.sensitive) == 0 && len(history.anomalies) == 0 {
+			delete(rs.history, key)
+		}
+	}
+}
+
+// recentFindings Function
+func recentFindings(records []findingRecord, now time.Time) []findingRecord {
+	idx := sort.Search(len(records), func(i int) bool {
+		return now.Sub(records[i].seen) <= historyPeriod
+	})
+	return records[idx:]
+}
+
+// historyOf Function (a copy of the findings of an endpoint)
+func (rs *RiskScorer) historyOf(key string) endpointHistory {
+	rs.historyLock.Lock()
+	defer rs.historyLock.Unlock()
+
+	history, ok := rs.history[key]
+	if !ok {
+		return endpointHistory{}
+	}
+
+	return endpointHistory{
+		sensitive: append([]findingRecord(nil), history.sensitive...),
+		anomalies: append([]findingRecord(nil), history.anomalies...),
+	}
+}
+
+// == //
+
+// ScoreEndpoints Function that gives the risk scores of endpoints (riskiest first)
+func ScoreEndpoints(namespace, workload string) []*protobuf.RiskScore {
+	apis := inventory.ListAPIs(&protobuf.APIQuery{Namespace: namespace, Workload: workload})
+
+	ex := loadExposure()
+
+	scores := make([]*protobuf.RiskScore, 0, len(apis))
+	for _, api := range apis {
+		scores = append(scores, scoreEndpoint(api, RiskS.historyOf(api.Id), ex))
+	}
+
+	sortScores(scores)
+
+	return scores
+}
+
+// ScoreWorkloads Function that gives the risk scores of workloads (riskiest first)
+func ScoreWorkloads(namespace, workload string) []*protobuf.RiskScore {
+	workloads := make(map[string]*protobuf.RiskScore)
+
+	for _, score := range ScoreEndpoints(namespace, workload) {
+		id := score.Namespace + "/" + score.Workload
+
+		wl, ok := workloads[id]
+		if !ok {
+			// endpoints come riskiest first, so the first endpoint of a workload decides its score
+			wl = &protobuf.RiskScore{
+				Id:        id,
+				Namespace: score.Namespace,
+				Workload:  score.Workload,
+				Score:     score.Score,
+				Factors:   score.Factors,
+			}
+			workloads[id] = wl
+		}
+
+		wl.Endpoints++
+		if score.Score >= highRiskScore {
+			wl.HighRiskEndpoints++
+		}
+	}
+
+	scores := make([]*protobuf.RiskScore, 0, len(workloads))
+	for _, wl := range workloads {
+		scores = append(scores, wl)
+	}
+
+	sortScores(scores)
+
+	return scores
+}
+
+// GetRiskScores Function
+func GetRiskScores(query *protobuf.RiskQuery) (*protobuf.RiskScores, error) {
+	var scores []*protobuf.RiskScore
+
+	switch query.Level {
+	case "", LevelEndpoint:
+		scores = ScoreEndpoints(query.Namespace, query.Workload)
+	case LevelWorkload:
+		scores = ScoreWorkloads(query.Namespace, query.Workload)
+	default:
+		return nil, fmt.Errorf("unknown level %q (endpoint|workload)", query.Level)
+	}
+
+	if query.Limit > 0 && int(query.Limit) < len(scores) {
+		scores = scores[:query.Limit]
+	}
+
+	return &protobuf.RiskScores{Scores: scores}, nil
+}
+
+// sortScores Function
+func sortScores(scores []*protobuf.RiskScore) {
+	sort.Slice(scores, func(i, j int) bool {
+		if scores[i].Score != scores[j].Score {
+			return scores[i].Score > scores[j].Score
+		}
+		return scores[i].Id < scores[j].Id
+	})
+}
+
+// == //
@@ -0,0 +1,419 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package rules
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/5gsec/SentryFlow/config"
+	"github.com/5gsec/SentryFlow/protobuf"
+	"github.com/5gsec/SentryFlow/types"
+	"google.golang.org/protobuf/proto"
+)
+
+// == //
+
+// defaultSamples is the number of API logs attached to an alert by default
+const defaultSamples = 5
+
+// maxGroups is the maximum number of groups (or new values) tracked per rule
+const maxGroups = 10000
+
+// RuleEng global reference for Rule Engine
+var RuleEng *RuleEngine
+
+// init Function
+func init() {
+	RuleEng = NewRuleEngine()
+}
+
+// ruleGroup Structure
+type ruleGroup struct {
+	group map[string]string
+
+	hits    []time.Time
+	samples []*protobuf.APILog
+
+	lastAlert time.Time
+	lastSeen  time.Time
+}
+
+// ruleState Structure (groups of a rule, kept when the rule is reloaded unchanged)
+type ruleState struct {
+	groups    map[string]*ruleGroup
+	newValues map[string]bool
+	stateLock sync.Mutex
+}
+
+// alertRule Structure
+type alertRule struct {
+	config.AlertRuleConfig
+
+	condition *Expression
+	loadedAt  time.Time
+
+	*ruleState
+}
+
+// RuleEngine Structure
+type RuleEngine struct {
+	stopChan chan struct{}
+
+	rules     []*alertRule // replaced as a whole (never changed in place) by LoadRules
+	rulesLock sync.RWMutex
+}
+
+// NewRuleEngine Function
+func NewRuleEngine() *RuleEngine {
+	re := &RuleEngine{
+		stopChan: make(chan struct{}),
+
+		rules:     make([]*alertRule, 0),
+		rulesLock: sync.RWMutex{},
+	}
+
+	return re
+}
+
+// == //
+
+// StartRuleEngine Function
+func StartRuleEngine(wg *sync.WaitGroup) bool {
+	// forget idle groups
+	go cleanUpGroups(wg)
+
+	if err := LoadRules(config.GlobalConfig.AlertRules); err != nil {
+		log.Printf("[RuleEngine] Failed to load alert rules: %v", err)
+		return false
+	}
+
+	log.Printf("[RuleEngine] Started Rule Engine (%d rules)", len(config.GlobalConfig.AlertRules))
+
+	return true
+}
+
+// StopRuleEngine Function
+func StopRuleEngine() bool {
+	close(RuleEng.stopChan)
+
+	log.Print("[RuleEngine] Stopped Rule Engine")
+
+	return true
+}
+
+// cleanUpGroups Function
+func cleanUpGroups(wg *sync.WaitGroup) {
+	wg.Add(1)
+
+	ticker := time.NewTicker(time.Minute)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			RuleEng.cleanUpGroups(time.Now())
+		case <-RuleEng.stopChan:
+			wg.Done()
+			return
+		}
+	}
+}
+
+// == //
+
+// compileRule Function
+func compileRule(ruleCfg config.AlertRuleConfig) (*alertRule, error) {
+	if ruleCfg.Name == "" {
+		return nil, errors.New("name is required")
+	}
+
+	condition, err := Compile(ruleCfg.Condition)
+	if err != nil {
+		return nil, fmt.Errorf("invalid condition: %v", err)
+	}
+
+	for _, name := range append(append([]string{}, ruleCfg.GroupBy...), ruleCfg.NewValueOf) {
+		if name == "" {
+			continue
+		}
+		if _, err := newField(name); err != nil {
+			return nil, err
+		}
+	}
+
+	if ruleCfg.Threshold > 0 && ruleCfg.Window <= 0 {
+		return nil, errors.New("window is required with threshold")
+	}
+
+	if ruleCfg.Severity == "" {
+		ruleCfg.Severity = types.SeverityMedium
+	}
+
+	if ruleCfg.Samples <= 0 {
+		ruleCfg.Samples = defaultSamples
+	}
+
+	// threshold rules report once per window unless a cooldown is given
+	if ruleCfg.Threshold > 0 && ruleCfg.Cooldown <= 0 {
+		ruleCfg.Cooldown = ruleCfg.Window
+	}
+
+	rule := &alertRule{
+		AlertRuleConfig: ruleCfg,
+
+		condition: condition,
+		loadedAt:  time.Now(),
+
+		ruleState: &ruleState{
+			groups:    make(map[string]*ruleGroup),
+			newValues: make(map[string]bool),
+		},
+	}
+
+	return rule, nil
+}
+
+// LoadRules Function that replaces the alert rules (the state of unchanged rules is kept)
+func LoadRules(ruleCfgs []config.AlertRuleConfig) error {
+	rules := make([]*alertRule, 0, len(ruleCfgs))
+	names := make(map[string]bool)
+
+	for _, ruleCfg := range ruleCfgs {
+		rule, err := compileRule(ruleCfg)
+		if err != nil {
+			return fmt.Errorf("rule %q: %v", ruleCfg.Name, err)
+		}
+
+		if names[rule.Name] {
+			return fmt.Errorf("rule %q: duplicated name", rule.Name)
+		}
+		names[rule.Name] = true
+
+		rules = append(rules, rule)
+	}
+
+	RuleEng.rulesLock.Lock()
+	defer RuleEng.rulesLock.Unlock()
+
+	for _, rule := range rules {
+		for _, old := range RuleEng.rules {
+			if old.Name == rule.Name && sameRule(old.AlertRuleConfig, rule.AlertRuleConfig) {
+				rule.loadedAt = old.loadedAt
+				rule.ruleState = old.ruleState
+			}
+		}
+	}
+
+	RuleEng.rules = rules
+
+	return nil
+}
+
+// sameRule Function
+func sameRule(a, b config.AlertRuleConfig) bool {
+	return a.Condition == b.Condition && a.Window == b.Window && a.Threshold == b.Threshold &&
+		strings.Join(a.GroupBy, ",") == strings.Join(b.GroupBy, ",") && a.NewValueOf == b.NewValueOf
+}
+
+// == //
+
+// groupOf Function that gives the values of the groupBy fields of an API log
+func (rule *alertRule) groupOf(apiLog *protobuf.APILog) (string, map[string]string) {
+	if len(rule.GroupBy) == 0 {
+		return "", nil
+	}
+
+	group := make(map[string]string, len(rule.GroupBy))
+	keys := make([]string, 0, len(rule.GroupBy))
+
+	for _, name := range rule.GroupBy {
+		value, _ := FieldValue(apiLog, name)
+		group[name] = value
+		keys = append(keys, name+"="+value)
+	}
+
+	return strings.Join(keys, ","), group
+}
+
+// describe Function
+func (rule *alertRule) describe(group map[string]string, count int, value string) string {
+	description := rule.Description
+	if description == "" {
+		description = fmt.Sprintf("Rule %s matched", rule.Name)
+	}
+
+	details := make([]string, 0)
+
+	if rule.NewValueOf != "" {
+		details = append(details, fmt.Sprintf("new %s %q", rule.NewValueOf, value))
+	} else if rule.Threshold > 0 {
+		details = append(details, fmt.Sprintf("%d matches within %s", count, rule.Window))
+	}
+
+	names := make([]string, 0, len(group))
+	for name := range group {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		if name == rule.NewValueOf {
+			continue
+		}
+		details = append(details, fmt.Sprintf("%s=%s", name, group[name]))
+	}
+
+	if len(details) > 0 {
+		description += " (" + strings.Join(details, ", ") + ")"
+	}
+
+	return description
+}
+
+// evaluate Function that evaluates a rule with an API log
+func (rule *alertRule) evaluate(apiLog *protobuf.APILog, now time.Time) *protobuf.Alert {
+	if !rule.condition.Match(apiLog) {
+		return nil
+	}
+
+	groupKey, group := rule.groupOf(apiLog)
+
+	rule.stateLock.Lock()
+	defer rule.stateLock.Unlock()
+
+	// new value rules: alert when a value shows up for the first time
+	newValue := ""
+	if rule.NewValueOf != "" {
+		newValue, _ = FieldValue(apiLog, rule.NewValueOf)
+
+		valueKey := groupKey + "|" + newValue
+		if rule.newValues[valueKey] {
+			return nil
+		}
+
+		if len(rule.newValues) >= maxGroups {
+			return nil
+		}
+		rule.newValues[valueKey] = true
+
+		if now.Sub(rule.loadedAt) < rule.LearningPeriod {
+			return nil
+		}
+
+		groupKey = valueKey
+		if group == nil {
+			group = make(map[string]string)
+		}
+		group[rule.NewValueOf] = newValue
+	}
+
+	rg, ok := rule.groups[groupKey]
+	if !ok {
+		if len(rule.groups) >= maxGroups {
+			return nil
+		}
+		rg = &ruleGroup{group: group}
+		rule.groups[groupKey] = rg
+	}
+	rg.lastSeen = now
+
+	// keep copies of the latest API logs as evidence (the API log itself goes on through the pipeline)
+	rg.samples = append(rg.samples, proto.Clone(apiLog).(*protobuf.APILog))
+	if len(rg.samples) > rule.Samples {
+		rg.samples = rg.samples[len(rg.samples)-rule.Samples:]
+	}
+
+	count := 1
+
+	// threshold rules: count matches within the window
+	if rule.Threshold > 0 {
+		rg.hits = append(rg.hits, now)
+
+		start := 0
+		for start < len(rg.hits) && now.Sub(rg.hits[start]) > rule.Window {
+			start++
+		}
+		rg.hits = rg.hits[start:]
+
+		count = len(rg.hits)
+		if count <= rule.Threshold {
+			return nil
+		}
+	}
+
+	if rule.Cooldown > 0 && !rg.lastAlert.IsZero() && now.Sub(rg.lastAlert) < rule.Cooldown {
+		return nil
+	}
+	rg.lastAlert = now
+
+	alert := &protobuf.Alert{
+		TimeStamp:   apiLog.TimeStamp,
+		Rule:        rule.Name,
+		Severity:    rule.Severity,
+		Description: rule.describe(group, count, newValue),
+
+		Group:     group,
+		Count:     uint64(count),
+		Threshold: uint64(rule.Threshold),
+		Window:    int64(rule.Window.Seconds()),
+
+		Samples: rg.samples,
+	}
+
+	// start counting again after an alert
+	rg.hits = nil
+	rg.samples = nil
+
+	return alert
+}
+
+// EvaluateAPILog Function that evaluates every alert rule with an API log
+func EvaluateAPILog(apiLog *protobuf.APILog) []*protobuf.Alert {
+	RuleEng.rulesLock.RLock()
+	rules := RuleEng.rules
+	RuleEng.rulesLock.RUnlock()
+
+	now := time.Now()
+	alerts := make([]*protobuf.Alert, 0)
+
+	for _, rule := range rules {
+		if alert := rule.evaluate(apiLog, now); alert != nil {
+			alerts = append(alerts, alert)
+		}
+	}
+
+	return alerts
+}
+
+// cleanUpGroups Function that forgets groups without matches for a while
+func (re *RuleEngine) cleanUpGroups(now time.Time) {
+	re.rulesLock.RLock()
+	rules := re.rules
+	re.rulesLock.RUnlock()
+
+	for _, rule := range rules {
+		idle := rule.Window
+		if rule.Cooldown > idle {
+			idle = rule.Cooldown
+		}
+		if idle < time.Minute {
+			idle = time.Minute
+		}
+
+		rule.stateLock.Lock()
+		for key, rg := range rule.groups {
+			if now.Sub(rg.lastSeen) > idle {
+				delete(rule.groups, key)
+			}
+		}
+		rule.stateLock.Unlock()
+	}
+}
+
+// == //
@@ -0,0 +1,60 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package rules
+
+import (
+	"testing"
+	"time"
+
+	"github.com/5gsec/SentryFlow/config"
+	"github.com/5gsec/SentryFlow/protobuf"
+)
+
+// TestEvaluateAPILog checks threshold rules (with groups and sample API logs)
+func TestEvaluateAPILog(t *testing.T) {
+	err := LoadRules([]config.AlertRuleConfig{{
+		Name:      "payments-5xx",
+		Condition: `dstWorkload == "payments" && responseCode >= 500`,
+		Window:    time.Minute,
+		Threshold: 2,
+		GroupBy:   []string{"srcNamespace"},
+		Samples:   2,
+	}})
+	if err != nil {
+		t.Fatalf("failed to load rules: %v", err)
+	}
+	defer func() { _ = LoadRules(nil) }()
+
+	apiLog := func(srcNamespace string, responseCode int32) *protobuf.APILog {
+		return &protobuf.APILog{SrcNamespace: srcNamespace, DstWorkloadName: "payments", DstWorkloadKind: "Deployment", ResponseCode: responseCode}
+	}
+
+	tests := []struct {
+		name   string
+		apiLog *protobuf.APILog
+		alerts int
+	}{
+		{"first match", apiLog("default", 500), 0},
+		{"no match", apiLog("default", 200), 0},
+		{"second match", apiLog("default", 502), 0},
+		{"other group", apiLog("other", 503), 0},
+		{"over threshold", apiLog("default", 503), 1},
+		{"after the alert", apiLog("default", 503), 0},
+	}
+
+	for _, tc := range tests {
+		alerts := EvaluateAPILog(tc.apiLog)
+		if len(alerts) != tc.alerts {
+			t.Fatalf("%s: expected %d alerts, got %d", tc.name, tc.alerts, len(alerts))
+		}
+
+		for _, alert := range alerts {
+			if alert.Count != 3 || alert.Group["srcNamespace"] != "default" {
+				t.Errorf("%s: unexpected alert %v", tc.name, alert)
+			}
+			if len(alert.Samples) != 2 || alert.Samples[1] == tc.apiLog || alert.Samples[1].ResponseCode != 503 {
+				t.Errorf("%s: expected copies of the latest 2 API logs, got %v", tc.name, alert.Samples)
+			}
+		}
+	}
+}
@@ -0,0 +1,566 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package rules
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/5gsec/SentryFlow/inventory"
+	"github.com/5gsec/SentryFlow/protobuf"
+)
+
+// == //
+//
+// Expressions are conditions over the fields of an API log, for example:
+//
+//	dstWorkload == "payments" && responseCode >= 500
+//	path =~ "/admin/*" && srcNamespace == "default"
+//	srcLabel["app"] in ["web", "mobile"] || requestHeaders["user-agent"] matches "^curl/"
+//	tags.team == "checkout"
+//
+// Operators: == != (numeric if both sides are numbers), < <= > >= (numeric fields and numbers only),
+// =~ !~ (glob, '*' matches any string), matches (regular expression), contains, in [..], && || ! and parentheses.
+// Field names consist of letters, digits, '_' and '.' (map keys with other characters go in brackets).
+//
+// == //
+
+// fieldGetters gives the value of each field of an API log as a string
+var fieldGetters = map[string]func(*protobuf.APILog) string{
+	"timeStamp": func(l *protobuf.APILog) string { return l.TimeStamp },
+
+	"srcNamespace":    func(l *protobuf.APILog) string { return l.SrcNamespace },
+	"srcName":         func(l *protobuf.APILog) string { return l.SrcName },
+	"srcWorkload":     func(l *protobuf.APILog) string { return inventory.SrcWorkload(l) },
+	"srcWorkloadKind": func(l *protobuf.APILog) string { return l.SrcWorkloadKind },
+	"srcType":         func(l *protobuf.APILog) string { return l.SrcType },
+	"srcIP":           func(l *protobuf.APILog) string { return l.SrcIP },
+	"srcPort":         func(l *protobuf.APILog) string { return l.SrcPort },
+
+	"dstNamespace":    func(l *protobuf.APILog) string { return l.DstNamespace },
+	"dstName":         func(l *protobuf.APILog) string { return l.DstName },
+	"dstWorkload":     func(l *protobuf.APILog) string { return inventory.DstWorkload(l) },
+	"dstWorkloadKind": func(l *protobuf.APILog) string { return l.DstWorkloadKind },
+	"dstType":         func(l *protobuf.APILog) string { return l.DstType },
+	"dstIP":           func(l *protobuf.APILog) string { return l.DstIP },
+	"dstPort":         func(l *protobuf.APILog) string { return l.DstPort },
+	"dstNetwork":      func(l *protobuf.APILog) string { return l.DstNetwork },
+	"dstHost":         func(l *protobuf.APILog) string { return l.DstHost },
+
+	"protocol":     func(l *protobuf.APILog) string { return l.Protocol },
+	"method":       func(l *protobuf.APILog) string { return l.Method },
+	"path":         func(l *protobuf.APILog) string { path, _ := inventory.SplitPath(l.Path); return path },
+	"query":        func(l *protobuf.APILog) string { _, query := inventory.SplitPath(l.Path); return query },
+	"pathTemplate": func(l *protobuf.APILog) string { tmpl, _ := inventory.PathTemplate(l.Path); return tmpl },
+	"endpoint":     func(l *protobuf.APILog) string { endpoint, _ := inventory.EndpointTemplate(l); return endpoint },
+	"responseCode": func(l *protobuf.APILog) string { return strconv.Itoa(int(l.ResponseCode)) },
+	"latency":      func(l *protobuf.APILog) string { return strconv.FormatUint(l.Latency, 10) },
+
+	"responseFlags": func(l *protobuf.APILog) string { return l.ResponseFlags },
+	"requestBytes":  func(l *protobuf.APILog) string { return strconv.FormatUint(l.RequestBytes, 10) },
+	"responseBytes": func(l *protobuf.APILog) string { return strconv.FormatUint(l.ResponseBytes, 10) },
+	"apiType":       func(l *protobuf.APILog) string { return l.ApiType },
+	"service":       func(l *protobuf.APILog) string { return l.Service },
+	"operation":     func(l *protobuf.APILog) string { return l.Operation },
+	"operationType": func(l *protobuf.APILog) string { return l.OperationType },
+	"grpcStatus":    func(l *protobuf.APILog) string { return l.GrpcStatus },
+}
+
+// numericFields gives the fields holding numbers (the only ones allowed with < <= > >=)
+var numericFields = map[string]bool{
+	"srcPort":       true,
+	"dstPort":       true,
+	"responseCode":  true,
+	"latency":       true,
+	"requestBytes":  true,
+	"responseBytes": true,
+}
+
+// mapGetters gives the maps of an API log that can be indexed (e.g., srcLabel["app"])
+var mapGetters = map[string]func(*protobuf.APILog) map[string]string{
+	"srcLabel":        func(l *protobuf.APILog) map[string]string { return l.SrcLabel },
+	"dstLabel":        func(l *protobuf.APILog) map[string]string { return l.DstLabel },
+	"requestHeaders":  func(l *protobuf.APILog) map[string]string { return l.RequestHeaders },
+	"responseHeaders": func(l *protobuf.APILog) map[string]string { return l.ResponseHeaders },
+	"tags":            func(l *protobuf.APILog) map[string]string { return l.Tags },
+}
+
+// == //
+
+// operand Interface
+type operand interface {
+	value(apiLog *protobuf.APILog) string
+}
+
+// literal Structure
+type literal string
+
+func (lit literal) value(_ *protobuf.APILog) string { return string(lit) }
+
+// field Structure
+type field struct {
+	getter  func(*protobuf.APILog) string
+	numeric bool
+}
+
+func (f field) value(apiLog *protobuf.APILog) string { return f.getter(apiLog) }
+
+// mapField Structure
+type mapField struct {
+	getter func(*protobuf.APILog) map[string]string
+	key    string
+}
+
+func (f mapField) value(apiLog *protobuf.APILog) string { return f.getter(apiLog)[f.key] }
+
+// isNumeric Function that tells if an operand always gives a number
+func isNumeric(op operand) bool {
+	switch op := op.(type) {
+	case literal:
+		_, err := strconv.ParseFloat(string(op), 64)
+		return err == nil
+	case field:
+		return op.numeric
+	}
+	return false
+}
+
+// FieldValue Function that gives the value of a field of an API log (e.g., dstWorkload, srcLabel.app)
+func FieldValue(apiLog *protobuf.APILog, name string) (string, bool) {
+	op, err := newField(name)
+	if err != nil {
+		return "", false
+	}
+	return op.value(apiLog), true
+}
+
+// newField Function
+func newField(name string) (operand, error) {
+	if getter, ok := fieldGetters[name]; ok {
+		return field{getter: getter, numeric: numericFields[name]}, nil
+	}
+
+	if mapName, key, ok := strings.Cut(name, "."); ok {
+		if getter, ok := mapGetters[mapName]; ok {
+			return mapField{getter: getter, key: key}, nil
+		}
+	}
+
+	return nil, fmt.Errorf("unknown field %q", name)
+}
+
+// == //
+
+// boolExpr Interface
+type boolExpr interface {
+	eval(apiLog *protobuf.APILog) bool
+}
+
+// andExpr Structure
+type andExpr struct{ left, right boolExpr }
+
+func (e andExpr) eval(apiLog *protobuf.APILog) bool {
+	return e.left.eval(apiLog) && e.right.eval(apiLog)
+}
+
+// orExpr Structure
+type orExpr struct{ left, right boolExpr }
+
+func (e orExpr) eval(apiLog *protobuf.APILog) bool {
+	return e.left.eval(apiLog) || e.right.eval(apiLog)
+}
+
+// notExpr Structure
+type notExpr struct{ expr boolExpr }
+
+func (e notExpr) eval(apiLog *protobuf.APILog) bool { return !e.expr.eval(apiLog) }
+
+// compareExpr Structure
+type compareExpr struct {
+	op          string
+	left, right operand
+}
+
+func (e compareExpr) eval(apiLog *protobuf.APILog) bool {
+	left := e.left.value(apiLog)
+	right := e.right.value(apiLog)
+
+	// compare numerically if both sides are numbers
+	leftNum, leftErr := strconv.ParseFloat(left, 64)
+	rightNum, rightErr := strconv.ParseFloat(right, 64)
+	numeric := leftErr == nil && rightErr == nil
+
+	switch e.op {
+	case "==":
+		if numeric {
+			return leftNum == rightNum
+		}
+		return left == right
+	case "!=":
+		if numeric {
+			return leftNum != rightNum
+		}
+		return left != right
+	case "<":
+		return numeric && leftNum < rightNum
+	case "<=":
+		return numeric && leftNum <= rightNum
+	case ">":
+		return numeric && leftNum > rightNum
+	case ">=":
+		return numeric && leftNum >= rightNum
+	case "contains":
+		return strings.Contains(left, right)
+	}
+
+	return false
+}
+
+// patternExpr Structure (=~, !~, matches)
+type patternExpr struct {
+	left    operand
+	pattern *regexp.Regexp
+	negate  bool
+}
+
+func (e patternExpr) eval(apiLog *protobuf.APILog) bool {
+	return e.pattern.MatchString(e.left.value(apiLog)) != e.negate
+}
+
+// inExpr Structure
+type inExpr struct {
+	left   operand
+	values map[string]bool
+}
+
+func (e inExpr) eval(apiLog *protobuf.APILog) bool {
+	return e.values[e.left.value(apiLog)]
+}
+
+// GlobToRegexp Function that converts a glob pattern ('*' for any string, '?' for any character) into a regular expression
+func GlobToRegexp(glob string) (*regexp.Regexp, error) {
+	pattern := regexp.QuoteMeta(glob)
+	pattern = strings.ReplaceAll(pattern, `\*`, ".*")
+	pattern = strings.ReplaceAll(pattern, `\?`, ".")
+	return regexp.Compile("^" + pattern + "$")
+}
+
+// == //
+
+// token Structure
+type token struct {
+	kind string // ident, string, number, op, eof
+	text string
+	pos  int
+}
+
+// isIdentRune Function that tells if a character can be in a field name ([A-Za-z0-9_.])
+func isIdentRune(c rune) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9') || c == '_' || c == '.'
+}
+
+// tokenize Function
+func tokenize(src string) ([]token, error) {
+	tokens := make([]token, 0)
+	runes := []rune(src)
+
+	for i := 0; i < len(runes); {
+		c := runes[i]
+
+		switch {
+		case unicode.IsSpace(c):
+			i++
+
+		case c == '"' || c == '\'':
+			var sb strings.Builder
+			j := i + 1
+			for ; j < len(runes) && runes[j] != c; j++ {
+				if runes[j] == '\\' && j+1 < len(runes) {
+					j++
+				}
+				sb.WriteRune(runes[j])
+			}
+			if j >= len(runes) {
+				return nil, fmt.Errorf("unterminated string at %d", i)
+			}
+			tokens = append(tokens, token{kind: "string", text: sb.String(), pos: i})
+			i = j + 1
+
+		case unicode.IsDigit(c) || (c == '-' && i+1 < len(runes) && unicode.IsDigit(runes[i+1])):
+			j := i + 1
+			for j < len(runes) && (unicode.IsDigit(runes[j]) || runes[j] == '.') {
+				j++
+			}
+			tokens = append(tokens, token{kind: "number", text: string(runes[i:j]), pos: i})
+			i = j
+
+		case isIdentRune(c) && c != '.' && (c < '0' || c > '9'):
+			j := i + 1
+			for j < len(runes) && isIdentRune(runes[j]) {
+				j++
+			}
+			tokens = append(tokens, token{kind: "ident", text: string(runes[i:j]), pos: i})
+			i = j
+
+		default:
+			matched := false
+			for _, op := range []string{"&&", "||", "==", "!=", "<=", ">=", "=~", "!~", "<", ">", "!", "(", ")", "[", "]", ","} {
+				if strings.HasPrefix(string(runes[i:]), op) {
+					tokens = append(tokens, token{kind: "op", text: op, pos: i})
+					i += len([]rune(op))
+					matched = true
+					break
+				}
+			}
+			if !matched {
+				return nil, fmt.Errorf("unexpected character %q at %d", c, i)
+			}
+		}
+	}
+
+	tokens = append(tokens, token{kind: "eof", pos: len(runes)})
+
+	return tokens, nil
+}
+
+// parser Structure
+type parser struct {
+	tokens []token
+	pos    int
+}
+
+func (p *parser) peek() token { return p.tokens[p.pos] }
+
+func (p *parser) next() token {
+	tok := p.tokens[p.pos]
+	if tok.kind != "eof" {
+		p.pos++
+	}
+	return tok
+}
+
+func (p *parser) expect(text string) error {
+	if tok := p.next(); tok.text != text || (tok.kind != "op" && tok.kind != "ident") {
+		return fmt.Errorf("expected %q at %d", text, tok.pos)
+	}
+	return nil
+}
+
+// parseOr Function
+func (p *parser) parseOr() (boolExpr, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.peek().kind == "op" && p.peek().text == "||" {
+		p.next()
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = orExpr{left: left, right: right}
+	}
+
+	return left, nil
+}
+
+// parseAnd Function
+func (p *parser) parseAnd() (boolExpr, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.peek().kind == "op" && p.peek().text == "&&" {
+		p.next()
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = andExpr{left: left, right: right}
+	}
+
+	return left, nil
+}
+
+// parseUnary Function
+func (p *parser) parseUnary() (boolExpr, error) {
+	tok := p.peek()
+
+	if tok.kind == "op" && tok.text == "!" {
+		p.next()
+		expr, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return notExpr{expr: expr}, nil
+	}
+
+	if tok.kind == "op" && tok.text == "(" {
+		p.next()
+		expr, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expect(")"); err != nil {
+			return nil, err
+		}
+		return expr, nil
+	}
+
+	return p.parseComparison()
+}
+
+// parseOperand Function
+func (p *parser) parseOperand() (operand, error) {
+	tok := p.next()
+
+	switch tok.kind {
+	case "string", "number":
+		return literal(tok.text), nil
+	case "ident":
+		// map access (e.g., srcLabel["app"])
+		if next := p.peek(); next.kind == "op" && next.text == "[" {
+			p.next()
+			key := p.next()
+			if key.kind != "string" {
+				return nil, fmt.Errorf("expected a string key at %d", key.pos)
+			}
+			if err := p.expect("]"); err != nil {
+				return nil, err
+			}
+			getter, ok := mapGetters[tok.text]
+			if !ok {
+				return nil, fmt.Errorf("unknown map %q at %d", tok.text, tok.pos)
+			}
+			return mapField{getter: getter, key: key.text}, nil
+		}
+
+		op, err := newField(tok.text)
+		if err != nil {
+			return nil, fmt.Errorf("%v at %d", err, tok.pos)
+		}
+		return op, nil
+	}
+
+	return nil, fmt.Errorf("expected a field or a value at %d", tok.pos)
+}
+
+// parseComparison Function
+func (p *parser) parseComparison() (boolExpr, error) {
+	left, err := p.parseOperand()
+	if err != nil {
+		return nil, err
+	}
+
+	tok := p.next()
+
+	switch {
+	case tok.kind == "op" && (tok.text == "=~" || tok.text == "!~"), tok.kind == "ident" && tok.text == "matches":
+		pattern := p.next()
+		if pattern.kind != "string" {
+			return nil, fmt.Errorf("expected a string pattern at %d", pattern.pos)
+		}
+
+		var re *regexp.Regexp
+		if tok.text == "matches" {
+			re, err = regexp.Compile(pattern.text)
+		} else {
+			re, err = GlobToRegexp(pattern.text)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid pattern at %d: %v", pattern.pos, err)
+		}
+
+		return patternExpr{left: left, pattern: re, negate: tok.text == "!~"}, nil
+
+	case tok.kind == "ident" && tok.text == "in":
+		if err := p.expect("["); err != nil {
+			return nil, err
+		}
+
+		values := make(map[string]bool)
+		for {
+			value := p.next()
+			if value.kind != "string" && value.kind != "number" {
+				return nil, fmt.Errorf("expected a value at %d", value.pos)
+			}
+			values[value.text] = true
+
+			sep := p.next()
+			if sep.kind == "op" && sep.text == "]" {
+				break
+			}
+			if sep.kind != "op" || sep.text != "," {
+				return nil, fmt.Errorf("expected ',' or ']' at %d", sep.pos)
+			}
+		}
+
+		return inExpr{left: left, values: values}, nil
+
+	case tok.kind == "op" && (tok.text == "==" || tok.text == "!=" || tok.text == "<" || tok.text == "<=" || tok.text == ">" || tok.text == ">="),
+		tok.kind == "ident" && tok.text == "contains":
+		right, err := p.parseOperand()
+		if err != nil {
+			return nil, err
+		}
+		if strings.ContainsAny(tok.text, "<>") && (!isNumeric(left) || !isNumeric(right)) {
+			return nil, fmt.Errorf("%s needs numbers or numeric fields at %d", tok.text, tok.pos)
+		}
+		return compareExpr{op: tok.text, left: left, right: right}, nil
+	}
+
+	return nil, fmt.Errorf("expected an operator at %d", tok.pos)
+}
+
+// == //
+
+// Expression Structure
+type Expression struct {
+	source string
+	expr   boolExpr
+}
+
+// Compile Function that compiles a condition over API logs
+func Compile(source string) (*Expression, error) {
+	if strings.TrimSpace(source) == "" {
+		return &Expression{source: source}, nil
+	}
+
+	tokens, err := tokenize(source)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &parser{tokens: tokens}
+
+	expr, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+
+	if tok := p.peek(); tok.kind != "eof" {
+		return nil, fmt.Errorf("unexpected %q at %d", tok.text, tok.pos)
+	}
+
+	return &Expression{source: source, expr: expr}, nil
+}
+
+// Match Function that evaluates an expression with an API log (an empty expression matches everything)
+func (e *Expression) Match(apiLog *protobuf.APILog) bool {
+	if e.expr == nil {
+		return true
+	}
+	return e.expr.eval(apiLog)
+}
+
+// String Function
+func (e *Expression) String() string {
+	return e.source
+}
+
+// == //
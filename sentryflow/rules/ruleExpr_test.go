@@ -0,0 +1,88 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package rules
+
+import (
+	"testing"
+
+	"github.com/5gsec/SentryFlow/protobuf"
+)
+
+// testAPILog is the API log the expressions are matched against
+var testAPILog = &protobuf.APILog{
+	SrcNamespace:    "default",
+	SrcLabel:        map[string]string{"app": "web"},
+	DstNamespace:    "payments",
+	DstWorkloadKind: "Deployment",
+	DstWorkloadName: "payments",
+	DstPort:         "8080",
+	Method:          "POST",
+	Path:            "/admin/users/42?verbose=true",
+	ResponseCode:    503,
+	Latency:         1200,
+	RequestHeaders:  map[string]string{"user-agent": "curl/8.0"},
+	Tags:            map[string]string{"team": "checkout"},
+}
+
+// TestCompileMatch checks expressions against an API log
+func TestCompileMatch(t *testing.T) {
+	tests := []struct {
+		condition string
+		expected  bool
+	}{
+		{``, true},
+		{`dstWorkload == "payments" && responseCode >= 500`, true},
+		{`dstWorkload == "payments" && responseCode < 500`, false},
+		{`responseCode == 503.0`, true},
+		{`latency > 1000 && dstPort <= 8080`, true},
+		{`path =~ "/admin/*" && srcNamespace == "default"`, true},
+		{`path !~ "/admin/*"`, false},
+		{`query == "verbose=true"`, true},
+		{`srcLabel["app"] in ["web", "mobile"]`, true},
+		{`srcLabel.app in ["mobile"]`, false},
+		{`requestHeaders["user-agent"] matches "^curl/"`, true},
+		{`tags.team == 'checkout'`, true},
+		{`method contains "OS"`, true},
+		{`!(method == "GET") && (srcNamespace == "other" || dstNamespace == "payments")`, true},
+		{`tags.missing == ""`, true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.condition, func(t *testing.T) {
+			expr, err := Compile(tc.condition)
+			if err != nil {
+				t.Fatalf("failed to compile: %v", err)
+			}
+			if matched := expr.Match(testAPILog); matched != tc.expected {
+				t.Errorf("expected %v, got %v", tc.expected, matched)
+			}
+		})
+	}
+}
+
+// TestCompileErrors checks that invalid expressions are rejected
+func TestCompileErrors(t *testing.T) {
+	tests := []string{
+		`dstWorkload ==`,
+		`unknownField == "x"`,
+		`path == "/unterminated`,
+		`path =~ 42`,
+		`path matches "("`,
+		`srcLabel[app] == "web"`,
+		`dstWorkload == "payments" extra`,
+		`srcLabel.app in ["web"`,
+		`requestHeaders.user-agent == "curl"`, // '-' is not allowed in field names
+		`path/admin == "x"`,                   // neither is '/'
+		`dstWorkload > "payments"`,            // ordering needs numbers
+		`responseCode >= "5xx"`,
+		`requestHeaders["content-length"] > 1000`,
+	}
+
+	for _, condition := range tests {
+		t.Run(condition, func(t *testing.T) {
+			if _, err := Compile(condition); err == nil {
+				t.Errorf("expected an error")
+			}
+		})
+	}
+}
@@ -2,6 +2,12 @@
 
 package types
 
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
 // == //
 
 // K8sResourceTypes
@@ -9,15 +15,20 @@ const (
 	K8sResourceTypeUnknown = 0
 	K8sResourceTypePod     = 1
 	K8sResourceTypeService = 2
+	K8sResourceTypeNode    = 3
 )
 
 // K8sResource Structure
 type K8sResource struct {
-	Type       uint8
-	Namespace  string
-	Name       string
-	Labels     map[string]string
-	Containers []string
+	Type        uint8
+	Namespace   string
+	Name        string
+	Labels      map[string]string
+	Annotations map[string]string
+	Containers  []string
+
+	WorkloadKind string // Kind of the top-level owner (e.g., Deployment)
+	WorkloadName string // Name of the top-level owner
 }
 
 // K8sResourceTypeToString Function
@@ -27,6 +38,8 @@ func K8sResourceTypeToString(resourceType uint8) string {
 		return "Pod"
 	case K8sResourceTypeService:
 		return "Service"
+	case K8sResourceTypeNode:
+		return "Node"
 	case K8sResourceTypeUnknown:
 		return "Unknown"
 	}
@@ -34,3 +47,48 @@ func K8sResourceTypeToString(resourceType uint8) string {
 }
 
 // == //
+
+// API types
+const (
+	APITypeREST    = "REST"
+	APITypeGRPC    = "gRPC"
+	APITypeGraphQL = "GraphQL"
+)
+
+// == //
+
+// Networks of destinations
+const (
+	NetworkCluster  = "cluster"
+	NetworkPrivate  = "private"
+	NetworkInternet = "internet"
+)
+
+// == //
+
+// ParseTimeStamp Function that converts the timestamp of a log into time.Time
+// Envoy gives Unix seconds while OpenTelemetry gives RFC3339 strings (e.g., [2024-01-01T00:00:00.000Z])
+func ParseTimeStamp(timeStamp string) time.Time {
+	if sec, err := strconv.ParseInt(timeStamp, 10, 64); err == nil {
+		return time.Unix(sec, 0)
+	}
+
+	if ts, err := time.Parse(time.RFC3339Nano, strings.Trim(timeStamp, "[]")); err == nil {
+		return ts
+	}
+
+	return time.Now()
+}
+
+// == //
+
+// Severities of findings
+const (
+	SeverityInfo     = "Info"
+	SeverityLow      = "Low"
+	SeverityMedium   = "Medium"
+	SeverityHigh     = "High"
+	SeverityCritical = "Critical"
+)
+
+// == //